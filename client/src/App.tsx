@@ -1,6 +1,6 @@
 import { useState, useEffect, useRef } from 'react';
-import api from './api';
-import type { Task, LoginResponse} from './types';
+import api, { subscribeEvents } from './api';
+import type { Task, TaskPage, LoginResponse} from './types';
 import { AxiosError } from 'axios';
 import { 
   CheckCircle2, Circle, Trash2, Plus, LogOut, 
@@ -23,8 +23,14 @@ const AuthForm = ({ onLogin }: { onLogin: (token: string) => void }) => {
         alert("Register Berhasil! Silakan Login.");
         setIsRegister(false);
       } else {
-        const res = await api.post<LoginResponse>('/login', { email: formData.email, password: formData.password });
-        onLogin(res.data.access_token);
+        let res = await api.post<LoginResponse>('/login', { email: formData.email, password: formData.password });
+        if (res.data.mfa_required) {
+          const code = prompt("Masukkan kode 2FA (atau recovery code):");
+          if (!code) return;
+          res = await api.post<LoginResponse>('/auth/mfa/verify', { mfa_token: res.data.mfa_token, code });
+        }
+        localStorage.setItem('refresh_token', res.data.refresh_token!);
+        onLogin(res.data.access_token!);
       }
     } catch (error) {
       alert("Gagal! Cek email/password.");
@@ -93,9 +99,15 @@ const CreateTaskForm = ({ onTaskCreated }: { onTaskCreated: () => void }) => {
     if (!title) return;
     try {
       const formattedDate = reminder ? new Date(reminder).toISOString() : null;
+      // "after-3d": berulang 3 hari setelah ditandai selesai, tanpa next_run
+      const completion = recurrence === 'after-3d';
+      const pattern = completion ? 'FREQ=DAILY;INTERVAL=3' : recurrence;
+      // Task recurring terjadwal butuh next_run: mulai dari waktu reminder, atau sekarang
+      const nextRun = recurrence && !completion ? (formattedDate ?? new Date().toISOString()) : null;
       await api.post('/tasks/', { 
         title, description: desc, priority, 
-        reminder_time: formattedDate, recurrence_pattern: recurrence 
+        reminder_time: formattedDate, recurrence_pattern: pattern, next_run: nextRun,
+        recurrence_mode: completion ? 'completion' : 'schedule'
       });
       setTitle(''); setDesc(''); setPriority('medium'); setReminder(''); setRecurrence('');
       onTaskCreated();
@@ -135,7 +147,10 @@ const CreateTaskForm = ({ onTaskCreated }: { onTaskCreated: () => void }) => {
           >
             <option value="">One-time</option>
             <option value="daily">Daily</option>
+            <option value="weekdays">Every weekday</option>
             <option value="weekly">Weekly</option>
+            <option value="monthly">Monthly</option>
+            <option value="after-3d">3 days after done</option>
           </select>
         </div>
 
@@ -296,6 +311,16 @@ function App() {
 
   useEffect(() => { if (token) fetchTasks(); }, [token]);
 
+  // Perubahan dari teammate & scheduler datang lewat stream; cukup refetch daftar task
+  useEffect(() => {
+    if (!token) return;
+    return subscribeEvents((type) => {
+      if (type === 'reset' || type.startsWith('task.') || type.startsWith('subtask.') || type.startsWith('recurrence.')) {
+        fetchTasks();
+      }
+    });
+  }, [token]);
+
   // Alarm Logic
   useEffect(() => {
     if (!token || tasks.length === 0) return;
@@ -320,8 +345,14 @@ function App() {
 
   const fetchTasks = async () => {
     try {
-      const res = await api.get<Task[]>('/tasks/');
-      setTasks(res.data || []);
+      const all: Task[] = [];
+      let cursor: string | undefined;
+      do {
+        const res = await api.get<TaskPage>('/tasks/', { params: { limit: 200, cursor } });
+        all.push(...(res.data.data || []));
+        cursor = res.data.next_cursor;
+      } while (cursor);
+      setTasks(all);
     } catch (err) {
       if ((err as AxiosError).response?.status === 401) handleLogout();
     }
@@ -335,7 +366,9 @@ function App() {
   };
 
   const handleLogout = () => {
+    api.post('/auth/logout').catch(() => {});
     localStorage.removeItem('token');
+    localStorage.removeItem('refresh_token');
     setToken(null);
     setTasks([]);
   };
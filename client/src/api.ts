@@ -1,4 +1,4 @@
-import axios from 'axios';
+import axios, { AxiosError, type InternalAxiosRequestConfig } from 'axios';
 
 const api = axios.create({
   baseURL: 'http://localhost:8080',
@@ -12,4 +12,81 @@ api.interceptors.request.use((config) => {
   return config;
 });
 
-export default api;
\ No newline at end of file
+// Access token berumur pendek: saat 401, tukar refresh token sekali lalu ulangi request
+let refreshing: Promise<string> | null = null;
+
+api.interceptors.response.use(undefined, async (error: AxiosError) => {
+  const original = error.config as (InternalAxiosRequestConfig & { _retried?: boolean }) | undefined;
+  const refreshToken = localStorage.getItem('refresh_token');
+  if (error.response?.status !== 401 || !original || original._retried || !refreshToken || original.url === '/auth/refresh') {
+    return Promise.reject(error);
+  }
+
+  original._retried = true;
+  refreshing ??= axios
+    .post(`${api.defaults.baseURL}/auth/refresh`, { refresh_token: refreshToken })
+    .then((res) => {
+      localStorage.setItem('token', res.data.access_token);
+      localStorage.setItem('refresh_token', res.data.refresh_token);
+      return res.data.access_token as string;
+    })
+    .finally(() => { refreshing = null; });
+
+  try {
+    const token = await refreshing;
+    original.headers.Authorization = `Bearer ${token}`;
+    return api(original);
+  } catch {
+    localStorage.removeItem('refresh_token');
+    return Promise.reject(error);
+  }
+});
+
+// subscribeEvents membuka stream SSE /stream. EventSource tidak bisa mengirim header
+// Authorization, jadi stream dibaca lewat fetch dan tersambung ulang dengan Last-Event-ID.
+// Event "reset" berarti riwayat hilang dan state harus di-refetch. Mengembalikan fungsi unsubscribe.
+export function subscribeEvents(onEvent: (type: string, data: unknown) => void): () => void {
+  const controller = new AbortController();
+  let lastEventId = '';
+
+  const connect = async () => {
+    while (!controller.signal.aborted) {
+      try {
+        const headers: Record<string, string> = { Authorization: `Bearer ${localStorage.getItem('token') ?? ''}` };
+        if (lastEventId) headers['Last-Event-ID'] = lastEventId;
+        const res = await fetch(`${api.defaults.baseURL}/stream`, { headers, signal: controller.signal });
+        if (!res.ok || !res.body) throw new Error(`stream responded with ${res.status}`);
+
+        const reader = res.body.pipeThrough(new TextDecoderStream()).getReader();
+        let buffer = '';
+        for (;;) {
+          const { value, done } = await reader.read();
+          if (done) break;
+          buffer += value;
+          let end: number;
+          while ((end = buffer.indexOf('\n\n')) >= 0) {
+            const frame = buffer.slice(0, end);
+            buffer = buffer.slice(end + 2);
+            let id = '', type = 'message', data = '';
+            for (const line of frame.split('\n')) {
+              if (line.startsWith('id: ')) id = line.slice(4);
+              else if (line.startsWith('event: ')) type = line.slice(7);
+              else if (line.startsWith('data: ')) data += line.slice(6);
+            }
+            if (!data) continue;
+            if (id) lastEventId = id;
+            onEvent(type, JSON.parse(data));
+          }
+        }
+      } catch {
+        if (controller.signal.aborted) return;
+      }
+      await new Promise((r) => setTimeout(r, 3000));
+    }
+  };
+  connect();
+
+  return () => controller.abort();
+}
+
+export default api;
@@ -2,6 +2,7 @@ export interface User {
   id: number;
   name: string;
   email: string;
+  timezone?: string;
 }
 
 export interface Subtask {
@@ -14,18 +15,79 @@ export interface Subtask {
 export interface Task {
   id: number;
   user_id: number;
+  workspace_id?: number | null;
+  assignee_ids?: number[];
+  due_at?: string | null;
+  due_all_day?: boolean;
+  is_overdue?: boolean;
+  project_id?: number | null;
   title: string;
   description: string;
   status: string;
   priority: 'low' | 'medium' | 'high';
   labels: string[];
   reminder_time?: string;
-  recurrence_pattern?: string;
+  recurrence_pattern?: string; // RRULE, mis. "FREQ=WEEKLY;BYDAY=MO,WE"
+  recurrence_start?: string | null;
+  next_run?: string | null;
+  recurrence_mode?: 'schedule' | 'completion';
+  missed_run_policy?: 'all' | 'latest' | 'skip';
+  recurrence_parent_id?: number | null; // instance: template yang membuatnya
+  occurrence_at?: string | null;
   subtasks: Subtask[];
   created_at: string;
 }
 
+// Reminder relatif punya offset_minutes; remind_at null selama task tanpa tenggat
+export interface TaskReminder {
+  id: number;
+  task_id: number;
+  remind_at: string | null;
+  offset_minutes: number | null;
+  created_at: string;
+  updated_at: string;
+}
+
+export interface TaskPage {
+  data: Task[];
+  next_cursor?: string;
+}
+
+// Jika 2FA aktif, /login hanya mengembalikan mfa_required + mfa_token
 export interface LoginResponse {
-  access_token: string;
-  user: { id: number; name: string; email: string };
+  access_token?: string;
+  refresh_token?: string;
+  expires_in?: number;
+  user?: { id: number; name: string; email: string };
+  mfa_required?: boolean;
+  mfa_token?: string;
+}
+
+export interface Notification {
+  id: number;
+  user_id: number;
+  type: 'reminder' | 'assigned' | 'recurring_instance';
+  task_id: number | null;
+  actor_id: number | null;
+  title: string;
+  body: string;
+  read_at: string | null;
+  created_at: string;
+}
+
+export interface NotificationPage {
+  data: Notification[];
+  next_cursor?: string;
+}
+
+export interface RecurrencePreview {
+  rule: string;
+  timezone: string;
+  occurrences: string[];
+}
+
+export interface TaskSeries {
+  template: Task;
+  instances: Task[];
+  upcoming: string[];
 }
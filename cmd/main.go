@@ -5,13 +5,20 @@ import (
     "fmt"
     "log"
     "os"
+    "strings"
     "time"
 
+    "simple-task-manager/internal/core/domain"
     "simple-task-manager/internal/core/usecase"
     handler "simple-task-manager/internal/infra/delivery/http"
     "simple-task-manager/internal/infra/delivery/middleware"
+    "simple-task-manager/internal/infra/event"
+    "simple-task-manager/internal/infra/jobs"
+    "simple-task-manager/internal/infra/mail"
     "simple-task-manager/internal/infra/repository"
     "simple-task-manager/internal/infra/scheduler"
+    "simple-task-manager/internal/infra/stream"
+    "simple-task-manager/internal/infra/webhook"
 
     "github.com/gin-contrib/cors"
     "github.com/gin-gonic/gin"
@@ -45,10 +52,30 @@ import (
 
 // Config holds environment-backed configuration.
 type Config struct {
-    DBURL     string
-    JWTSecret string
-    Port      string
-    Timeout   time.Duration
+    DBURL      string
+    JWTSecret  string
+    Port       string
+    Timeout    time.Duration
+    AccessTTL  time.Duration
+    RefreshTTL time.Duration
+
+    AppURL               string
+    RequireVerifiedEmail bool
+    MFAIssuer            string
+    Mail                 MailConfig
+    ReminderWebhookURL   string   // opsional; reminder juga di-POST ke URL ini
+    AdminEmails          []string // user yang boleh mengakses /admin (antrian job)
+}
+
+// MailConfig selects and configures the outgoing mail driver.
+type MailConfig struct {
+    Driver   string // "smtp" or "file" (default, for local development)
+    Dir      string // output directory for the file driver; empty logs only
+    From     string
+    SMTPHost string
+    SMTPPort string
+    SMTPUser string
+    SMTPPass string
 }
 
 func main() {
@@ -56,18 +83,107 @@ func main() {
     dbPool := mustInitDB(cfg.DBURL)
     defer dbPool.Close()
 
-    startCron(dbPool)
-
     userRepo := repository.NewUserRepository(dbPool)
     taskRepo := repository.NewTaskRepository(dbPool)
+    statusRepo := repository.NewStatusRepository(dbPool)
+    sessionRepo := repository.NewSessionRepository(dbPool)
+    userTokenRepo := repository.NewUserTokenRepository(dbPool)
+    accessTokenRepo := repository.NewAccessTokenRepository(dbPool)
+    projectRepo := repository.NewProjectRepository(dbPool)
+    workspaceRepo := repository.NewWorkspaceRepository(dbPool)
+    mailer := mustInitMailer(cfg.Mail)
+    eventBus := event.NewBus()
+    reminderRepo := repository.NewReminderRepository(dbPool)
+    taskReminderRepo := repository.NewTaskReminderRepository(dbPool)
+    notificationRepo := repository.NewNotificationRepository(dbPool)
+    taskEventRepo := repository.NewTaskEventRepository(dbPool)
+    webhookRepo := repository.NewWebhookRepository(dbPool)
+    recurrenceRepo := repository.NewRecurrenceRepository(dbPool)
+    jobRepo := repository.NewJobRepository(dbPool)
+    webhookSender := webhook.NewHTTPSender(webhook.SenderConfig{Timeout: 10 * time.Second})
 
-    userUseCase := usecase.NewUserUsecase(userRepo, cfg.Timeout, cfg.JWTSecret)
-    taskUseCase := usecase.NewTaskUsecase(taskRepo, cfg.Timeout)
+    // Antrian job background; email dari request (verifikasi, reset password, undangan)
+    // dikirim lewat antrian supaya gangguan SMTP dicoba ulang, bukan menggagalkan request
+    jobQueue := jobs.NewQueue(jobRepo, jobs.Config{})
+    queuedMailer := jobs.RegisterMailer(jobQueue, mailer, jobs.HandlerConfig{
+        Concurrency: 4,
+        Timeout:     30 * time.Second,
+        MaxAttempts: 8,
+        BaseBackoff: time.Minute,
+        MaxBackoff:  6 * time.Hour,
+    })
+
+    userUseCase := usecase.NewUserUsecase(userRepo, sessionRepo, userTokenRepo, queuedMailer, cfg.Timeout, usecase.AuthConfig{
+        Secret:               cfg.JWTSecret,
+        AccessTTL:            cfg.AccessTTL,
+        RefreshTTL:           cfg.RefreshTTL,
+        AppURL:               cfg.AppURL,
+        VerifyEmailTTL:       48 * time.Hour,
+        ResetPasswordTTL:     time.Hour,
+        RequireVerifiedEmail: cfg.RequireVerifiedEmail,
+        MFAIssuer:            cfg.MFAIssuer,
+        MFAChallengeTTL:      5 * time.Minute,
+    })
+    taskUseCase := usecase.NewTaskUsecase(taskRepo, statusRepo, projectRepo, workspaceRepo, userRepo, taskReminderRepo, cfg.Timeout)
+    workflowUseCase := usecase.NewWorkflowUsecase(statusRepo, cfg.Timeout)
+    accessTokenUseCase := usecase.NewAccessTokenUsecase(accessTokenRepo, cfg.Timeout)
+    projectUseCase := usecase.NewProjectUsecase(projectRepo, statusRepo, workspaceRepo, cfg.Timeout)
+    notificationUseCase := usecase.NewNotificationUsecase(notificationRepo, eventBus, cfg.Timeout)
+    webhookUseCase := usecase.NewWebhookUsecase(webhookRepo, workspaceRepo, webhookSender, cfg.Timeout)
+    workspaceUseCase := usecase.NewWorkspaceUsecase(workspaceRepo, userRepo, queuedMailer, cfg.Timeout, usecase.WorkspaceConfig{
+        AppURL:        cfg.AppURL,
+        InvitationTTL: 7 * 24 * time.Hour,
+    })
+    jobUseCase := usecase.NewJobUsecase(jobRepo, userRepo, cfg.AdminEmails, cfg.Timeout)
 
     userHandler := &handler.UserHandler{UserUseCase: userUseCase}
     taskHandler := &handler.TaskHandler{TaskUseCase: taskUseCase}
+    workflowHandler := &handler.WorkflowHandler{WorkflowUseCase: workflowUseCase}
+    accessTokenHandler := &handler.AccessTokenHandler{AccessTokenUseCase: accessTokenUseCase}
+    projectHandler := &handler.ProjectHandler{ProjectUseCase: projectUseCase}
+    workspaceHandler := &handler.WorkspaceHandler{WorkspaceUseCase: workspaceUseCase}
+    notificationHandler := &handler.NotificationHandler{NotificationUseCase: notificationUseCase}
+    webhookHandler := &handler.WebhookHandler{WebhookUseCase: webhookUseCase}
+    jobHandler := &handler.JobHandler{JobUseCase: jobUseCase}
+
+    // Producer notifikasi in-app: assignment, reminder channel in_app, instance recurring.
+    // Durable: jika gagal disimpan, relay outbox mengirim ulang event-nya
+    eventBus.SubscribeDurable(domain.EventTaskAssigned, notificationUseCase.HandleEvent)
+    eventBus.SubscribeDurable(domain.EventReminderDue, notificationUseCase.HandleEvent)
+    eventBus.SubscribeDurable(domain.EventRecurrenceInstanceCreated, notificationUseCase.HandleEvent)
+
+    // Stream real-time menerima semua event
+    streamHub := stream.NewHub(workspaceRepo, 1000)
+    eventBus.SubscribeAll(streamHub.Handle)
+    streamHandler := &handler.StreamHandler{Hub: streamHub, Heartbeat: 25 * time.Second}
+
+    // Webhook keluar: event dimasukkan ke antrian webhook_deliveries, dikirim oleh cron
+    for _, eventType := range domain.WebhookEvents {
+        eventBus.SubscribeDurable(eventType, webhookUseCase.HandleEvent)
+    }
+    webhookDispatcher := scheduler.NewWebhookDispatcher(webhookRepo, webhookSender, scheduler.WebhookConfig{})
+
+    // Event task & subtask masuk outbox bersama perubahannya; relay meneruskannya ke eventBus
+    outboxRelay := scheduler.NewOutboxRelay(taskEventRepo, eventBus, scheduler.OutboxConfig{})
+
+    reminderChannels := []domain.ReminderChannel{
+        scheduler.NewEmailChannel(mailer, cfg.AppURL),
+        scheduler.NewInAppChannel(eventBus),
+    }
+    if cfg.ReminderWebhookURL != "" {
+        reminderChannels = append(reminderChannels, scheduler.NewWebhookChannel(cfg.ReminderWebhookURL, 10*time.Second))
+    }
+    reminderDispatcher := scheduler.NewReminderDispatcher(reminderRepo, scheduler.ReminderConfig{}, reminderChannels...)
 
-    r := setupRouter(cfg.JWTSecret, userHandler, taskHandler)
+    // Scheduler recurring aman dijalankan di beberapa replica: task diklaim dengan FOR UPDATE SKIP LOCKED
+    taskScheduler := scheduler.NewTaskScheduler(recurrenceRepo, scheduler.RecurrenceConfig{})
+
+    startCron(taskScheduler, outboxRelay, reminderDispatcher, webhookDispatcher)
+    go jobQueue.Run(context.Background())
+
+    authMiddleware := middleware.AuthMiddleware(cfg.JWTSecret, userUseCase, accessTokenUseCase)
+
+    r := setupRouter(authMiddleware, userHandler, taskHandler, workflowHandler, accessTokenHandler, projectHandler, workspaceHandler, notificationHandler, streamHandler, webhookHandler, jobHandler)
 
     log.Printf("Server running on port %s", cfg.Port)
     if err := r.Run(":" + cfg.Port); err != nil {
@@ -97,11 +213,65 @@ func mustLoadConfig() Config {
     }
 
     return Config{
-        DBURL:     dbURL,
-        JWTSecret: jwtSecret,
-        Port:      port,
-        Timeout:   2 * time.Second,
+        DBURL:      dbURL,
+        JWTSecret:  jwtSecret,
+        Port:       port,
+        Timeout:    2 * time.Second,
+        AccessTTL:  durationEnv("ACCESS_TOKEN_TTL", 15*time.Minute),
+        RefreshTTL: durationEnv("REFRESH_TOKEN_TTL", 30*24*time.Hour),
+
+        AppURL:               stringEnv("APP_URL", "http://localhost:3000"),
+        RequireVerifiedEmail: os.Getenv("REQUIRE_VERIFIED_EMAIL") == "true",
+        MFAIssuer:            stringEnv("MFA_ISSUER", "Simple Task Manager"),
+        Mail: MailConfig{
+            Driver:   stringEnv("MAIL_DRIVER", "file"),
+            Dir:      os.Getenv("MAIL_DIR"),
+            From:     stringEnv("MAIL_FROM", "Simple Task Manager <no-reply@localhost>"),
+            SMTPHost: os.Getenv("SMTP_HOST"),
+            SMTPPort: stringEnv("SMTP_PORT", "587"),
+            SMTPUser: os.Getenv("SMTP_USERNAME"),
+            SMTPPass: os.Getenv("SMTP_PASSWORD"),
+        },
+        ReminderWebhookURL: os.Getenv("REMINDER_WEBHOOK_URL"),
+        AdminEmails:        strings.Split(os.Getenv("ADMIN_EMAILS"), ","),
+    }
+}
+
+// stringEnv reads key from env, falling back to def when unset.
+func stringEnv(key, def string) string {
+    if v := os.Getenv(key); v != "" {
+        return v
+    }
+    return def
+}
+
+// mustInitMailer builds the configured mail driver or fatals.
+func mustInitMailer(cfg MailConfig) domain.Mailer {
+    switch cfg.Driver {
+    case "smtp":
+        if cfg.SMTPHost == "" {
+            log.Fatal("SMTP_HOST is not set while MAIL_DRIVER=smtp")
+        }
+        return mail.NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUser, cfg.SMTPPass, cfg.From)
+    case "file":
+        return mail.NewFileMailer(cfg.Dir, cfg.From)
+    default:
+        log.Fatalf("unknown MAIL_DRIVER %q", cfg.Driver)
+        return nil
+    }
+}
+
+// durationEnv reads a Go duration (e.g. "15m") from env, falling back to def.
+func durationEnv(key string, def time.Duration) time.Duration {
+    raw := os.Getenv(key)
+    if raw == "" {
+        return def
+    }
+    d, err := time.ParseDuration(raw)
+    if err != nil {
+        log.Fatalf("%s is not a valid duration: %v", key, err)
     }
+    return d
 }
 
 // mustInitDB initializes a pgx pool or fatals.
@@ -113,42 +283,170 @@ func mustInitDB(dbURL string) *pgxpool.Pool {
     return dbPool
 }
 
-// startCron initializes and starts the recurring task scheduler.
-func startCron(dbPool *pgxpool.Pool) {
-    taskScheduler := scheduler.NewTaskScheduler(dbPool)
-
+// startCron initializes and starts the recurring task scheduler, outbox relay, reminder and webhook dispatchers.
+func startCron(taskScheduler *scheduler.TaskScheduler, outboxRelay *scheduler.OutboxRelay, reminderDispatcher *scheduler.ReminderDispatcher, webhookDispatcher *scheduler.WebhookDispatcher) {
     c := cron.New()
-    _, _ = c.AddFunc("@every 1m", func() {
-        taskScheduler.ProcessRecurringTasks()
-    })
+    _, _ = c.AddJob("@every 1m", cron.NewChain(cron.SkipIfStillRunning(cron.DefaultLogger)).
+        Then(cron.FuncJob(func() { taskScheduler.ProcessRecurringTasks() })))
+    // SkipIfStillRunning: tick berikutnya dilewati selama pengiriman sebelumnya belum selesai
+    _, _ = c.AddJob("@every 1s", cron.NewChain(cron.SkipIfStillRunning(cron.DefaultLogger)).
+        Then(cron.FuncJob(outboxRelay.RelayEvents)))
+    _, _ = c.AddJob("@every 30s", cron.NewChain(cron.SkipIfStillRunning(cron.DefaultLogger)).
+        Then(cron.FuncJob(reminderDispatcher.DispatchDueReminders)))
+    _, _ = c.AddJob("@every 15s", cron.NewChain(cron.SkipIfStillRunning(cron.DefaultLogger)).
+        Then(cron.FuncJob(webhookDispatcher.DispatchPending)))
     c.Start()
 
     fmt.Println(">>> Cron Scheduler Started!")
 }
 
 // setupRouter wires middlewares, routes, and swagger.
-func setupRouter(jwtSecret string, userHandler *handler.UserHandler, taskHandler *handler.TaskHandler) *gin.Engine {
+func setupRouter(authMiddleware gin.HandlerFunc, userHandler *handler.UserHandler, taskHandler *handler.TaskHandler, workflowHandler *handler.WorkflowHandler, accessTokenHandler *handler.AccessTokenHandler, projectHandler *handler.ProjectHandler, workspaceHandler *handler.WorkspaceHandler, notificationHandler *handler.NotificationHandler, streamHandler *handler.StreamHandler, webhookHandler *handler.WebhookHandler, jobHandler *handler.JobHandler) *gin.Engine {
     r := gin.Default()
     r.Use(corsMiddleware())
+    r.Use(middleware.ErrorHandler())
 
     // Public routes
     r.POST("/register", userHandler.Register)
     r.POST("/login", userHandler.Login)
+    r.POST("/auth/refresh", userHandler.Refresh)
+    r.POST("/auth/verify-email", userHandler.VerifyEmail)
+    r.POST("/auth/verify-email/resend", userHandler.ResendVerification)
+    r.POST("/auth/password/forgot", userHandler.ForgotPassword)
+    r.POST("/auth/password/reset", userHandler.ResetPassword)
+    r.POST("/auth/mfa/verify", userHandler.VerifyMFA)
+
+    // Protected auth routes (hanya session login, bukan personal access token)
+    r.POST("/auth/logout", authMiddleware, middleware.RequireSession(), userHandler.Logout)
+
+    // Profil user (nama & zona waktu)
+    me := r.Group("/me")
+    me.Use(authMiddleware, middleware.RequireSession())
+    {
+        me.GET("", userHandler.GetMe)
+        me.PATCH("", userHandler.UpdateMe)
+    }
+
+    // Enrollment & pengelolaan 2FA (TOTP)
+    mfa := r.Group("/auth/mfa")
+    mfa.Use(authMiddleware, middleware.RequireSession())
+    {
+        mfa.POST("/setup", userHandler.SetupMFA)
+        mfa.POST("/confirm", userHandler.ConfirmMFA)
+        mfa.POST("/disable", userHandler.DisableMFA)
+        mfa.POST("/recovery-codes", userHandler.RegenerateRecoveryCodes)
+    }
+
+    // Personal access token untuk script & integrasi
+    tokens := r.Group("/auth/tokens")
+    tokens.Use(authMiddleware, middleware.RequireSession())
+    {
+        tokens.POST("", accessTokenHandler.Create)
+        tokens.GET("", accessTokenHandler.List)
+        tokens.DELETE("/:id", accessTokenHandler.Revoke)
+    }
 
     // Protected task routes
     protected := r.Group("/tasks")
-    protected.Use(middleware.AuthMiddleware(jwtSecret))
+    protected.Use(authMiddleware, middleware.RequireScope(domain.ScopeTasksRead, domain.ScopeTasksWrite))
     {
         protected.POST("/", taskHandler.Create)
         protected.GET("/", taskHandler.Fetch)
+        protected.GET("/due", taskHandler.Due)
+        protected.GET("/recurrence/preview", taskHandler.PreviewRecurrence)
         protected.PUT("/:id", taskHandler.UpdateStatus)
+        protected.PATCH("/:id", taskHandler.Patch)
         protected.DELETE("/:id", taskHandler.Delete)
+        protected.GET("/:id/series", taskHandler.Series)
         protected.POST("/:id/subtasks", taskHandler.AddSubtask)
+        protected.POST("/:id/assignees", taskHandler.Assign)
+        protected.DELETE("/:id/assignees/:user_id", taskHandler.Unassign)
+        protected.GET("/:id/reminders", taskHandler.ListReminders)
+        protected.POST("/:id/reminders", taskHandler.AddReminder)
+        protected.PUT("/:id/reminders/:reminder_id", taskHandler.UpdateReminder)
+        protected.DELETE("/:id/reminders/:reminder_id", taskHandler.DeleteReminder)
     }
 
     // Subtask routes (protected per-handler)
-    r.PUT("/subtasks/:sub_id", middleware.AuthMiddleware(jwtSecret), taskHandler.ToggleSubtask)
-    r.DELETE("/subtasks/:sub_id", middleware.AuthMiddleware(jwtSecret), taskHandler.DeleteSubtask)
+    tasksScope := middleware.RequireScope(domain.ScopeTasksRead, domain.ScopeTasksWrite)
+    r.PUT("/subtasks/:sub_id", authMiddleware, tasksScope, taskHandler.ToggleSubtask)
+    r.DELETE("/subtasks/:sub_id", authMiddleware, tasksScope, taskHandler.DeleteSubtask)
+
+    // Projects (task list) memakai scope yang sama dengan tasks
+    projects := r.Group("/projects")
+    projects.Use(authMiddleware, tasksScope)
+    {
+        projects.POST("", projectHandler.Create)
+        projects.GET("", projectHandler.List)
+        projects.GET("/:id", projectHandler.Get)
+        projects.PATCH("/:id", projectHandler.Patch)
+        projects.DELETE("/:id", projectHandler.Delete)
+    }
+
+    // Workspace tim: anggota, role & undangan
+    workspaces := r.Group("/workspaces")
+    workspaces.Use(authMiddleware, middleware.RequireScope(domain.ScopeWorkspacesRead, domain.ScopeWorkspacesWrite))
+    {
+        workspaces.POST("", workspaceHandler.Create)
+        workspaces.GET("", workspaceHandler.List)
+        workspaces.GET("/:id", workspaceHandler.Get)
+        workspaces.PATCH("/:id", workspaceHandler.Rename)
+        workspaces.DELETE("/:id", workspaceHandler.Delete)
+        workspaces.GET("/:id/members", workspaceHandler.ListMembers)
+        workspaces.PATCH("/:id/members/:user_id", workspaceHandler.UpdateMember)
+        workspaces.DELETE("/:id/members/:user_id", workspaceHandler.RemoveMember)
+        workspaces.POST("/:id/invitations", workspaceHandler.Invite)
+        workspaces.GET("/:id/invitations", workspaceHandler.ListInvitations)
+        workspaces.DELETE("/:id/invitations/:invitation_id", workspaceHandler.RevokeInvitation)
+    }
+
+    // Menerima undangan hanya lewat session login, bukan personal access token
+    r.POST("/invitations/accept", authMiddleware, middleware.RequireSession(), workspaceHandler.AcceptInvitation)
+
+    // Inbox notifikasi in-app
+    notifications := r.Group("/notifications")
+    notifications.Use(authMiddleware, middleware.RequireScope(domain.ScopeNotificationsRead, domain.ScopeNotificationsWrite))
+    {
+        notifications.GET("", notificationHandler.List)
+        notifications.GET("/unread-count", notificationHandler.UnreadCount)
+        notifications.POST("/read-all", notificationHandler.MarkAllRead)
+        notifications.POST("/:id/read", notificationHandler.MarkRead)
+    }
+
+    // Webhook keluar dengan payload bertanda tangan HMAC
+    webhooks := r.Group("/webhooks")
+    webhooks.Use(authMiddleware, middleware.RequireScope(domain.ScopeWebhooksRead, domain.ScopeWebhooksWrite))
+    {
+        webhooks.POST("", webhookHandler.Create)
+        webhooks.GET("", webhookHandler.List)
+        webhooks.GET("/:id", webhookHandler.Get)
+        webhooks.PATCH("/:id", webhookHandler.Patch)
+        webhooks.DELETE("/:id", webhookHandler.Delete)
+        webhooks.GET("/:id/deliveries", webhookHandler.ListDeliveries)
+        webhooks.POST("/:id/ping", webhookHandler.Ping)
+    }
+
+    // Admin: antrian job background (hanya session login milik email terverifikasi di ADMIN_EMAILS)
+    admin := r.Group("/admin/jobs")
+    admin.Use(authMiddleware, middleware.RequireSession())
+    {
+        admin.GET("", jobHandler.List)
+        admin.GET("/stats", jobHandler.Stats)
+        admin.GET("/:id", jobHandler.Get)
+        admin.POST("/:id/retry", jobHandler.Retry)
+    }
+
+    // Server-Sent Events: perubahan task, subtask & notifikasi secara real-time
+    r.GET("/stream", authMiddleware, middleware.RequireScope(domain.ScopeTasksRead, domain.ScopeTasksWrite), streamHandler.Stream)
+
+    // Workflow status per user (kolom kanban)
+    statuses := r.Group("/statuses")
+    statuses.Use(authMiddleware, middleware.RequireScope(domain.ScopeStatusesRead, domain.ScopeStatusesWrite))
+    {
+        statuses.GET("", workflowHandler.Get)
+        statuses.PUT("", workflowHandler.Save)
+        statuses.DELETE("", workflowHandler.Reset)
+    }
 
     // Swagger
     r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
@@ -160,7 +458,7 @@ func setupRouter(jwtSecret string, userHandler *handler.UserHandler, taskHandler
 func corsMiddleware() gin.HandlerFunc {
     config := cors.DefaultConfig()
     config.AllowAllOrigins = true
-    config.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
-    config.AllowHeaders = []string{"Origin", "Content-Type", "Authorization", "Accept"}
+    config.AllowMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+    config.AllowHeaders = []string{"Origin", "Content-Type", "Authorization", "Accept", "Last-Event-ID"}
     return cors.New(config)
 }
@@ -4,6 +4,10 @@ CREATE TABLE IF NOT EXISTS users (
     name varchar(255) NOT NULL,
     email varchar(255) UNIQUE NOT NULL,
     password varchar(255) NOT NULL,
+    email_verified_at timestamptz,
+    totp_secret varchar(64),               -- base32, terisi sejak enrollment 2FA dimulai
+    totp_last_step bigint,                 -- time-step TOTP terakhir yang dipakai (anti replay)
+    mfa_enabled_at timestamptz,
     created_at timestamptz NOT NULL DEFAULT (now()),
     updated_at timestamptz NOT NULL DEFAULT (now())
 );
@@ -25,6 +29,11 @@ CREATE TABLE IF NOT EXISTS tasks (
     recurrence_pattern varchar(20),        -- 'daily', 'weekly', 'monthly'
     next_run timestamptz,                  -- kapan task ini dijadwalkan ulang
 
+    -- Workflow status (dicap oleh usecase)
+    status_changed_at timestamptz,
+    started_at timestamptz,
+    completed_at timestamptz,
+
     -- Metadata
     created_at timestamptz NOT NULL DEFAULT (now()),
     updated_at timestamptz NOT NULL DEFAULT (now()),
@@ -48,3 +57,323 @@ CREATE TABLE IF NOT EXISTS subtasks (
         REFERENCES tasks(id) 
         ON DELETE CASCADE
 );
+
+-- 4. Index untuk filter & pagination GET /tasks
+CREATE INDEX IF NOT EXISTS idx_tasks_user_created ON tasks(user_id, created_at, id);
+CREATE INDEX IF NOT EXISTS idx_tasks_user_updated ON tasks(user_id, updated_at, id);
+CREATE INDEX IF NOT EXISTS idx_subtasks_task_id ON subtasks(task_id);
+
+-- 5. Workflow status custom per user (kolom kanban)
+CREATE TABLE IF NOT EXISTS task_statuses (
+    id bigserial PRIMARY KEY,
+    user_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    key varchar(50) NOT NULL,
+    name varchar(100) NOT NULL,
+    category varchar(20) NOT NULL,         -- 'todo', 'in_progress', 'done'
+    position int NOT NULL DEFAULT 0,
+    UNIQUE (user_id, key)
+);
+
+CREATE TABLE IF NOT EXISTS task_status_transitions (
+    user_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    from_status varchar(50) NOT NULL,
+    to_status varchar(50) NOT NULL,
+    PRIMARY KEY (user_id, from_status, to_status)
+);
+
+-- Riwayat perpindahan status untuk laporan cycle time
+CREATE TABLE IF NOT EXISTS task_status_history (
+    id bigserial PRIMARY KEY,
+    task_id bigint NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    from_status varchar(50) NOT NULL,
+    to_status varchar(50) NOT NULL,
+    changed_at timestamptz NOT NULL DEFAULT (now())
+);
+CREATE INDEX IF NOT EXISTS idx_task_status_history_task ON task_status_history(task_id, changed_at);
+
+-- 6. Session login (token family) & refresh token (hanya hash yang disimpan)
+CREATE TABLE IF NOT EXISTS auth_sessions (
+    id bigserial PRIMARY KEY,
+    user_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at timestamptz NOT NULL DEFAULT (now()),
+    expires_at timestamptz NOT NULL,
+    revoked_at timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_auth_sessions_user ON auth_sessions(user_id);
+
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id bigserial PRIMARY KEY,
+    session_id bigint NOT NULL REFERENCES auth_sessions(id) ON DELETE CASCADE,
+    token_hash char(64) UNIQUE NOT NULL,   -- sha256 hex
+    expires_at timestamptz NOT NULL,
+    used_at timestamptz,                   -- terisi saat dirotasi
+    created_at timestamptz NOT NULL DEFAULT (now())
+);
+
+-- 7. Token sekali pakai untuk verifikasi email, reset password & challenge 2FA (hanya hash yang disimpan)
+CREATE TABLE IF NOT EXISTS user_tokens (
+    id bigserial PRIMARY KEY,
+    user_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    purpose varchar(30) NOT NULL,          -- 'verify_email', 'reset_password', 'mfa_challenge'
+    token_hash char(64) UNIQUE NOT NULL,
+    expires_at timestamptz NOT NULL,
+    used_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT (now())
+);
+CREATE INDEX IF NOT EXISTS idx_user_tokens_user ON user_tokens(user_id, purpose);
+
+-- 8. Recovery code 2FA (sekali pakai, hanya hash yang disimpan)
+CREATE TABLE IF NOT EXISTS mfa_recovery_codes (
+    id bigserial PRIMARY KEY,
+    user_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash char(64) NOT NULL,
+    used_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT (now()),
+    UNIQUE (user_id, code_hash)
+);
+
+-- 9. Personal access token untuk script & integrasi (hanya hash yang disimpan)
+CREATE TABLE IF NOT EXISTS personal_access_tokens (
+    id bigserial PRIMARY KEY,
+    user_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name varchar(100) NOT NULL,
+    scopes text[] NOT NULL,                -- 'tasks:read', 'tasks:write', ...
+    token_hash char(64) UNIQUE NOT NULL,   -- sha256 hex dari token lengkap (termasuk prefix stm_pat_)
+    hint varchar(8) NOT NULL,              -- karakter terakhir token untuk ditampilkan
+    expires_at timestamptz NOT NULL,
+    last_used_at timestamptz,
+    revoked_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT (now())
+);
+CREATE INDEX IF NOT EXISTS idx_personal_access_tokens_user ON personal_access_tokens(user_id);
+
+-- 10. Projects (task list); task boleh tanpa project
+CREATE TABLE IF NOT EXISTS projects (
+    id bigserial PRIMARY KEY,
+    user_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name varchar(255) NOT NULL,
+    description text,
+    default_priority varchar(20),          -- NULL = default global (medium)
+    default_status varchar(50),            -- NULL = status awal workflow user
+    archived_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT (now()),
+    updated_at timestamptz NOT NULL DEFAULT (now())
+);
+CREATE INDEX IF NOT EXISTS idx_projects_user ON projects(user_id);
+
+-- Dibuat setelah tabel projects karena foreign key; menghapus project tidak menghapus task-nya
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS project_id bigint REFERENCES projects(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_tasks_project ON tasks(project_id) WHERE project_id IS NOT NULL;
+
+-- 11. Workspaces (tim); task & project dengan workspace_id terlihat oleh semua anggota
+CREATE TABLE IF NOT EXISTS workspaces (
+    id bigserial PRIMARY KEY,
+    name varchar(255) NOT NULL,
+    owner_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at timestamptz NOT NULL DEFAULT (now()),
+    updated_at timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE IF NOT EXISTS workspace_members (
+    workspace_id bigint NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    user_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role varchar(20) NOT NULL CHECK (role IN ('owner', 'admin', 'member', 'viewer')),
+    joined_at timestamptz NOT NULL DEFAULT (now()),
+    PRIMARY KEY (workspace_id, user_id)
+);
+CREATE INDEX IF NOT EXISTS idx_workspace_members_user ON workspace_members(user_id);
+
+CREATE TABLE IF NOT EXISTS workspace_invitations (
+    id bigserial PRIMARY KEY,
+    workspace_id bigint NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    email varchar(255) NOT NULL,
+    role varchar(20) NOT NULL CHECK (role IN ('admin', 'member', 'viewer')),
+    token_hash char(64) UNIQUE NOT NULL,
+    invited_by bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at timestamptz NOT NULL,
+    accepted_at timestamptz,
+    revoked_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT (now())
+);
+CREATE INDEX IF NOT EXISTS idx_workspace_invitations_workspace ON workspace_invitations(workspace_id);
+
+-- Menghapus workspace ikut menghapus task & project di dalamnya
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS workspace_id bigint REFERENCES workspaces(id) ON DELETE CASCADE;
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS workspace_id bigint REFERENCES workspaces(id) ON DELETE CASCADE;
+CREATE INDEX IF NOT EXISTS idx_tasks_workspace ON tasks(workspace_id) WHERE workspace_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_projects_workspace ON projects(workspace_id) WHERE workspace_id IS NOT NULL;
+
+-- 12. Assignee task (bisa lebih dari satu); assignee harus bisa melihat task-nya
+CREATE TABLE IF NOT EXISTS task_assignees (
+    task_id bigint NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    assigned_by bigint REFERENCES users(id) ON DELETE SET NULL,
+    assigned_at timestamptz NOT NULL DEFAULT (now()),
+    PRIMARY KEY (task_id, user_id)
+);
+CREATE INDEX IF NOT EXISTS idx_task_assignees_user ON task_assignees(user_id);
+
+-- 13. Tenggat task & zona waktu user
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS due_at timestamptz;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS due_all_day boolean NOT NULL DEFAULT false; -- true: due_at disimpan sebagai tanggal 00:00 UTC
+CREATE INDEX IF NOT EXISTS idx_tasks_due ON tasks(due_at) WHERE due_at IS NOT NULL AND completed_at IS NULL;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS timezone varchar(64) NOT NULL DEFAULT 'UTC';
+
+-- 14. Status pengiriman reminder, satu baris per (reminder, penerima, channel) supaya tidak pernah terkirim dua kali
+CREATE TABLE IF NOT EXISTS reminder_deliveries (
+    id bigserial PRIMARY KEY,
+    task_id bigint NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    remind_at timestamptz NOT NULL,
+    user_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    channel varchar(20) NOT NULL,          -- 'email', 'webhook', 'in_app'
+    status varchar(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sending', 'sent', 'failed')),
+    attempts int NOT NULL DEFAULT 0,
+    next_attempt_at timestamptz NOT NULL DEFAULT (now()),
+    last_error text,
+    sent_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT (now()),
+    updated_at timestamptz NOT NULL DEFAULT (now()),
+    UNIQUE (task_id, remind_at, user_id, channel)
+);
+CREATE INDEX IF NOT EXISTS idx_reminder_deliveries_pending ON reminder_deliveries(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_tasks_reminder ON tasks(reminder_time) WHERE reminder_time IS NOT NULL AND completed_at IS NULL;
+
+-- 15. Banyak reminder per task: absolut, atau relatif terhadap due_at (offset_minutes sebelum tenggat)
+CREATE TABLE IF NOT EXISTS task_reminders (
+    id bigserial PRIMARY KEY,
+    task_id bigint NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    remind_at timestamptz,                 -- NULL untuk reminder relatif selama task tanpa tenggat
+    offset_minutes int CHECK (offset_minutes >= 0),
+    created_at timestamptz NOT NULL DEFAULT (now()),
+    updated_at timestamptz NOT NULL DEFAULT (now()),
+    CHECK (offset_minutes IS NOT NULL OR remind_at IS NOT NULL)
+);
+CREATE INDEX IF NOT EXISTS idx_task_reminders_task ON task_reminders(task_id);
+CREATE INDEX IF NOT EXISTS idx_task_reminders_remind_at ON task_reminders(remind_at) WHERE remind_at IS NOT NULL;
+
+-- 16. Inbox notifikasi in-app
+CREATE TABLE IF NOT EXISTS notifications (
+    id bigserial PRIMARY KEY,
+    user_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type varchar(50) NOT NULL,             -- 'reminder', 'assigned', 'recurring_instance'
+    task_id bigint REFERENCES tasks(id) ON DELETE CASCADE,
+    actor_id bigint REFERENCES users(id) ON DELETE SET NULL,
+    title varchar(255) NOT NULL,
+    body text NOT NULL DEFAULT '',
+    read_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT (now())
+);
+CREATE INDEX IF NOT EXISTS idx_notifications_user ON notifications(user_id, id DESC);
+CREATE INDEX IF NOT EXISTS idx_notifications_unread ON notifications(user_id) WHERE read_at IS NULL;
+
+-- 17. Outgoing webhook per user atau per workspace, dengan antrian & log pengiriman
+CREATE TABLE IF NOT EXISTS webhooks (
+    id bigserial PRIMARY KEY,
+    user_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    workspace_id bigint REFERENCES workspaces(id) ON DELETE CASCADE,
+    url text NOT NULL,
+    events text[] NOT NULL,
+    secret varchar(255) NOT NULL,          -- disimpan mentah karena dibutuhkan untuk HMAC
+    active boolean NOT NULL DEFAULT true,
+    created_at timestamptz NOT NULL DEFAULT (now()),
+    updated_at timestamptz NOT NULL DEFAULT (now())
+);
+CREATE INDEX IF NOT EXISTS idx_webhooks_user ON webhooks(user_id) WHERE workspace_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_webhooks_workspace ON webhooks(workspace_id);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id bigserial PRIMARY KEY,
+    webhook_id bigint NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    event_type varchar(50) NOT NULL,
+    payload jsonb NOT NULL,
+    status varchar(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sending', 'succeeded', 'failed')),
+    attempts int NOT NULL DEFAULT 0,
+    next_attempt_at timestamptz NOT NULL DEFAULT (now()),
+    response_status int,
+    last_error text,
+    delivered_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT (now()),
+    updated_at timestamptz NOT NULL DEFAULT (now())
+);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_pending ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook ON webhook_deliveries(webhook_id, id DESC);
+
+-- 18. Outbox event task: ditulis dalam transaksi yang sama dengan perubahan task/subtask,
+-- dikirim relay ke subscriber urut id (at-least-once). task_id tanpa FK supaya event delete tetap ada.
+CREATE TABLE IF NOT EXISTS task_events (
+    id bigserial PRIMARY KEY,
+    event_type varchar(50) NOT NULL,
+    task_id bigint NOT NULL,
+    payload jsonb NOT NULL,
+    attempts int NOT NULL DEFAULT 0,
+    last_error text,
+    published_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT (now())
+);
+CREATE INDEX IF NOT EXISTS idx_task_events_pending ON task_events(id) WHERE published_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_task_events_published ON task_events(published_at) WHERE published_at IS NOT NULL;
+
+-- 19. Recurrence berbasis RRULE (RFC 5545); pola lama daily/weekly/monthly tetap dibaca sebagai alias
+ALTER TABLE tasks ALTER COLUMN recurrence_pattern TYPE text;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS recurrence_start timestamptz; -- DTSTART: acuan INTERVAL & COUNT
+UPDATE tasks SET recurrence_start = next_run WHERE recurrence_pattern <> '' AND recurrence_start IS NULL;
+
+-- 20. Kebijakan kemunculan terlewat (server mati, dsb.): all, latest (default), skip
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS missed_run_policy varchar(10) NOT NULL DEFAULT 'latest'
+    CHECK (missed_run_policy IN ('all', 'latest', 'skip'));
+
+-- 21. Seri recurring: instance menunjuk template-nya & kemunculan yang diwakilinya.
+-- Unik per (template, kemunculan) supaya scheduler tidak pernah membuat instance ganda.
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS recurrence_parent_id bigint REFERENCES tasks(id) ON DELETE SET NULL;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS occurrence_at timestamptz;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tasks_recurrence_occurrence ON tasks(recurrence_parent_id, occurrence_at)
+    WHERE recurrence_parent_id IS NOT NULL;
+
+-- 22. Recurrence berbasis penyelesaian: instance berikutnya dibuat saat task selesai, bukan oleh scheduler
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS recurrence_mode varchar(10) NOT NULL DEFAULT 'schedule'
+    CHECK (recurrence_mode IN ('schedule', 'completion'));
+
+-- 23. Klaim scheduler recurring (FOR UPDATE SKIP LOCKED) hanya menyentuh task terjadwal yang jatuh tempo
+CREATE INDEX IF NOT EXISTS idx_tasks_recurrence_due ON tasks(next_run)
+    WHERE recurrence_pattern <> '' AND recurrence_mode = 'schedule' AND next_run IS NOT NULL;
+
+-- 24. Antrian job background generik (email, dsb.): diklaim worker dengan FOR UPDATE SKIP LOCKED,
+-- dicoba ulang dengan backoff, lalu berhenti di status dead (dead letter) sampai di-retry admin
+CREATE TABLE IF NOT EXISTS jobs (
+    id bigserial PRIMARY KEY,
+    type varchar(100) NOT NULL,
+    payload jsonb NOT NULL DEFAULT '{}',
+    priority int NOT NULL DEFAULT 0,
+    status varchar(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'done', 'dead')),
+    attempts int NOT NULL DEFAULT 0,
+    max_attempts int NOT NULL DEFAULT 5 CHECK (max_attempts > 0),
+    run_at timestamptz NOT NULL DEFAULT (now()),
+    last_error text,
+    locked_at timestamptz,
+    finished_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT (now()),
+    updated_at timestamptz NOT NULL DEFAULT (now())
+);
+CREATE INDEX IF NOT EXISTS idx_jobs_pending ON jobs(type, priority DESC, run_at, id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_jobs_running ON jobs(locked_at) WHERE status = 'running';
+CREATE INDEX IF NOT EXISTS idx_jobs_status ON jobs(status, id DESC);
+
+-- 25. Subscriber durable idempoten terhadap event outbox yang dikirim ulang relay (at-least-once).
+-- NULL (event tanpa outbox, mis. reminder & ping) tidak saling bentrok.
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS event_id bigint;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_notifications_event ON notifications(user_id, event_id);
+ALTER TABLE webhook_deliveries ADD COLUMN IF NOT EXISTS event_id bigint;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_deliveries_event ON webhook_deliveries(webhook_id, event_id);
+
+-- 26. Retensi job selesai per tipe (mis. email.send yang payload-nya berisi token sekali pakai)
+CREATE INDEX IF NOT EXISTS idx_jobs_finished ON jobs(type, finished_at) WHERE status IN ('done', 'dead');
+
+-- 27. Email unik tanpa membedakan huruf besar/kecil (usecase menyimpan & mencari email dalam huruf kecil).
+-- Gagal dibuat jika masih ada akun ganda beda kapitalisasi; gabungkan akun tersebut lebih dulu.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_lower ON users (lower(email));
+
+-- 28. Event outbox yang terus gagal ditandai gagal (dead letter) setelah beberapa percobaan
+-- supaya tidak menahan pengiriman event sesudahnya
+ALTER TABLE task_events ADD COLUMN IF NOT EXISTS failed_at timestamptz;
+CREATE INDEX IF NOT EXISTS idx_task_events_relay ON task_events(id) WHERE published_at IS NULL AND failed_at IS NULL;
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// Scope personal access token, satu pasang read/write per grup route
+const (
+	ScopeTasksRead          = "tasks:read"
+	ScopeTasksWrite         = "tasks:write"
+	ScopeStatusesRead       = "statuses:read"
+	ScopeStatusesWrite      = "statuses:write"
+	ScopeWorkspacesRead     = "workspaces:read"
+	ScopeWorkspacesWrite    = "workspaces:write"
+	ScopeNotificationsRead  = "notifications:read"
+	ScopeNotificationsWrite = "notifications:write"
+	ScopeWebhooksRead       = "webhooks:read"
+	ScopeWebhooksWrite      = "webhooks:write"
+)
+
+// AccessTokenScopes adalah daftar scope yang boleh diminta saat membuat token
+var AccessTokenScopes = []string{
+	ScopeTasksRead, ScopeTasksWrite,
+	ScopeStatusesRead, ScopeStatusesWrite,
+	ScopeWorkspacesRead, ScopeWorkspacesWrite,
+	ScopeNotificationsRead, ScopeNotificationsWrite,
+	ScopeWebhooksRead, ScopeWebhooksWrite,
+}
+
+// AccessTokenPrefix membedakan personal access token dari JWT di header Authorization
+const AccessTokenPrefix = "stm_pat_"
+
+// PersonalAccessToken adalah API key milik user untuk script dan integrasi.
+// Hanya hash-nya yang disimpan; token mentah hanya ditampilkan sekali saat dibuat.
+type PersonalAccessToken struct {
+	ID         int64      `json:"id"`
+	UserID     int64      `json:"-"`
+	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes"`
+	TokenHash  string     `json:"-"`
+	Hint       string     `json:"hint"` // beberapa karakter terakhir token, untuk dikenali di UI
+	ExpiresAt  time.Time  `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// Active bernilai true jika token belum dicabut dan belum kedaluwarsa
+func (t *PersonalAccessToken) Active(now time.Time) bool {
+	return t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
+
+// HasScope mengecek scope token; scope write sebuah resource juga mencakup read-nya
+func HasScope(granted []string, scope string) bool {
+	for _, g := range granted {
+		if g == scope {
+			return true
+		}
+		if resource, ok := strings.CutSuffix(scope, ":read"); ok && g == resource+":write" {
+			return true
+		}
+	}
+	return false
+}
+
+type AccessTokenRepository interface {
+	Create(ctx context.Context, t *PersonalAccessToken) error
+	ListByUser(ctx context.Context, userID int64) ([]PersonalAccessToken, error)
+	GetByHash(ctx context.Context, hash string) (*PersonalAccessToken, error)
+	// Revoke mengembalikan ErrNotFound jika token tidak ada atau bukan milik user
+	Revoke(ctx context.Context, id int64, userID int64, at time.Time) error
+	TouchLastUsed(ctx context.Context, id int64, at time.Time) error
+}
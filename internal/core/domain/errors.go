@@ -1,7 +1,108 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
 
+// Sentinel error. Delivery layer memetakan setiap kategori ke HTTP status,
+// jadi usecase cukup membungkus salah satu sentinel ini (langsung, lewat
+// *Error, atau lewat tipe error khusus di bawah).
 var ErrNotFound = errors.New("record not found")
 
-var ErrConflict = errors.New("record already exists")
\ No newline at end of file
+var ErrConflict = errors.New("record already exists")
+
+var ErrForbidden = errors.New("you don't have access to this resource")
+
+var ErrBadParamInput = errors.New("given param is not valid")
+
+var ErrUnauthorized = errors.New("authentication required")
+
+var ErrRateLimited = errors.New("too many requests")
+
+// Error adalah error domain dengan pesan yang aman ditampilkan ke client.
+// Kind adalah salah satu sentinel di atas sehingga errors.Is tetap berlaku.
+type Error struct {
+	Kind    error
+	Message string
+}
+
+func (e *Error) Error() string { return e.Message }
+
+func (e *Error) Unwrap() error { return e.Kind }
+
+func newError(kind error, format string, args ...interface{}) error {
+	return &Error{Kind: kind, Message: fmt.Sprintf(format, args...)}
+}
+
+// NotFound membuat error kategori ErrNotFound dengan pesan custom
+func NotFound(format string, args ...interface{}) error {
+	return newError(ErrNotFound, format, args...)
+}
+
+// Forbidden membuat error kategori ErrForbidden dengan pesan custom
+func Forbidden(format string, args ...interface{}) error {
+	return newError(ErrForbidden, format, args...)
+}
+
+// Conflict membuat error kategori ErrConflict dengan pesan custom
+func Conflict(format string, args ...interface{}) error {
+	return newError(ErrConflict, format, args...)
+}
+
+// Unauthorized membuat error kategori ErrUnauthorized dengan pesan custom
+func Unauthorized(format string, args ...interface{}) error {
+	return newError(ErrUnauthorized, format, args...)
+}
+
+// FieldError menjelaskan kenapa satu field input ditolak
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError berisi satu atau lebih FieldError. errors.Is(err, ErrBadParamInput) bernilai true.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+// NewValidationError membuat ValidationError untuk satu field
+func NewValidationError(field string, format string, args ...interface{}) *ValidationError {
+	return &ValidationError{Fields: []FieldError{{Field: field, Message: fmt.Sprintf(format, args...)}}}
+}
+
+// Add menambahkan field error; dipakai saat memvalidasi banyak field sekaligus
+func (e *ValidationError) Add(field string, format string, args ...interface{}) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// OrNil mengembalikan nil jika tidak ada field error, supaya bisa langsung di-return
+func (e *ValidationError) OrNil() error {
+	if e == nil || len(e.Fields) == 0 {
+		return nil
+	}
+	return e
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		parts[i] = f.Field + ": " + f.Message
+	}
+	return "validation failed: " + strings.Join(parts, "; ")
+}
+
+func (e *ValidationError) Unwrap() error { return ErrBadParamInput }
+
+// RateLimitedError dikembalikan jika client harus menunggu sebelum mencoba lagi
+type RateLimitedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitedError) Error() string {
+	return fmt.Sprintf("too many requests, retry after %s", e.RetryAfter.Round(time.Second))
+}
+
+func (e *RateLimitedError) Unwrap() error { return ErrRateLimited }
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Jenis event yang dipancarkan usecase untuk dikonsumsi lapisan lain (notifikasi, webhook, dsb.)
+const (
+	EventTaskCreated               = "task.created"
+	EventTaskUpdated               = "task.updated"
+	EventTaskDeleted               = "task.deleted"
+	EventTaskAssigned              = "task.assigned"
+	EventTaskUnassigned            = "task.unassigned"
+	EventSubtaskCreated            = "subtask.created"
+	EventSubtaskToggled            = "subtask.toggled"
+	EventSubtaskDeleted            = "subtask.deleted"
+	EventRecurrenceInstanceCreated = "recurrence.instance_created" // scheduler membuat instance baru dari task recurring
+	EventNotificationCreated       = "notification.created"
+)
+
+// Event adalah kejadian penting pada sebuah task
+type Event struct {
+	ID          int64          `json:"id,omitempty"` // ID baris outbox; tetap sama bila event dikirim ulang, dipakai subscriber durable untuk dedup
+	Type        string         `json:"type"`
+	TaskID      int64          `json:"task_id"`
+	ActorID     int64          `json:"actor_id"`               // user yang memicu event
+	WorkspaceID *int64         `json:"workspace_id,omitempty"` // diisi untuk task workspace; semua anggotanya ikut melihat
+	Recipients  []int64        `json:"recipients,omitempty"`   // user yang terdampak langsung
+	Data        map[string]any `json:"data,omitempty"`
+	OccurredAt  time.Time      `json:"occurred_at"`
+}
+
+// EventHandler memproses satu event
+type EventHandler func(ctx context.Context, e Event) error
+
+// EventPublisher meneruskan event ke subscriber. Publish dipanggil setelah
+// perubahan tersimpan; kegagalannya tidak membatalkan perubahan tersebut.
+type EventPublisher interface {
+	Publish(ctx context.Context, e Event) error
+}
+
+// TaskEventRepository adalah outbox task_events. Event task & subtask ditulis
+// TaskRepository dalam transaksi yang sama dengan perubahannya, lalu dikirim
+// ke subscriber oleh relay secara berurutan (at-least-once).
+type TaskEventRepository interface {
+	// Drain memanggil fn untuk event yang belum terkirim, urut ID, paling banyak limit.
+	// Berhenti di error pertama supaya urutan terjaga; event itu dicoba lagi di putaran
+	// berikutnya. Event yang sudah gagal maxAttempts kali ditandai gagal (failed_at, dead
+	// letter) dan dilewati supaya tidak menahan event sesudahnya. Mengembalikan jumlah event
+	// yang selesai (terkirim atau gagal). Hanya satu Drain yang berjalan sekaligus; yang lain
+	// langsung kembali 0.
+	Drain(ctx context.Context, limit, maxAttempts int, fn EventHandler) (int, error)
+	// PurgePublished menghapus event yang sudah terkirim atau gagal sebelum `before`
+	PurgePublished(ctx context.Context, before time.Time) (int64, error)
+}
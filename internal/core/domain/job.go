@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Status job di antrian background
+const (
+	JobPending = "pending" // menunggu run_at, termasuk job yang akan dicoba ulang
+	JobRunning = "running"
+	JobDone    = "done"
+	JobDead    = "dead" // dead letter: percobaan habis atau error permanen; bisa di-retry admin
+)
+
+var JobStatuses = []string{JobPending, JobRunning, JobDone, JobDead}
+
+// JobSendEmail mengirim satu Mail. Body-nya bisa berisi token sekali pakai (verifikasi,
+// reset password, undangan), jadi tidak ditampilkan ke admin dan dihapus setelah selesai.
+const JobSendEmail = "email.send"
+
+// Job adalah satu pekerjaan background. Payload berupa JSON yang ditafsirkan oleh handler
+// untuk Type-nya; job dengan Priority lebih besar diambil lebih dulu.
+type Job struct {
+	ID          int64           `json:"id"`
+	Type        string          `json:"type"`
+	Payload     json.RawMessage `json:"payload" swaggertype:"object"`
+	Priority    int             `json:"priority"`
+	Status      string          `json:"status"`
+	Attempts    int             `json:"attempts"`
+	MaxAttempts int             `json:"max_attempts"`
+	RunAt       time.Time       `json:"run_at"` // tidak diambil worker sebelum waktu ini
+	LastError   *string         `json:"last_error"`
+	LockedAt    *time.Time      `json:"locked_at"` // kapan worker mengklaim job yang sedang running
+	FinishedAt  *time.Time      `json:"finished_at"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+// JobFilter untuk GET /admin/jobs, terbaru lebih dulu
+type JobFilter struct {
+	Type   string
+	Status string
+	Limit  int
+	Cursor string // opaque, didapat dari JobPage.NextCursor
+}
+
+type JobPage struct {
+	Data       []Job  `json:"data"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// JobCount adalah jumlah job per (type, status) untuk ringkasan admin
+type JobCount struct {
+	Type   string `json:"type"`
+	Status string `json:"status"`
+	Count  int64  `json:"count"`
+}
+
+type JobRepository interface {
+	Enqueue(ctx context.Context, job *Job) error
+	// Claim mengubah job pending jatuh tempo bertipe jobType menjadi running (FOR UPDATE SKIP LOCKED),
+	// urut priority tertinggi lalu run_at, dan menaikkan attempts-nya
+	Claim(ctx context.Context, jobType string, now time.Time, limit int) ([]Job, error)
+	// Finish menyimpan hasil percobaan: status, run_at berikutnya, error dan finished_at. Bernilai
+	// false jika klaimnya (LockedAt) sudah tidak berlaku karena job diantrikan ulang setelah lease habis.
+	Finish(ctx context.Context, job *Job) (bool, error)
+	// RequeueStale mengembalikan job yang running sejak sebelum `before` (worker mati) ke pending,
+	// atau ke dead jika percobaannya sudah habis
+	RequeueStale(ctx context.Context, before time.Time) (int64, error)
+
+	GetByID(ctx context.Context, id int64) (*Job, error)
+	List(ctx context.Context, filter JobFilter) (*JobPage, error)
+	Stats(ctx context.Context) ([]JobCount, error)
+	// Retry mengantrikan ulang job dead dengan attempts direset; false jika job tidak dead
+	Retry(ctx context.Context, id int64, at time.Time) (bool, error)
+	// PurgeFinished menghapus job bertipe jobType yang selesai (done atau dead) sebelum `before`
+	PurgeFinished(ctx context.Context, jobType string, before time.Time) (int64, error)
+}
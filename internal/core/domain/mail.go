@@ -0,0 +1,15 @@
+package domain
+
+import "context"
+
+// Mail adalah email plain-text yang dikirim oleh aplikasi
+type Mail struct {
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// Mailer adalah kontrak pengiriman email; implementasinya ada di internal/infra/mail
+type Mailer interface {
+	Send(ctx context.Context, msg Mail) error
+}
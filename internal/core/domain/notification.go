@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Jenis notifikasi in-app
+const (
+	NotificationReminder  = "reminder"
+	NotificationAssigned  = "assigned"
+	NotificationRecurring = "recurring_instance"
+)
+
+// Notification adalah satu item di inbox in-app user
+type Notification struct {
+	ID        int64      `json:"id"`
+	UserID    int64      `json:"user_id"`
+	Type      string     `json:"type"`
+	TaskID    *int64     `json:"task_id"`
+	ActorID   *int64     `json:"actor_id"` // nil untuk notifikasi dari sistem (reminder, scheduler)
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	ReadAt    *time.Time `json:"read_at"`
+	CreatedAt time.Time  `json:"created_at"`
+	EventID   *int64     `json:"-"` // event outbox asal; mencegah notifikasi ganda saat event dikirim ulang
+}
+
+// NotificationFilter untuk GET /notifications, terbaru lebih dulu
+type NotificationFilter struct {
+	UserID     int64
+	UnreadOnly bool
+	Limit      int
+	Cursor     string // opaque, didapat dari NotificationPage.NextCursor
+}
+
+type NotificationPage struct {
+	Data       []Notification `json:"data"`
+	NextCursor string         `json:"next_cursor,omitempty"` // kosong jika sudah halaman terakhir
+}
+
+type NotificationRepository interface {
+	// Create mengembalikan ErrConflict jika notifikasi untuk (UserID, EventID) sudah ada, dan
+	// ErrNotFound jika task atau penerimanya sudah dihapus
+	Create(ctx context.Context, n *Notification) error
+	Fetch(ctx context.Context, filter NotificationFilter) (*NotificationPage, error)
+	// MarkRead bernilai false jika notifikasi tidak ada atau bukan milik user
+	MarkRead(ctx context.Context, id int64, userID int64, at time.Time) (bool, error)
+	MarkAllRead(ctx context.Context, userID int64, at time.Time) (int64, error)
+	CountUnread(ctx context.Context, userID int64) (int64, error)
+}
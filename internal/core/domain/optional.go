@@ -0,0 +1,33 @@
+package domain
+
+import "encoding/json"
+
+// Optional membedakan field JSON yang tidak dikirim dengan field yang
+// dikirim bernilai null. Set bernilai true jika key ada di payload;
+// Value bernilai nil jika key tersebut dikirim sebagai null.
+type Optional[T any] struct {
+	Set   bool
+	Value *T
+}
+
+// UnmarshalJSON hanya dipanggil encoding/json jika key ada di payload,
+// termasuk ketika nilainya null.
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	o.Set = true
+	if string(data) == "null" {
+		o.Value = nil
+		return nil
+	}
+
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	o.Value = &v
+	return nil
+}
+
+// Null bernilai true jika field dikirim secara eksplisit sebagai null
+func (o Optional[T]) Null() bool {
+	return o.Set && o.Value == nil
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Project mengelompokkan task (task list). Task boleh tidak punya project.
+type Project struct {
+	ID          int64  `json:"id"`
+	UserID      int64  `json:"user_id"`      // pembuat project
+	WorkspaceID *int64 `json:"workspace_id"` // null = project pribadi
+	Name        string `json:"name"`
+	Description string `json:"description"`
+
+	// Dipakai saat task baru dibuat di project ini tanpa priority/status eksplisit
+	DefaultPriority string `json:"default_priority"` // kosong = default global (medium)
+	DefaultStatus   string `json:"default_status"`   // kosong = status awal workflow
+
+	// Project yang diarsipkan tetap bisa dibaca, tetapi tidak bisa menerima task baru
+	ArchivedAt *time.Time `json:"archived_at"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Archived bernilai true jika project sudah diarsipkan
+func (p *Project) Archived() bool {
+	return p.ArchivedAt != nil
+}
+
+// ProjectPatch berisi perubahan parsial untuk PATCH /projects/:id
+type ProjectPatch struct {
+	Name            Optional[string] `json:"name" swaggertype:"string"`
+	Description     Optional[string] `json:"description" swaggertype:"string"`
+	DefaultPriority Optional[string] `json:"default_priority" swaggertype:"string"`
+	DefaultStatus   Optional[string] `json:"default_status" swaggertype:"string"`
+	Archived        Optional[bool]   `json:"archived" swaggertype:"boolean"`
+}
+
+type ProjectRepository interface {
+	Create(ctx context.Context, p *Project) error
+	// GetByID mengembalikan nil jika project tidak ada
+	GetByID(ctx context.Context, id int64) (*Project, error)
+	// ListVisible mengembalikan project pribadi user dan project di workspace tempat ia menjadi anggota
+	ListVisible(ctx context.Context, userID int64, includeArchived bool) ([]Project, error)
+	Update(ctx context.Context, p *Project) error
+	// Delete menghapus project; task di dalamnya tetap ada tanpa project
+	Delete(ctx context.Context, id int64) error
+}
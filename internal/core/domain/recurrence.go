@@ -0,0 +1,494 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frekuensi RRULE yang didukung
+const (
+	FreqDaily   = "DAILY"
+	FreqWeekly  = "WEEKLY"
+	FreqMonthly = "MONTHLY"
+	FreqYearly  = "YEARLY"
+)
+
+// maxRecurrencePeriods membatasi iterasi supaya aturan yang jarang (atau tidak pernah)
+// cocok, mis. BYMONTHDAY=31 setiap Februari, tidak berputar selamanya
+const maxRecurrencePeriods = 50000
+
+// recurrenceAliases menjaga pola lama ("daily", "weekly", "monthly") tetap berlaku
+var recurrenceAliases = map[string]string{
+	"daily":    "FREQ=DAILY",
+	"weekly":   "FREQ=WEEKLY",
+	"monthly":  "FREQ=MONTHLY",
+	"yearly":   "FREQ=YEARLY",
+	"weekdays": "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR",
+}
+
+var weekdayCodes = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// ByDay adalah satu nilai BYDAY. N bukan nol hanya untuk MONTHLY:
+// 1MO = Senin pertama, -1FR = Jumat terakhir dalam bulan.
+type ByDay struct {
+	Weekday time.Weekday
+	N       int
+}
+
+func (d ByDay) String() string {
+	if d.N == 0 {
+		return weekdayCodes[d.Weekday]
+	}
+	return strconv.Itoa(d.N) + weekdayCodes[d.Weekday]
+}
+
+// RecurrenceRule adalah subset RRULE RFC 5545: FREQ, INTERVAL, BYDAY, BYMONTHDAY,
+// COUNT dan UNTIL. Kemunculan dihitung dari DTSTART (Task.RecurrenceStart) pada
+// jam dinding yang sama di zona waktu pemilik task, sehingga tidak bergeser saat DST.
+type RecurrenceRule struct {
+	Freq       string
+	Interval   int
+	ByDay      []ByDay
+	ByMonthDay []int
+	Count      int
+	Until      *time.Time
+	untilDate  bool // UNTIL berupa tanggal saja: berlaku sampai akhir hari itu di zona waktu user
+}
+
+// ParseRecurrence membaca RRULE ("FREQ=WEEKLY;BYDAY=MO,WE", boleh diawali "RRULE:")
+// atau salah satu alias daily, weekly, monthly, yearly, weekdays
+func ParseRecurrence(s string) (*RecurrenceRule, error) {
+	s = strings.TrimSpace(s)
+	if alias, ok := recurrenceAliases[strings.ToLower(s)]; ok {
+		s = alias
+	}
+	if len(s) >= 6 && strings.EqualFold(s[:6], "RRULE:") {
+		s = s[6:]
+	}
+	if s == "" {
+		return nil, fmt.Errorf("rule is empty")
+	}
+
+	r := &RecurrenceRule{Interval: 1}
+	seen := map[string]bool{}
+	for _, part := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		key = strings.ToUpper(strings.TrimSpace(key))
+		value = strings.ToUpper(strings.TrimSpace(value))
+		if !ok || key == "" || value == "" {
+			return nil, fmt.Errorf("malformed rule part %q", part)
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("%s is given more than once", key)
+		}
+		seen[key] = true
+
+		var err error
+		switch key {
+		case "FREQ":
+			if !slices.Contains([]string{FreqDaily, FreqWeekly, FreqMonthly, FreqYearly}, value) {
+				return nil, fmt.Errorf("FREQ must be one of DAILY, WEEKLY, MONTHLY, YEARLY")
+			}
+			r.Freq = value
+		case "INTERVAL":
+			r.Interval, err = parseRulePositive(key, value, 1000)
+		case "COUNT":
+			r.Count, err = parseRulePositive(key, value, 10000)
+		case "UNTIL":
+			err = r.parseUntil(value)
+		case "BYDAY":
+			r.ByDay, err = parseByDay(value)
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parseByMonthDay(value)
+		case "WKST":
+			if value != "MO" {
+				return nil, fmt.Errorf("only WKST=MO is supported")
+			}
+		default:
+			return nil, fmt.Errorf("unsupported rule part %s", key)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if r.Freq == "" {
+		return nil, fmt.Errorf("FREQ is required")
+	}
+	if r.Count > 0 && r.Until != nil {
+		return nil, fmt.Errorf("COUNT and UNTIL cannot be combined")
+	}
+	for _, d := range r.ByDay {
+		if d.N != 0 && r.Freq != FreqMonthly {
+			return nil, fmt.Errorf("BYDAY with an ordinal (e.g. 1MO) is only supported with FREQ=MONTHLY")
+		}
+	}
+	if len(r.ByDay) > 0 && r.Freq == FreqYearly {
+		return nil, fmt.Errorf("BYDAY is not supported with FREQ=YEARLY")
+	}
+	if len(r.ByMonthDay) > 0 && r.Freq == FreqWeekly {
+		return nil, fmt.Errorf("BYMONTHDAY cannot be used with FREQ=WEEKLY")
+	}
+	return r, nil
+}
+
+func parseRulePositive(key, value string, limit int) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 || n > limit {
+		return 0, fmt.Errorf("%s must be an integer between 1 and %d", key, limit)
+	}
+	return n, nil
+}
+
+func (r *RecurrenceRule) parseUntil(value string) error {
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		r.Until = &t
+		return nil
+	}
+	if t, err := time.Parse("20060102", value); err == nil {
+		r.Until, r.untilDate = &t, true
+		return nil
+	}
+	return fmt.Errorf("UNTIL must be YYYYMMDD or YYYYMMDDTHHMMSSZ")
+}
+
+func parseByDay(value string) ([]ByDay, error) {
+	var days []ByDay
+	for _, raw := range strings.Split(value, ",") {
+		if len(raw) < 2 {
+			return nil, fmt.Errorf("invalid BYDAY value %q", raw)
+		}
+		wd := slices.Index(weekdayCodes, raw[len(raw)-2:])
+		if wd < 0 {
+			return nil, fmt.Errorf("invalid BYDAY value %q", raw)
+		}
+		d := ByDay{Weekday: time.Weekday(wd)}
+		if prefix := raw[:len(raw)-2]; prefix != "" {
+			n, err := strconv.Atoi(prefix)
+			if err != nil || n == 0 || n < -5 || n > 5 {
+				return nil, fmt.Errorf("invalid BYDAY value %q", raw)
+			}
+			d.N = n
+		}
+		if !slices.Contains(days, d) {
+			days = append(days, d)
+		}
+	}
+	return days, nil
+}
+
+func parseByMonthDay(value string) ([]int, error) {
+	var days []int
+	for _, raw := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n == 0 || n < -31 || n > 31 {
+			return nil, fmt.Errorf("BYMONTHDAY values must be between 1 and 31 or -31 and -1")
+		}
+		if !slices.Contains(days, n) {
+			days = append(days, n)
+		}
+	}
+	return days, nil
+}
+
+// String mengembalikan bentuk kanonik aturan, yang disimpan di recurrence_pattern
+func (r *RecurrenceRule) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, d := range r.ByDay {
+			days[i] = d.String()
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, len(r.ByMonthDay))
+		for i, d := range r.ByMonthDay {
+			days[i] = strconv.Itoa(d)
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil {
+		if r.untilDate {
+			parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+		} else {
+			parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+		}
+	}
+	return strings.Join(parts, ";")
+}
+
+// First mengembalikan kemunculan pertama pada atau setelah start
+func (r *RecurrenceRule) First(start time.Time, loc *time.Location) (time.Time, bool) {
+	return r.Next(start, time.Time{}, loc)
+}
+
+// Next mengembalikan kemunculan pertama yang lebih besar dari after; false jika
+// aturan sudah habis (COUNT/UNTIL)
+func (r *RecurrenceRule) Next(start, after time.Time, loc *time.Location) (time.Time, bool) {
+	next := r.After(start, after, 1, loc)
+	if len(next) == 0 {
+		return time.Time{}, false
+	}
+	return next[0], true
+}
+
+// After mengembalikan paling banyak limit kemunculan yang lebih besar dari after,
+// dihitung dari start (DTSTART) di zona waktu loc
+func (r *RecurrenceRule) After(start, after time.Time, limit int, loc *time.Location) []time.Time {
+	start = start.In(loc)
+	hour, minute, sec := start.Clock()
+	sy, sm, sd := start.Date()
+	base := time.Date(sy, sm, sd, 0, 0, 0, 0, time.UTC) // aritmetika tanggal tanpa DST
+
+	var out []time.Time
+	seen := 0
+	for k := 0; k < maxRecurrencePeriods; k++ {
+		for _, day := range r.periodDays(base, k) {
+			y, m, d := day.Date()
+			t := time.Date(y, m, d, hour, minute, sec, 0, loc)
+			if t.Before(start) {
+				continue
+			}
+			if r.pastUntil(t, loc) {
+				return out
+			}
+			if seen++; r.Count > 0 && seen > r.Count {
+				return out
+			}
+			if t.After(after) {
+				if out = append(out, t); len(out) >= limit {
+					return out
+				}
+			}
+		}
+	}
+	return out
+}
+
+func (r *RecurrenceRule) pastUntil(t time.Time, loc *time.Location) bool {
+	if r.Until == nil {
+		return false
+	}
+	if !r.untilDate {
+		return t.After(*r.Until)
+	}
+	uy, um, ud := r.Until.Date()
+	return !t.Before(time.Date(uy, um, ud+1, 0, 0, 0, 0, loc))
+}
+
+// periodDays mengembalikan tanggal kandidat (UTC, tengah malam) periode ke-k, urut naik
+func (r *RecurrenceRule) periodDays(base time.Time, k int) []time.Time {
+	step := k * r.Interval
+	switch r.Freq {
+	case FreqDaily:
+		day := base.AddDate(0, 0, step)
+		if r.matchesWeekday(day) && r.matchesMonthDay(day) {
+			return []time.Time{day}
+		}
+		return nil
+
+	case FreqWeekly:
+		monday := base.AddDate(0, 0, -((int(base.Weekday())+6)%7)+7*step)
+		if len(r.ByDay) == 0 {
+			return []time.Time{monday.AddDate(0, 0, (int(base.Weekday())+6)%7)}
+		}
+		var days []time.Time
+		for i := 0; i < 7; i++ {
+			if day := monday.AddDate(0, 0, i); r.matchesWeekday(day) {
+				days = append(days, day)
+			}
+		}
+		return days
+
+	case FreqMonthly:
+		first := time.Date(base.Year(), base.Month()+time.Month(step), 1, 0, 0, 0, 0, time.UTC)
+		n := first.AddDate(0, 1, -1).Day()
+		if len(r.ByDay) == 0 && len(r.ByMonthDay) == 0 {
+			if base.Day() > n {
+				return nil // mis. tanggal 31 dilewati pada bulan yang lebih pendek
+			}
+			return []time.Time{first.AddDate(0, 0, base.Day()-1)}
+		}
+		var days []time.Time
+		for d := 1; d <= n; d++ {
+			day := first.AddDate(0, 0, d-1)
+			if (len(r.ByDay) == 0 || r.matchesMonthlyByDay(day, n)) &&
+				(len(r.ByMonthDay) == 0 || r.matchesMonthDay(day)) {
+				days = append(days, day)
+			}
+		}
+		return days
+
+	case FreqYearly:
+		first := time.Date(base.Year()+step, base.Month(), 1, 0, 0, 0, 0, time.UTC)
+		n := first.AddDate(0, 1, -1).Day()
+		if len(r.ByMonthDay) == 0 {
+			if base.Day() > n {
+				return nil // 29 Februari hanya pada tahun kabisat
+			}
+			return []time.Time{first.AddDate(0, 0, base.Day()-1)}
+		}
+		var days []time.Time
+		for d := 1; d <= n; d++ {
+			if day := first.AddDate(0, 0, d-1); r.matchesMonthDay(day) {
+				days = append(days, day)
+			}
+		}
+		return days
+	}
+	return nil
+}
+
+// matchesWeekday: BYDAY tanpa urutan (DAILY & WEEKLY)
+func (r *RecurrenceRule) matchesWeekday(day time.Time) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(r.ByDay, func(d ByDay) bool { return d.Weekday == day.Weekday() })
+}
+
+// matchesMonthlyByDay: BYDAY dengan urutan opsional dalam bulan berisi n hari
+func (r *RecurrenceRule) matchesMonthlyByDay(day time.Time, n int) bool {
+	nth := (day.Day()-1)/7 + 1
+	nthFromEnd := -((n-day.Day())/7 + 1)
+	return slices.ContainsFunc(r.ByDay, func(d ByDay) bool {
+		return d.Weekday == day.Weekday() && (d.N == 0 || d.N == nth || d.N == nthFromEnd)
+	})
+}
+
+func (r *RecurrenceRule) matchesMonthDay(day time.Time) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+	n := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	return slices.ContainsFunc(r.ByMonthDay, func(md int) bool {
+		return md == day.Day() || (md < 0 && n+md+1 == day.Day())
+	})
+}
+
+// Mode recurrence
+const (
+	RecurrenceModeSchedule   = "schedule"   // mengikuti kalender; instance dibuat scheduler pada next_run
+	RecurrenceModeCompletion = "completion" // instance berikutnya dibuat saat task ditandai selesai, dihitung dari waktu selesai
+)
+
+// RecurrenceModes adalah daftar nilai recurrence_mode yang valid
+var RecurrenceModes = []string{RecurrenceModeSchedule, RecurrenceModeCompletion}
+
+// AfterCompletion mengembalikan kemunculan berikutnya untuk recurrence berbasis penyelesaian:
+// aturan dihitung dengan waktu selesai sebagai DTSTART, mis. FREQ=DAILY;INTERVAL=3
+// berarti tiga hari setelah selesai. false jika UNTIL sudah lewat.
+func (r *RecurrenceRule) AfterCompletion(completedAt time.Time, loc *time.Location) (time.Time, bool) {
+	return r.Next(completedAt, completedAt, loc)
+}
+
+// Kebijakan untuk kemunculan yang terlewat (mis. server mati beberapa hari)
+const (
+	MissedRunAll    = "all"    // buat instance untuk setiap kemunculan yang terlewat
+	MissedRunLatest = "latest" // buat satu instance untuk kemunculan terakhir saja
+	MissedRunSkip   = "skip"   // lewati yang terlewat; hanya kemunculan tepat waktu yang dibuat
+)
+
+// MissedRunPolicies adalah daftar nilai missed_run_policy yang valid
+var MissedRunPolicies = []string{MissedRunAll, MissedRunLatest, MissedRunSkip}
+
+const (
+	// MissedRunGrace: kemunculan yang telat kurang dari ini dianggap tepat waktu (jeda cron)
+	MissedRunGrace = 10 * time.Minute
+	// maxCatchUpInstances membatasi instance yang dibuat sekali jalan dengan kebijakan all;
+	// sisanya (yang paling lama) dihitung sebagai terlewat
+	maxCatchUpInstances = 100
+)
+
+// CatchUpPlan adalah hasil perhitungan satu putaran scheduler untuk satu task
+type CatchUpPlan struct {
+	Create  []time.Time // kemunculan yang dibuatkan instance, urut naik
+	Skipped int         // kemunculan jatuh tempo yang tidak dibuatkan instance
+	Next    *time.Time  // next_run baru; nil jika seri sudah habis
+}
+
+// CatchUp menghitung semua kemunculan dari nextRun sampai now dalam satu kali jalan,
+// memilih yang dibuatkan instance sesuai policy, lalu memajukan next_run melewati now
+func (r *RecurrenceRule) CatchUp(start, nextRun, now time.Time, policy string, loc *time.Location) CatchUpPlan {
+	due := []time.Time{nextRun}
+	for after := nextRun; ; {
+		batch := r.After(start, after, 500, loc)
+		var next *time.Time
+		for i, t := range batch {
+			if t.After(now) {
+				next = &batch[i]
+				break
+			}
+			due = append(due, t)
+		}
+		if next != nil || len(batch) < 500 {
+			plan := CatchUpPlan{Next: next}
+			plan.Create, plan.Skipped = selectMissed(due, now, policy)
+			return plan
+		}
+		after = batch[len(batch)-1]
+	}
+}
+
+func selectMissed(due []time.Time, now time.Time, policy string) ([]time.Time, int) {
+	switch policy {
+	case MissedRunAll:
+		if len(due) > maxCatchUpInstances {
+			return due[len(due)-maxCatchUpInstances:], len(due) - maxCatchUpInstances
+		}
+		return due, 0
+	case MissedRunSkip:
+		var onTime []time.Time
+		for _, t := range due {
+			if now.Sub(t) <= MissedRunGrace {
+				onTime = append(onTime, t)
+			}
+		}
+		return onTime, len(due) - len(onTime)
+	default:
+		return due[len(due)-1:], len(due) - 1
+	}
+}
+
+// RecurrenceClaim adalah task recurring terjadwal yang jatuh tempo dan sedang dikunci
+// oleh satu scheduler
+type RecurrenceClaim struct {
+	Task          Task           // template; RecurrenceStart & NextRun selalu terisi
+	Location      *time.Location // zona waktu pemilik, tempat aturan dihitung
+	InitialStatus string         // status todo pertama di workflow pemilik, untuk instance baru
+}
+
+// RecurrenceInstance adalah instance yang dibuat untuk satu kemunculan
+type RecurrenceInstance struct {
+	Task         Task
+	ReminderBase *time.Time // acuan reminder relatif yang disalin dari template
+	Event        Event
+}
+
+// RecurrenceAdvance adalah hasil memproses satu klaim
+type RecurrenceAdvance struct {
+	Instances []RecurrenceInstance
+	NextRun   *time.Time // nil menghentikan seri
+}
+
+// RecurrenceRepository mengklaim task recurring terjadwal untuk scheduler. Aman dipakai
+// beberapa replica sekaligus: setiap task hanya diproses oleh satu scheduler pada satu waktu.
+type RecurrenceRepository interface {
+	// ProcessDue mengklaim task terjadwal dengan next_run <= now satu per satu (paling banyak
+	// limit), memanggil fn, lalu membuat instance & menyimpan next_run hasilnya dalam transaksi
+	// yang sama dengan klaimnya. Task yang sedang diklaim scheduler lain dilewati. Kegagalan
+	// satu task tidak menghentikan yang lain; semua error dikembalikan di akhir.
+	// Mengembalikan jumlah task yang diproses dan instance yang dibuat.
+	ProcessDue(ctx context.Context, now time.Time, limit int, fn func(RecurrenceClaim) RecurrenceAdvance) (int, int, error)
+}
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Channel pengiriman reminder
+const (
+	ChannelEmail   = "email"
+	ChannelWebhook = "webhook"
+	ChannelInApp   = "in_app"
+)
+
+// Status pengiriman reminder
+const (
+	DeliveryPending = "pending" // menunggu dikirim (atau dikirim ulang setelah gagal)
+	DeliverySending = "sending" // sedang dikirim oleh satu worker
+	DeliverySent    = "sent"
+	DeliveryFailed  = "failed" // menyerah; tidak akan dikirim ulang
+)
+
+// EventReminderDue dipancarkan channel in-app saat reminder sebuah task jatuh tempo
+const EventReminderDue = "reminder.due"
+
+// ReminderDelivery adalah satu pengiriman reminder ke satu user lewat satu channel.
+// Sumbernya Task.ReminderTime dan TaskReminder.
+// Kombinasi (task, waktu reminder, user, channel) unik sehingga tidak pernah dikirim dua kali.
+type ReminderDelivery struct {
+	ID       int64
+	TaskID   int64
+	UserID   int64
+	Channel  string
+	RemindAt time.Time
+	Attempts int // termasuk percobaan yang sedang berjalan
+
+	// Diisi saat diklaim, untuk isi pesan
+	TaskTitle string
+	DueAt     *time.Time
+	UserName  string
+	UserEmail string
+}
+
+// ReminderChannel mengirim reminder lewat satu media (email, webhook, in-app, ...)
+type ReminderChannel interface {
+	Name() string
+	Send(ctx context.Context, d ReminderDelivery) error
+}
+
+type ReminderRepository interface {
+	// ScheduleDue membuat baris pengiriman untuk setiap reminder yang jatuh tempo di
+	// antara now-lookback dan now, per penerima (pembuat + assignee) dan per channel.
+	// Idempoten: baris yang sudah ada tidak dibuat ulang.
+	ScheduleDue(ctx context.Context, now time.Time, lookback time.Duration, channels []string) (int64, error)
+
+	// ClaimDue mengubah paling banyak limit baris pending menjadi sending secara atomik
+	// (aman dipanggil dari beberapa replica sekaligus) dan mengembalikannya
+	ClaimDue(ctx context.Context, now time.Time, limit int) ([]ReminderDelivery, error)
+
+	MarkSent(ctx context.Context, id int64, at time.Time) error
+
+	// MarkFailed mengembalikan baris ke pending dengan jadwal retryAt, atau
+	// menandainya failed permanen jika retryAt nil
+	MarkFailed(ctx context.Context, id int64, reason string, retryAt *time.Time, at time.Time) error
+
+	// AbandonStale menandai failed baris yang tertahan di sending sejak sebelum `before`
+	// (worker mati di tengah pengiriman). Tidak dikirim ulang karena statusnya tidak diketahui.
+	AbandonStale(ctx context.Context, before time.Time) (int64, error)
+}
+
+// MaxTaskReminders adalah batas reminder per task
+const MaxTaskReminders = 10
+
+// TaskReminder adalah satu reminder milik task: absolut (RemindAt diisi langsung)
+// atau relatif terhadap tenggat (OffsetMinutes sebelum due_at). RemindAt reminder
+// relatif dihitung ulang setiap tenggat berubah, dan nil selama task tanpa tenggat.
+type TaskReminder struct {
+	ID            int64      `json:"id"`
+	TaskID        int64      `json:"task_id"`
+	RemindAt      *time.Time `json:"remind_at"`
+	OffsetMinutes *int       `json:"offset_minutes"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// Relative bernilai true jika reminder mengikuti tenggat task
+func (r *TaskReminder) Relative() bool {
+	return r.OffsetMinutes != nil
+}
+
+// TaskReminderInput adalah body POST/PUT /tasks/:id/reminders; isi salah satu field
+type TaskReminderInput struct {
+	RemindAt      *time.Time `json:"remind_at"`
+	OffsetMinutes *int       `json:"offset_minutes"` // menit sebelum tenggat, mis. 1440 = 1 hari
+}
+
+type TaskReminderRepository interface {
+	Create(ctx context.Context, reminder *TaskReminder) error
+	GetByID(ctx context.Context, id int64) (*TaskReminder, error)
+	ListByTask(ctx context.Context, taskID int64) ([]TaskReminder, error)
+	Update(ctx context.Context, reminder *TaskReminder) error
+	Delete(ctx context.Context, id int64) error
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Session adalah satu login (satu "token family"). Semua refresh token hasil
+// rotasi dari login yang sama berbagi Session; mencabut Session berarti
+// access token dan refresh token dari family tersebut tidak berlaku lagi.
+type Session struct {
+	ID        int64      `json:"id"`
+	UserID    int64      `json:"user_id"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+}
+
+// Active bernilai true jika session belum dicabut dan belum kedaluwarsa
+func (s *Session) Active(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// RefreshToken hanya disimpan dalam bentuk hash; token mentah hanya dikirim sekali ke client
+type RefreshToken struct {
+	ID        int64
+	SessionID int64
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    *time.Time // terisi saat token dirotasi; pemakaian kedua = reuse
+	CreatedAt time.Time
+}
+
+type SessionRepository interface {
+	CreateSession(ctx context.Context, s *Session) error
+	GetSession(ctx context.Context, id int64) (*Session, error)
+	RevokeSession(ctx context.Context, id int64, at time.Time) error
+	RevokeUserSessions(ctx context.Context, userID int64, at time.Time) error
+
+	CreateRefreshToken(ctx context.Context, t *RefreshToken) error
+	GetRefreshTokenByHash(ctx context.Context, hash string) (*RefreshToken, error)
+	// MarkRefreshTokenUsed bernilai false jika token sudah pernah dipakai (dicek secara atomik)
+	MarkRefreshTokenUsed(ctx context.Context, id int64, at time.Time) (bool, error)
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Kategori status menentukan timestamp apa yang dicap saat task berpindah status
+const (
+	StatusCategoryTodo       = "todo"
+	StatusCategoryInProgress = "in_progress"
+	StatusCategoryDone       = "done"
+)
+
+// TaskStatus adalah satu kolom kanban milik user
+type TaskStatus struct {
+	Key      string `json:"key"`      // nilai yang disimpan di tasks.status
+	Name     string `json:"name"`     // label untuk ditampilkan
+	Category string `json:"category"` // todo, in_progress, done
+	Position int    `json:"position"` // urutan kolom kanban
+}
+
+// StatusTransition adalah satu perpindahan status yang diizinkan
+type StatusTransition struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Workflow adalah kumpulan status dan transisi yang berlaku untuk task seorang user
+type Workflow struct {
+	Statuses    []TaskStatus       `json:"statuses"`
+	Transitions []StatusTransition `json:"transitions"`
+}
+
+// StatusChange dicatat setiap kali status task berubah, untuk laporan cycle time
+type StatusChange struct {
+	TaskID    int64     `json:"task_id"`
+	UserID    int64     `json:"user_id"`
+	From      string    `json:"from"`
+	To        string    `json:"to"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+// InvalidTransitionError dikembalikan jika perpindahan status tidak diizinkan workflow
+type InvalidTransitionError struct {
+	From string
+	To   string
+}
+
+func (e *InvalidTransitionError) Error() string {
+	if e.From == "" {
+		return fmt.Sprintf("invalid status %q", e.To)
+	}
+	return fmt.Sprintf("status transition from %q to %q is not allowed", e.From, e.To)
+}
+
+// Unwrap menjadikan transisi ilegal sebagai konflik dengan state task saat ini.
+// Status yang tidak dikenal sama sekali diperlakukan sebagai input tidak valid.
+func (e *InvalidTransitionError) Unwrap() error {
+	if e.From == "" {
+		return ErrBadParamInput
+	}
+	return ErrConflict
+}
+
+// StatusRepository menyimpan workflow custom per user
+type StatusRepository interface {
+	// GetWorkflow mengembalikan nil jika user belum mendefinisikan workflow sendiri
+	GetWorkflow(ctx context.Context, userID int64) (*Workflow, error)
+	// SaveWorkflow mengganti seluruh workflow user
+	SaveWorkflow(ctx context.Context, userID int64, wf *Workflow) error
+	// DeleteWorkflow mengembalikan user ke workflow default
+	DeleteWorkflow(ctx context.Context, userID int64) error
+
+	LogChange(ctx context.Context, change *StatusChange) error
+}
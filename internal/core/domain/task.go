@@ -17,35 +17,260 @@ type Subtask struct {
 // Task merepresentasikan tugas utama
 type Task struct {
     ID           int64      `json:"id"`
-    UserID       int64      `json:"user_id"`       // Foreign Key ke User
+    UserID       int64      `json:"user_id"`       // Foreign Key ke User (pembuat task)
+    WorkspaceID  *int64     `json:"workspace_id"`  // null = task pribadi, selain itu terlihat oleh semua anggota workspace
+    ProjectID    *int64     `json:"project_id"`    // opsional, null = tanpa project
     Title        string     `json:"title"`
     Description  string     `json:"description"`
-    Status       string     `json:"status"`        // e.g., "pending", "in_progress", "done"
+    Status       string     `json:"status"`        // key dari Workflow user, default: "pending", "in_progress", "done"
     Priority     string     `json:"priority"`      // low, medium, high
     Labels       []string   `json:"labels"`        // contoh: ["work", "bug"]
     ReminderTime *time.Time `json:"reminder_time"` // pointer agar bisa null
+    DueAt        *time.Time `json:"due_at"`        // tenggat; untuk all-day hanya tanggalnya yang dipakai
+    DueAllDay    bool       `json:"due_all_day"`   // tenggat berlaku sampai akhir hari menurut zona waktu user
+    IsOverdue    bool       `json:"is_overdue"`    // dihitung saat dibaca, tidak disimpan
+    AssigneeIDs  []int64    `json:"assignee_ids"`  // diatur lewat /tasks/:id/assignees, bukan lewat create/patch
 
     // --- FIELD BARU ---
-    RecurrencePattern string     `json:"recurrence_pattern"` // "daily", "weekly", "monthly"
-    NextRun           *time.Time `json:"next_run"`           // kapan trigger berikutnya
-    Subtasks          []Subtask  `json:"subtasks"`           // Nested JSON untuk checklist
+    RecurrencePattern  string     `json:"recurrence_pattern"`   // RRULE (lihat RecurrenceRule), mis. "FREQ=WEEKLY;BYDAY=MO,WE"
+    RecurrenceStart    *time.Time `json:"recurrence_start"`     // DTSTART: kemunculan pertama, acuan INTERVAL & COUNT
+    NextRun            *time.Time `json:"next_run"`             // kapan trigger berikutnya
+    RecurrenceMode     string     `json:"recurrence_mode"`      // schedule (default) atau completion; lihat RecurrenceModeSchedule dkk.
+    MissedRunPolicy    string     `json:"missed_run_policy"`    // all, latest (default), skip; lihat MissedRunAll dkk.
+    RecurrenceParentID *int64     `json:"recurrence_parent_id"` // instance: task template yang membuatnya
+    OccurrenceAt       *time.Time `json:"occurrence_at"`        // instance: kemunculan yang diwakilinya
+    Subtasks           []Subtask  `json:"subtasks"`             // Nested JSON untuk checklist
     // ------------------
 
+    // Dicap oleh workflow status (lihat domain.Workflow)
+    StatusChangedAt *time.Time `json:"status_changed_at"`
+    StartedAt       *time.Time `json:"started_at"`   // pertama kali masuk kategori in_progress
+    CompletedAt     *time.Time `json:"completed_at"` // masuk kategori done; di-reset saat dibuka lagi
+
     CreatedAt time.Time `json:"created_at"`
     UpdatedAt time.Time `json:"updated_at"`
 }
 
+// DueBy mengembalikan batas tenggat efektif (eksklusif). Untuk all-day, tanggal
+// DueAt ditafsirkan di zona waktu loc sehingga tenggat berakhir tengah malam waktu user.
+func (t *Task) DueBy(loc *time.Location) (time.Time, bool) {
+    if t.DueAt == nil {
+        return time.Time{}, false
+    }
+    if !t.DueAllDay {
+        return *t.DueAt, true
+    }
+    y, m, d := t.DueAt.UTC().Date()
+    return time.Date(y, m, d+1, 0, 0, 0, 0, loc), true
+}
+
+// ReminderBase adalah titik acuan reminder relatif: due_at untuk tenggat berjam,
+// atau awal hari tenggat di zona waktu loc untuk tenggat all-day
+func (t *Task) ReminderBase(loc *time.Location) *time.Time {
+    if t.DueAt == nil {
+        return nil
+    }
+    if !t.DueAllDay {
+        base := *t.DueAt
+        return &base
+    }
+    y, m, d := t.DueAt.UTC().Date()
+    base := time.Date(y, m, d, 0, 0, 0, 0, loc)
+    return &base
+}
+
+// Instance membuat instance seri dari template t untuk kemunculan occurrence: field konten
+// disalin, tenggat & reminder_time digeser sebanyak hari kalender antara DTSTART dan
+// occurrence di zona waktu loc. Bukan durasi tetap, supaya jam lokal (dan tanggal tenggat
+// all-day) tidak bergeser saat melewati pergantian DST. Status & timestamp diisi pemanggil.
+func (t *Task) Instance(occurrence time.Time, loc *time.Location) Task {
+    parentID := t.ID
+    inst := Task{
+        UserID:             t.UserID,
+        WorkspaceID:        t.WorkspaceID,
+        ProjectID:          t.ProjectID,
+        Title:              t.Title,
+        Description:        t.Description,
+        Priority:           t.Priority,
+        Labels:             t.Labels,
+        DueAllDay:          t.DueAllDay,
+        AssigneeIDs:        []int64{},
+        RecurrenceParentID: &parentID,
+        OccurrenceAt:       &occurrence,
+        Subtasks:           []Subtask{},
+    }
+    days := 0
+    if t.RecurrenceStart != nil {
+        days = calendarDays(t.RecurrenceStart.In(loc), occurrence.In(loc))
+    }
+    if t.DueAt != nil {
+        var due time.Time
+        if t.DueAllDay {
+            // tenggat all-day berupa tanggal saja (00:00 UTC)
+            y, m, d := t.DueAt.UTC().Date()
+            due = time.Date(y, m, d+days, 0, 0, 0, 0, time.UTC)
+        } else {
+            due = t.DueAt.In(loc).AddDate(0, 0, days).UTC()
+        }
+        inst.DueAt = &due
+    }
+    if t.ReminderTime != nil {
+        remind := t.ReminderTime.In(loc).AddDate(0, 0, days).UTC()
+        inst.ReminderTime = &remind
+    }
+    return inst
+}
+
+// calendarDays menghitung selisih tanggal kalender from → to, mengabaikan jam
+func calendarDays(from, to time.Time) int {
+    fy, fm, fd := from.Date()
+    ty, tm, td := to.Date()
+    return int(time.Date(ty, tm, td, 0, 0, 0, 0, time.UTC).Sub(time.Date(fy, fm, fd, 0, 0, 0, 0, time.UTC)).Hours() / 24)
+}
+
+// Overdue bernilai true jika task belum selesai dan tenggatnya sudah lewat
+func (t *Task) Overdue(now time.Time, loc *time.Location) bool {
+    due, ok := t.DueBy(loc)
+    return ok && t.CompletedAt == nil && !now.Before(due)
+}
+
+// TaskPatch berisi perubahan parsial untuk PATCH /tasks/:id.
+// Field yang tidak dikirim tidak diubah; field pointer di Task
+// (ReminderTime, NextRun) bisa dikosongkan dengan mengirim null.
+type TaskPatch struct {
+    Title             Optional[string]    `json:"title" swaggertype:"string"`
+    Description       Optional[string]    `json:"description" swaggertype:"string"`
+    Status            Optional[string]    `json:"status" swaggertype:"string"`
+    Priority          Optional[string]    `json:"priority" swaggertype:"string"`
+    Labels            Optional[[]string]  `json:"labels" swaggertype:"array,string"`
+    ReminderTime      Optional[time.Time] `json:"reminder_time" swaggertype:"string" format:"date-time"`
+    RecurrencePattern Optional[string]    `json:"recurrence_pattern" swaggertype:"string"`
+    NextRun           Optional[time.Time] `json:"next_run" swaggertype:"string" format:"date-time"`
+    RecurrenceMode    Optional[string]    `json:"recurrence_mode" swaggertype:"string"`
+    MissedRunPolicy   Optional[string]    `json:"missed_run_policy" swaggertype:"string"`
+    ProjectID         Optional[int64]     `json:"project_id" swaggertype:"integer"`
+    DueAt             Optional[time.Time] `json:"due_at" swaggertype:"string" format:"date-time"`
+    DueAllDay         Optional[bool]      `json:"due_all_day" swaggertype:"boolean"`
+}
+
+// Kolom yang boleh dipakai untuk sorting daftar task
+const (
+    TaskSortCreatedAt    = "created_at"
+    TaskSortUpdatedAt    = "updated_at"
+    TaskSortReminderTime = "reminder_time"
+    TaskSortPriority     = "priority"
+    TaskSortTitle        = "title"
+    TaskSortDueAt        = "due_at"
+)
+
+// TaskFilter berisi parameter filter, sorting dan pagination untuk Fetch.
+// Field pointer/slice yang kosong berarti filter tersebut tidak dipakai.
+type TaskFilter struct {
+    UserID     int64 // user yang meminta: task pribadinya + task di workspace tempat ia menjadi anggota
+    Statuses   []string
+    Priorities []string
+    Labels     []string // task cocok jika memiliki salah satu label
+
+    ProjectID      *int64
+    WithoutProject bool // hanya task yang tidak masuk project mana pun
+
+    WorkspaceID  *int64
+    PersonalOnly bool // hanya task pribadi (tanpa workspace)
+
+    AssigneeID *int64 // hanya task yang di-assign ke user ini
+
+    CreatedFrom  *time.Time
+    CreatedTo    *time.Time
+    UpdatedFrom  *time.Time
+    UpdatedTo    *time.Time
+    ReminderFrom *time.Time
+    ReminderTo   *time.Time
+    DueFrom      *time.Time
+    DueTo        *time.Time
+
+    Incomplete bool // hanya task yang belum selesai (completed_at kosong)
+
+    SortBy   string // salah satu konstanta TaskSort*
+    SortDesc bool
+    Limit    int
+    Cursor   string // opaque, didapat dari TaskPage.NextCursor
+}
+
+// TaskPage adalah satu halaman hasil Fetch
+type TaskPage struct {
+    Tasks      []Task `json:"data"`
+    NextCursor string `json:"next_cursor,omitempty"` // kosong jika sudah halaman terakhir
+}
+
+// TaskDueBuckets mengelompokkan task yang belum selesai menurut tenggatnya,
+// dihitung dengan zona waktu user
+type TaskDueBuckets struct {
+    Timezone string `json:"timezone"`
+    Overdue  []Task `json:"overdue"`
+    Today    []Task `json:"today"`
+    Upcoming []Task `json:"upcoming"`
+    // Truncated bernilai true jika ada task yang tidak ikut karena batas halaman:
+    // overdue membuang yang paling lama, upcoming yang paling jauh
+    Truncated bool `json:"truncated"`
+}
+
+// Cakupan edit task dalam seri recurring (PATCH /tasks/:id?scope=)
+const (
+    EditScopeThis   = "this"   // hanya task/kemunculan ini (default)
+    EditScopeFuture = "future" // template beserta kemunculan ini & sesudahnya yang belum selesai
+)
+
+// TaskSeries adalah template recurring beserta instance yang sudah dibuat scheduler
+type TaskSeries struct {
+    Template  Task        `json:"template"`
+    Instances []Task      `json:"instances"` // urut occurrence_at
+    Upcoming  []time.Time `json:"upcoming"`  // kemunculan berikutnya yang belum dibuat
+}
+
+// TaskEffects adalah perubahan turunan yang disimpan bersama perubahan task (lihat UpdateWith)
+type TaskEffects struct {
+    // NextInstance: kemunculan berikutnya seri berbasis penyelesaian (RecurrenceParentID &
+    // OccurrenceAt wajib), beserta salinan subtask template dan reminder relatifnya. Template
+    // dikunci selama pengecekan; instance dilewati tanpa error jika template sudah dihapus, seri
+    // masih punya kemunculan yang belum selesai, atau instance untuk kemunculan itu sudah ada.
+    NextInstance *RecurrenceInstance
+    // RecomputeReminders: hitung ulang semua reminder relatif task dari ReminderBase (titik
+    // tenggat yang baru); ReminderBase nil mengosongkan remind_at-nya
+    RecomputeReminders bool
+    ReminderBase       *time.Time
+}
+
 // TaskRepository mendefinisikan kontrak untuk operasi database terkait Task & Subtask
 type TaskRepository interface {
+    // Method yang mengubah data menerima event e dan menuliskannya ke outbox
+    // task_events dalam transaksi yang sama. TaskID event diisi repository.
+
     // --- Method Task ---
-    Create(ctx context.Context, task *Task) error
-    Fetch(ctx context.Context, userID int64) ([]Task, error)
+    Create(ctx context.Context, task *Task, e Event) error
+    Fetch(ctx context.Context, filter TaskFilter) (*TaskPage, error)
     GetByID(ctx context.Context, id int64) (*Task, error)
-    Update(ctx context.Context, task *Task) error
-    Delete(ctx context.Context, id int64) error
+    Update(ctx context.Context, task *Task, e Event) error
+    // UpdateWith seperti Update, ditambah effects dalam transaksi yang sama: jika salah satu
+    // gagal, tidak ada yang tersimpan
+    UpdateWith(ctx context.Context, task *Task, e Event, effects TaskEffects) error
+    Delete(ctx context.Context, id int64, e Event) error
+
+    // --- Method Seri Recurring ---
+    // ListSeries mengembalikan instance milik template parentID, urut occurrence_at
+    ListSeries(ctx context.Context, parentID int64) ([]Task, error)
+    // UpdateSeries menyimpan beberapa task sekaligus dalam satu transaksi; events[i] milik tasks[i]
+    UpdateSeries(ctx context.Context, tasks []*Task, events []Event) error
 
     // --- Method Subtask ---
-    CreateSubtask(ctx context.Context, sub *Subtask) error
-    DeleteSubtask(ctx context.Context, id int64) error
-    ToggleSubtask(ctx context.Context, id int64) error
+    CreateSubtask(ctx context.Context, sub *Subtask, e Event) error
+    DeleteSubtask(ctx context.Context, id int64, e Event) error
+    ToggleSubtask(ctx context.Context, id int64, e Event) error
+    // GetSubtaskTaskID mengembalikan ID task induk, atau ErrNotFound
+    GetSubtaskTaskID(ctx context.Context, id int64) (int64, error)
+
+    // --- Method Assignee ---
+    // AddAssignee bernilai false jika user sudah di-assign sebelumnya (event tidak ditulis)
+    AddAssignee(ctx context.Context, taskID int64, userID int64, assignedBy int64, at time.Time, e Event) (bool, error)
+    // RemoveAssignee bernilai false jika user memang tidak di-assign (event tidak ditulis)
+    RemoveAssignee(ctx context.Context, taskID int64, userID int64, e Event) (bool, error)
 }
@@ -9,14 +9,67 @@ type User struct {
 	Name      string    `json:"name"`
 	Email     string    `json:"email"`
 	Password  string    `json:"-"` 
+
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+
+	// Zona waktu IANA (mis. "Asia/Jakarta") untuk menafsirkan tenggat all-day dan "hari ini"
+	Timezone string `json:"timezone"`
+
+	// TOTP 2FA: secret terisi sejak enrollment dimulai, MFA aktif setelah MFAEnabledAt diisi
+	TOTPSecret   string     `json:"-"`
+	MFAEnabledAt *time.Time `json:"mfa_enabled_at"`
+
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// Location mengembalikan zona waktu user; UTC jika kosong atau tidak dikenal
+func (u *User) Location() *time.Location {
+	if u == nil || u.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(u.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// UserPatch berisi perubahan profil untuk PATCH /me
+type UserPatch struct {
+	Name     Optional[string] `json:"name" swaggertype:"string"`
+	Timezone Optional[string] `json:"timezone" swaggertype:"string"`
+}
+
 type UserRepository interface {
 	Create(ctx context.Context, user *User) error
 	
 	GetByEmail(ctx context.Context, email string) (*User, error)
 	
 	GetByID(ctx context.Context, id int64) (*User, error)
+
+	MarkEmailVerified(ctx context.Context, id int64, at time.Time) error
+
+	UpdatePassword(ctx context.Context, id int64, hashedPassword string, at time.Time) error
+
+	// UpdateProfile menyimpan Name, Timezone dan UpdatedAt
+	UpdateProfile(ctx context.Context, user *User) error
+
+	// SetTOTPSecret menyimpan secret enrollment yang belum dikonfirmasi (MFA tetap nonaktif)
+	SetTOTPSecret(ctx context.Context, id int64, secret string, at time.Time) error
+
+	EnableMFA(ctx context.Context, id int64, at time.Time) error
+
+	// DisableMFA menghapus secret, status MFA, dan semua recovery code user
+	DisableMFA(ctx context.Context, id int64, at time.Time) error
+
+	// ClaimTOTPStep bernilai false jika time-step ini (atau yang lebih baru) sudah pernah dipakai,
+	// sehingga kode TOTP yang sama tidak bisa di-replay
+	ClaimTOTPStep(ctx context.Context, id int64, step int64) (bool, error)
+
+	// ReplaceRecoveryCodes mengganti seluruh recovery code user dengan hash yang baru
+	ReplaceRecoveryCodes(ctx context.Context, userID int64, hashes []string, at time.Time) error
+
+	// UseRecoveryCode bernilai false jika kode tidak ada atau sudah terpakai (dicek secara atomik)
+	UseRecoveryCode(ctx context.Context, userID int64, hash string, at time.Time) (bool, error)
 }
\ No newline at end of file
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Tujuan token sekali pakai yang dikirim lewat email, atau diberikan ke client saat login 2 langkah
+const (
+	UserTokenVerifyEmail   = "verify_email"
+	UserTokenResetPassword = "reset_password"
+	UserTokenMFAChallenge  = "mfa_challenge"
+)
+
+// UserToken adalah token sekali pakai dengan masa berlaku; hanya hash-nya yang disimpan
+type UserToken struct {
+	ID        int64
+	UserID    int64
+	Purpose   string
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+type UserTokenRepository interface {
+	Create(ctx context.Context, t *UserToken) error
+	GetByHash(ctx context.Context, purpose string, hash string) (*UserToken, error)
+	// MarkUsed bernilai false jika token sudah pernah dipakai (dicek secara atomik)
+	MarkUsed(ctx context.Context, id int64, at time.Time) (bool, error)
+	// InvalidateAll menandai semua token user untuk purpose tertentu yang belum dipakai sebagai terpakai
+	InvalidateAll(ctx context.Context, userID int64, purpose string, at time.Time) error
+}
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// WebhookEvents adalah jenis event yang bisa dilanggani webhook
+var WebhookEvents = []string{
+	EventTaskCreated,
+	EventTaskUpdated,
+	EventTaskDeleted,
+	EventSubtaskToggled,
+	EventRecurrenceInstanceCreated,
+}
+
+// WebhookEventPing dikirim oleh endpoint test ping
+const WebhookEventPing = "ping"
+
+// Status pengiriman webhook
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySending   = "sending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed" // menyerah setelah percobaan maksimal
+)
+
+// Webhook adalah langganan event milik user (task pribadinya) atau milik workspace.
+// Secret dipakai untuk tanda tangan HMAC dan hanya ditampilkan sekali saat dibuat.
+type Webhook struct {
+	ID          int64     `json:"id"`
+	UserID      int64     `json:"user_id"` // pembuat
+	WorkspaceID *int64    `json:"workspace_id"`
+	URL         string    `json:"url"`
+	Events      []string  `json:"events"`
+	Secret      string    `json:"-"`
+	Active      bool      `json:"active"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// WebhookDelivery adalah satu baris antrian pengiriman sekaligus log-nya
+type WebhookDelivery struct {
+	ID             int64           `json:"id"`
+	WebhookID      int64           `json:"webhook_id"`
+	EventType      string          `json:"event_type"`
+	EventID        *int64          `json:"event_id"` // event outbox asal; kosong untuk ping
+	Payload        json.RawMessage `json:"payload" swaggertype:"object"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	ResponseStatus *int            `json:"response_status"`
+	LastError      *string         `json:"last_error"`
+	DeliveredAt    *time.Time      `json:"delivered_at"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+// WebhookInput adalah body POST /webhooks
+type WebhookInput struct {
+	URL         string   `json:"url"`
+	Events      []string `json:"events"`
+	WorkspaceID *int64   `json:"workspace_id"`
+}
+
+// WebhookPatch berisi perubahan parsial untuk PATCH /webhooks/:id
+type WebhookPatch struct {
+	URL    Optional[string]   `json:"url" swaggertype:"string"`
+	Events Optional[[]string] `json:"events" swaggertype:"array,string"`
+	Active Optional[bool]     `json:"active" swaggertype:"boolean"`
+}
+
+type WebhookRepository interface {
+	Create(ctx context.Context, hook *Webhook) error
+	GetByID(ctx context.Context, id int64) (*Webhook, error)
+	// List mengembalikan webhook pribadi userID, atau webhook workspace jika workspaceID diisi
+	List(ctx context.Context, userID int64, workspaceID *int64) ([]Webhook, error)
+	Update(ctx context.Context, hook *Webhook) error
+	Delete(ctx context.Context, id int64) error
+
+	// ListSubscribed mengembalikan webhook aktif yang melanggani eventType: milik
+	// workspaceID, atau webhook pribadi milik salah satu userIDs jika workspaceID nil
+	ListSubscribed(ctx context.Context, eventType string, workspaceID *int64, userIDs []int64) ([]Webhook, error)
+
+	// --- Antrian pengiriman ---
+	// EnqueueDelivery mengembalikan ErrConflict jika (WebhookID, EventID) sudah pernah diantrikan,
+	// dan ErrNotFound jika webhook-nya sudah dihapus
+	EnqueueDelivery(ctx context.Context, d *WebhookDelivery) error
+	// ClaimDeliveries mengubah baris pending yang jatuh tempo milik webhook aktif menjadi sending
+	// (FOR UPDATE SKIP LOCKED) dan menaikkan attempts-nya
+	ClaimDeliveries(ctx context.Context, now time.Time, limit int) ([]WebhookDelivery, error)
+	// CompleteDelivery menyimpan hasil percobaan: status, response, error dan jadwal berikutnya
+	CompleteDelivery(ctx context.Context, d *WebhookDelivery) error
+	// RequeueStale mengembalikan baris yang tertahan di sending sejak sebelum `before` ke pending
+	RequeueStale(ctx context.Context, before time.Time) (int64, error)
+	ListDeliveries(ctx context.Context, webhookID int64, limit int) ([]WebhookDelivery, error)
+}
+
+// WebhookSender mengirim satu delivery ke URL webhook dan mengembalikan status HTTP-nya
+type WebhookSender interface {
+	Send(ctx context.Context, hook Webhook, d WebhookDelivery) (int, error)
+}
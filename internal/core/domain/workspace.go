@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Role anggota workspace, dari yang paling tinggi
+const (
+	RoleOwner  = "owner"  // satu per workspace; satu-satunya yang bisa menghapus workspace dan mengatur admin
+	RoleAdmin  = "admin"  // mengelola anggota & undangan, menghapus task/project siapa pun
+	RoleMember = "member" // membuat & mengubah task/project, menghapus miliknya sendiri
+	RoleViewer = "viewer" // hanya membaca
+)
+
+// ValidRole bernilai true untuk role yang dikenal
+func ValidRole(role string) bool {
+	switch role {
+	case RoleOwner, RoleAdmin, RoleMember, RoleViewer:
+		return true
+	}
+	return false
+}
+
+// Workspace adalah ruang kerja bersama; task dan project di dalamnya terlihat oleh semua anggota
+type Workspace struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	OwnerID   int64     `json:"owner_id"`
+	Role      string    `json:"role,omitempty"` // role user yang meminta, diisi saat listing
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WorkspaceMember adalah keanggotaan seorang user di workspace
+type WorkspaceMember struct {
+	WorkspaceID int64     `json:"workspace_id"`
+	UserID      int64     `json:"user_id"`
+	Name        string    `json:"name"`
+	Email       string    `json:"email"`
+	Role        string    `json:"role"`
+	JoinedAt    time.Time `json:"joined_at"`
+}
+
+// WorkspaceInvitation adalah undangan lewat email; hanya hash token yang disimpan
+type WorkspaceInvitation struct {
+	ID          int64      `json:"id"`
+	WorkspaceID int64      `json:"workspace_id"`
+	Email       string     `json:"email"`
+	Role        string     `json:"role"`
+	TokenHash   string     `json:"-"`
+	InvitedBy   int64      `json:"invited_by"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	AcceptedAt  *time.Time `json:"accepted_at"`
+	RevokedAt   *time.Time `json:"revoked_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// Pending bernilai true jika undangan masih bisa diterima
+func (i *WorkspaceInvitation) Pending(now time.Time) bool {
+	return i.AcceptedAt == nil && i.RevokedAt == nil && now.Before(i.ExpiresAt)
+}
+
+type WorkspaceRepository interface {
+	// Create menyimpan workspace sekaligus menjadikan OwnerID anggota dengan role owner
+	Create(ctx context.Context, ws *Workspace) error
+	// GetByID mengembalikan nil jika workspace tidak ada
+	GetByID(ctx context.Context, id int64) (*Workspace, error)
+	// ListByMember mengembalikan workspace tempat user menjadi anggota, dengan Role terisi
+	ListByMember(ctx context.Context, userID int64) ([]Workspace, error)
+	Update(ctx context.Context, ws *Workspace) error
+	Delete(ctx context.Context, id int64) error
+
+	// GetMember mengembalikan nil jika user bukan anggota workspace
+	GetMember(ctx context.Context, workspaceID int64, userID int64) (*WorkspaceMember, error)
+	ListMembers(ctx context.Context, workspaceID int64) ([]WorkspaceMember, error)
+	UpdateMemberRole(ctx context.Context, workspaceID int64, userID int64, role string) error
+	RemoveMember(ctx context.Context, workspaceID int64, userID int64) error
+
+	CreateInvitation(ctx context.Context, inv *WorkspaceInvitation) error
+	GetInvitation(ctx context.Context, id int64) (*WorkspaceInvitation, error)
+	GetInvitationByHash(ctx context.Context, hash string) (*WorkspaceInvitation, error)
+	// ListInvitations hanya mengembalikan undangan yang belum diterima atau dicabut
+	ListInvitations(ctx context.Context, workspaceID int64) ([]WorkspaceInvitation, error)
+	RevokeInvitation(ctx context.Context, id int64, at time.Time) error
+	// AcceptInvitation menandai undangan diterima dan menambahkan anggota dalam satu transaksi.
+	// Bernilai false jika undangan sudah diterima/dicabut lebih dulu.
+	AcceptInvitation(ctx context.Context, invitationID int64, member *WorkspaceMember, at time.Time) (bool, error)
+}
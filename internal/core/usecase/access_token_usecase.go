@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+	"simple-task-manager/pkg/util"
+)
+
+const (
+	defaultAccessTokenDays = 30
+	maxAccessTokenDays     = 365
+	maxAccessTokenName     = 100
+)
+
+// CreateAccessTokenInput adalah permintaan pembuatan personal access token
+type CreateAccessTokenInput struct {
+	Name          string   `json:"name"`
+	Scopes        []string `json:"scopes"`
+	ExpiresInDays int      `json:"expires_in_days"` // 0 = default 30 hari
+}
+
+// CreatedAccessToken berisi token mentah yang hanya dikembalikan sekali
+type CreatedAccessToken struct {
+	domain.PersonalAccessToken
+	Token string `json:"token"`
+}
+
+type AccessTokenUsecase struct {
+	tokenRepo      domain.AccessTokenRepository
+	contextTimeout time.Duration
+}
+
+func NewAccessTokenUsecase(tokenRepo domain.AccessTokenRepository, timeout time.Duration) *AccessTokenUsecase {
+	return &AccessTokenUsecase{
+		tokenRepo:      tokenRepo,
+		contextTimeout: timeout,
+	}
+}
+
+// Create membuat token baru untuk user; scope wajib diisi dan token selalu punya tanggal kedaluwarsa
+func (u *AccessTokenUsecase) Create(c context.Context, userID int64, in CreateAccessTokenInput) (*CreatedAccessToken, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	name := strings.TrimSpace(in.Name)
+	verr := &domain.ValidationError{}
+	if name == "" {
+		verr.Add("name", "is required")
+	} else if len(name) > maxAccessTokenName {
+		verr.Add("name", "must be at most %d characters", maxAccessTokenName)
+	}
+
+	scopes := make([]string, 0, len(in.Scopes))
+	if len(in.Scopes) == 0 {
+		verr.Add("scopes", "at least one scope is required")
+	}
+	for i, s := range in.Scopes {
+		s = strings.TrimSpace(s)
+		if !slices.Contains(domain.AccessTokenScopes, s) {
+			verr.Add(fmt.Sprintf("scopes[%d]", i), "unknown scope %q", s)
+			continue
+		}
+		if !slices.Contains(scopes, s) {
+			scopes = append(scopes, s)
+		}
+	}
+
+	days := in.ExpiresInDays
+	if days == 0 {
+		days = defaultAccessTokenDays
+	}
+	if days < 0 || days > maxAccessTokenDays {
+		verr.Add("expires_in_days", "must be between 1 and %d", maxAccessTokenDays)
+	}
+	if err := verr.OrNil(); err != nil {
+		return nil, err
+	}
+
+	// Prefix ikut di-hash, jadi hash dari GenerateOpaqueToken tidak dipakai
+	random, _, err := util.GenerateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+	raw := domain.AccessTokenPrefix + random
+
+	now := time.Now()
+	token := domain.PersonalAccessToken{
+		UserID:    userID,
+		Name:      name,
+		Scopes:    scopes,
+		TokenHash: util.HashToken(raw),
+		Hint:      raw[len(raw)-4:],
+		ExpiresAt: now.AddDate(0, 0, days),
+		CreatedAt: now,
+	}
+
+	if err := u.tokenRepo.Create(ctx, &token); err != nil {
+		return nil, err
+	}
+	return &CreatedAccessToken{PersonalAccessToken: token, Token: raw}, nil
+}
+
+// List mengembalikan semua token user (termasuk yang sudah dicabut/kedaluwarsa), terbaru dulu
+func (u *AccessTokenUsecase) List(c context.Context, userID int64) ([]domain.PersonalAccessToken, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	return u.tokenRepo.ListByUser(ctx, userID)
+}
+
+// Revoke mencabut token milik user; token milik user lain diperlakukan seperti tidak ada
+func (u *AccessTokenUsecase) Revoke(c context.Context, userID int64, id int64) error {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	if err := u.tokenRepo.Revoke(ctx, id, userID, time.Now()); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.NotFound("access token %d not found", id)
+		}
+		return err
+	}
+	return nil
+}
+
+// Authenticate dipakai AuthMiddleware untuk memvalidasi token dari header Authorization
+func (u *AccessTokenUsecase) Authenticate(c context.Context, raw string) (*domain.PersonalAccessToken, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	token, err := u.tokenRepo.GetByHash(ctx, util.HashToken(raw))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if token == nil || !token.Active(now) {
+		return nil, domain.Unauthorized("invalid, expired or revoked access token")
+	}
+
+	if err := u.tokenRepo.TouchLastUsed(ctx, token.ID, now); err != nil {
+		log.Printf("failed to update last_used_at for access token %d: %v", token.ID, err)
+	}
+	return token, nil
+}
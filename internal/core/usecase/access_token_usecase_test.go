@@ -0,0 +1,93 @@
+package usecase_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+	"simple-task-manager/internal/core/usecase"
+	"simple-task-manager/internal/core/usecase/mocks"
+	"simple-task-manager/pkg/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateAccessToken(t *testing.T) {
+	t.Run("Success Stores Only Hash", func(t *testing.T) {
+		mockRepo := new(mocks.AccessTokenRepository)
+		u := usecase.NewAccessTokenUsecase(mockRepo, 2*time.Second)
+
+		var stored *domain.PersonalAccessToken
+		mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.PersonalAccessToken")).
+			Run(func(args mock.Arguments) { stored = args.Get(1).(*domain.PersonalAccessToken) }).
+			Return(nil).Once()
+
+		res, err := u.Create(context.Background(), 1, usecase.CreateAccessTokenInput{
+			Name:   " CI bot ",
+			Scopes: []string{domain.ScopeTasksRead, domain.ScopeTasksRead},
+		})
+
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(res.Token, domain.AccessTokenPrefix))
+		assert.Equal(t, util.HashToken(res.Token), stored.TokenHash)
+		assert.Equal(t, "CI bot", stored.Name)
+		assert.Equal(t, []string{domain.ScopeTasksRead}, stored.Scopes)
+		assert.WithinDuration(t, time.Now().AddDate(0, 0, 30), stored.ExpiresAt, time.Minute)
+	})
+
+	t.Run("Invalid Input", func(t *testing.T) {
+		mockRepo := new(mocks.AccessTokenRepository)
+		u := usecase.NewAccessTokenUsecase(mockRepo, 2*time.Second)
+
+		_, err := u.Create(context.Background(), 1, usecase.CreateAccessTokenInput{
+			Scopes:        []string{"admin"},
+			ExpiresInDays: 1000,
+		})
+
+		var verr *domain.ValidationError
+		assert.ErrorAs(t, err, &verr)
+		assert.Len(t, verr.Fields, 3)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAuthenticateAccessToken(t *testing.T) {
+	raw := domain.AccessTokenPrefix + "abc123"
+	revokedAt := time.Now().Add(-time.Minute)
+
+	cases := []struct {
+		name  string
+		token *domain.PersonalAccessToken
+		ok    bool
+	}{
+		{"Active", &domain.PersonalAccessToken{ID: 1, UserID: 7, ExpiresAt: time.Now().Add(time.Hour)}, true},
+		{"Expired", &domain.PersonalAccessToken{ID: 1, UserID: 7, ExpiresAt: time.Now().Add(-time.Hour)}, false},
+		{"Revoked", &domain.PersonalAccessToken{ID: 1, UserID: 7, ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt}, false},
+		{"Unknown", nil, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(mocks.AccessTokenRepository)
+			u := usecase.NewAccessTokenUsecase(mockRepo, 2*time.Second)
+
+			mockRepo.On("GetByHash", mock.Anything, util.HashToken(raw)).Return(tc.token, nil).Once()
+			if tc.ok {
+				mockRepo.On("TouchLastUsed", mock.Anything, int64(1), mock.Anything).Return(nil).Once()
+			}
+
+			token, err := u.Authenticate(context.Background(), raw)
+
+			if tc.ok {
+				assert.NoError(t, err)
+				assert.Equal(t, int64(7), token.UserID)
+			} else {
+				assert.ErrorIs(t, err, domain.ErrUnauthorized)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+)
+
+const (
+	defaultJobLimit = 50
+	maxJobLimit     = 200
+	redactedPayload = "[redacted]"
+)
+
+// JobUsecase adalah endpoint admin untuk memeriksa antrian job background. Belum ada role
+// admin global, jadi admin ditentukan dari daftar email di konfigurasi (ADMIN_EMAILS) yang
+// sudah diverifikasi pemiliknya.
+type JobUsecase struct {
+	jobRepo        domain.JobRepository
+	userRepo       domain.UserRepository
+	admins         []string
+	contextTimeout time.Duration
+}
+
+func NewJobUsecase(jobRepo domain.JobRepository, userRepo domain.UserRepository, adminEmails []string, timeout time.Duration) *JobUsecase {
+	admins := []string{}
+	for _, email := range adminEmails {
+		if email = normalizeEmail(email); email != "" {
+			admins = append(admins, email)
+		}
+	}
+	return &JobUsecase{
+		jobRepo:        jobRepo,
+		userRepo:       userRepo,
+		admins:         admins,
+		contextTimeout: timeout,
+	}
+}
+
+// List job terbaru lebih dulu, bisa difilter per tipe & status
+func (u *JobUsecase) List(c context.Context, userID int64, filter domain.JobFilter) (*domain.JobPage, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	if err := u.authorize(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	verr := &domain.ValidationError{}
+	if filter.Status != "" && !slices.Contains(domain.JobStatuses, filter.Status) {
+		verr.Add("status", "must be one of %s", strings.Join(domain.JobStatuses, ", "))
+	}
+	switch {
+	case filter.Limit == 0:
+		filter.Limit = defaultJobLimit
+	case filter.Limit < 0 || filter.Limit > maxJobLimit:
+		verr.Add("limit", "must be between 1 and %d", maxJobLimit)
+	}
+	if err := verr.OrNil(); err != nil {
+		return nil, err
+	}
+
+	page, err := u.jobRepo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	for i := range page.Data {
+		redactJob(&page.Data[i])
+	}
+	return page, nil
+}
+
+func (u *JobUsecase) Get(c context.Context, userID int64, id int64) (*domain.Job, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	if err := u.authorize(ctx, userID); err != nil {
+		return nil, err
+	}
+	return u.getJob(ctx, id)
+}
+
+// Stats menghitung job per tipe & status, mis. untuk memantau dead letter
+func (u *JobUsecase) Stats(c context.Context, userID int64) ([]domain.JobCount, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	if err := u.authorize(ctx, userID); err != nil {
+		return nil, err
+	}
+	return u.jobRepo.Stats(ctx)
+}
+
+// Retry mengantrikan ulang job dead dengan jatah percobaan penuh
+func (u *JobUsecase) Retry(c context.Context, userID int64, id int64) (*domain.Job, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	if err := u.authorize(ctx, userID); err != nil {
+		return nil, err
+	}
+	job, err := u.getJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status != domain.JobDead {
+		return nil, domain.Conflict("only dead jobs can be retried (job is %s)", job.Status)
+	}
+
+	ok, err := u.jobRepo.Retry(ctx, id, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, domain.Conflict("job was changed by another request")
+	}
+	return u.getJob(ctx, id)
+}
+
+func (u *JobUsecase) getJob(ctx context.Context, id int64) (*domain.Job, error) {
+	job, err := u.jobRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, domain.NotFound("job not found")
+	}
+	redactJob(job)
+	return job, nil
+}
+
+// redactJob menyembunyikan isi email dari respons admin: body-nya bisa berisi token sekali
+// pakai. Penerima & subjek tetap terlihat untuk menelusuri pengiriman yang gagal.
+func redactJob(job *domain.Job) {
+	if job.Type != domain.JobSendEmail {
+		return
+	}
+	var msg domain.Mail
+	_ = json.Unmarshal(job.Payload, &msg)
+	msg.Body = redactedPayload
+	job.Payload, _ = json.Marshal(msg)
+}
+
+func (u *JobUsecase) authorize(ctx context.Context, userID int64) error {
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	// Email belum terverifikasi bisa didaftarkan siapa saja, jadi tidak dianggap admin
+	if user == nil || user.EmailVerifiedAt == nil || !slices.Contains(u.admins, normalizeEmail(user.Email)) {
+		return domain.Forbidden("admin access required")
+	}
+	return nil
+}
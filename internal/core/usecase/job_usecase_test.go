@@ -0,0 +1,142 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+	"simple-task-manager/internal/core/usecase"
+	"simple-task-manager/internal/core/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newJobUsecase() (*usecase.JobUsecase, *mocks.JobRepository) {
+	jobRepo := new(mocks.JobRepository)
+	userRepo := new(mocks.UserRepository)
+	verified := time.Now()
+	userRepo.On("GetByID", mock.Anything, int64(1)).Return(&domain.User{ID: 1, Email: "Ops@Example.com", EmailVerifiedAt: &verified}, nil)
+	userRepo.On("GetByID", mock.Anything, int64(2)).Return(&domain.User{ID: 2, Email: "member@example.com", EmailVerifiedAt: &verified}, nil)
+	// Mendaftar dengan email admin tanpa bisa memverifikasinya
+	userRepo.On("GetByID", mock.Anything, int64(3)).Return(&domain.User{ID: 3, Email: "ops@example.com"}, nil)
+	return usecase.NewJobUsecase(jobRepo, userRepo, []string{" ops@example.com", ""}, 2*time.Second), jobRepo
+}
+
+func TestListJobs(t *testing.T) {
+	t.Run("Success - Admin Gets Default Limit", func(t *testing.T) {
+		u, jobRepo := newJobUsecase()
+		jobRepo.On("List", mock.Anything, domain.JobFilter{Status: domain.JobDead, Limit: 50}).
+			Return(&domain.JobPage{Data: []domain.Job{{ID: 7, Status: domain.JobDead}}}, nil).Once()
+
+		page, err := u.List(context.Background(), 1, domain.JobFilter{Status: domain.JobDead})
+
+		assert.NoError(t, err)
+		assert.Len(t, page.Data, 1)
+		jobRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success - Email Body Is Redacted", func(t *testing.T) {
+		u, jobRepo := newJobUsecase()
+		jobRepo.On("List", mock.Anything, domain.JobFilter{Limit: 50}).Return(&domain.JobPage{Data: []domain.Job{
+			{ID: 8, Type: domain.JobSendEmail, Payload: []byte(`{"to":"ana@example.com","subject":"Reset your password","body":"https://app/reset-password?token=secret"}`)},
+			{ID: 9, Type: "report.export", Payload: []byte(`{"report":1}`)},
+		}}, nil).Once()
+
+		page, err := u.List(context.Background(), 1, domain.JobFilter{})
+
+		if assert.NoError(t, err) && assert.Len(t, page.Data, 2) {
+			assert.JSONEq(t, `{"to":"ana@example.com","subject":"Reset your password","body":"[redacted]"}`, string(page.Data[0].Payload))
+			assert.JSONEq(t, `{"report":1}`, string(page.Data[1].Payload))
+		}
+	})
+
+	t.Run("Failed - Non Admin Is Forbidden", func(t *testing.T) {
+		u, jobRepo := newJobUsecase()
+
+		_, err := u.List(context.Background(), 2, domain.JobFilter{})
+
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+		jobRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failed - Unverified Admin Email Is Forbidden", func(t *testing.T) {
+		u, jobRepo := newJobUsecase()
+
+		_, err := u.List(context.Background(), 3, domain.JobFilter{})
+
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+		jobRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failed - Invalid Status And Limit", func(t *testing.T) {
+		u, _ := newJobUsecase()
+
+		_, err := u.List(context.Background(), 1, domain.JobFilter{Status: "stuck", Limit: 1000})
+
+		var verr *domain.ValidationError
+		if assert.ErrorAs(t, err, &verr) && assert.Len(t, verr.Fields, 2) {
+			assert.Equal(t, "status", verr.Fields[0].Field)
+			assert.Equal(t, "limit", verr.Fields[1].Field)
+		}
+	})
+}
+
+func TestGetJob(t *testing.T) {
+	t.Run("Success - Email Body Is Redacted", func(t *testing.T) {
+		u, jobRepo := newJobUsecase()
+		jobRepo.On("GetByID", mock.Anything, int64(8)).Return(&domain.Job{ID: 8, Type: domain.JobSendEmail,
+			Payload: []byte(`{"to":"ana@example.com","subject":"Verify your email","body":"token=secret"}`)}, nil).Once()
+
+		job, err := u.Get(context.Background(), 1, 8)
+
+		if assert.NoError(t, err) {
+			assert.NotContains(t, string(job.Payload), "secret")
+			assert.Contains(t, string(job.Payload), "ana@example.com")
+		}
+	})
+}
+
+func TestRetryJob(t *testing.T) {
+	t.Run("Success - Dead Job Requeued", func(t *testing.T) {
+		u, jobRepo := newJobUsecase()
+		jobRepo.On("GetByID", mock.Anything, int64(7)).Return(&domain.Job{ID: 7, Status: domain.JobDead, Attempts: 5}, nil).Once()
+		jobRepo.On("Retry", mock.Anything, int64(7), mock.Anything).Return(true, nil).Once()
+		jobRepo.On("GetByID", mock.Anything, int64(7)).Return(&domain.Job{ID: 7, Status: domain.JobPending}, nil).Once()
+
+		job, err := u.Retry(context.Background(), 1, 7)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.JobPending, job.Status)
+		jobRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failed - Only Dead Jobs", func(t *testing.T) {
+		u, jobRepo := newJobUsecase()
+		jobRepo.On("GetByID", mock.Anything, int64(8)).Return(&domain.Job{ID: 8, Status: domain.JobRunning}, nil).Once()
+
+		_, err := u.Retry(context.Background(), 1, 8)
+
+		assert.ErrorIs(t, err, domain.ErrConflict)
+		jobRepo.AssertNotCalled(t, "Retry", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failed - Job Not Found", func(t *testing.T) {
+		u, jobRepo := newJobUsecase()
+		jobRepo.On("GetByID", mock.Anything, int64(9)).Return(nil, nil).Once()
+
+		_, err := u.Retry(context.Background(), 1, 9)
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("Failed - Non Admin Is Forbidden", func(t *testing.T) {
+		u, jobRepo := newJobUsecase()
+
+		_, err := u.Retry(context.Background(), 2, 7)
+
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+		jobRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+}
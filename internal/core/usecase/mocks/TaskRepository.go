@@ -2,30 +2,41 @@ package mocks
 
 import (
     "context"
+    "time"
+
     "simple-task-manager/internal/core/domain"
 )
 
 type TaskRepositoryMock struct {
-    CreateFn        func(ctx context.Context, task *domain.Task) error
-    FetchFn         func(ctx context.Context, userID int64) ([]domain.Task, error)
+    CreateFn        func(ctx context.Context, task *domain.Task, e domain.Event) error
+    FetchFn         func(ctx context.Context, filter domain.TaskFilter) (*domain.TaskPage, error)
     GetByIDFn       func(ctx context.Context, id int64) (*domain.Task, error)
-    UpdateFn        func(ctx context.Context, task *domain.Task) error
-    DeleteFn        func(ctx context.Context, id int64) error
-    CreateSubtaskFn func(ctx context.Context, sub *domain.Subtask) error
-    DeleteSubtaskFn func(ctx context.Context, id int64) error
-    ToggleSubtaskFn func(ctx context.Context, id int64) error
+    UpdateFn        func(ctx context.Context, task *domain.Task, e domain.Event) error
+    DeleteFn        func(ctx context.Context, id int64, e domain.Event) error
+    ListSeriesFn    func(ctx context.Context, parentID int64) ([]domain.Task, error)
+    UpdateSeriesFn  func(ctx context.Context, tasks []*domain.Task, events []domain.Event) error
+
+    UpdateWithFn    func(ctx context.Context, task *domain.Task, e domain.Event, effects domain.TaskEffects) error
+    CreateSubtaskFn func(ctx context.Context, sub *domain.Subtask, e domain.Event) error
+    DeleteSubtaskFn func(ctx context.Context, id int64, e domain.Event) error
+    ToggleSubtaskFn func(ctx context.Context, id int64, e domain.Event) error
+
+    GetSubtaskTaskIDFn func(ctx context.Context, id int64) (int64, error)
+
+    AddAssigneeFn    func(ctx context.Context, taskID int64, userID int64, assignedBy int64, at time.Time, e domain.Event) (bool, error)
+    RemoveAssigneeFn func(ctx context.Context, taskID int64, userID int64, e domain.Event) (bool, error)
 }
 
-func (m *TaskRepositoryMock) Create(ctx context.Context, task *domain.Task) error {
+func (m *TaskRepositoryMock) Create(ctx context.Context, task *domain.Task, e domain.Event) error {
     if m.CreateFn != nil {
-        return m.CreateFn(ctx, task)
+        return m.CreateFn(ctx, task, e)
     }
     return nil
 }
 
-func (m *TaskRepositoryMock) Fetch(ctx context.Context, userID int64) ([]domain.Task, error) {
+func (m *TaskRepositoryMock) Fetch(ctx context.Context, filter domain.TaskFilter) (*domain.TaskPage, error) {
     if m.FetchFn != nil {
-        return m.FetchFn(ctx, userID)
+        return m.FetchFn(ctx, filter)
     }
     return nil, nil
 }
@@ -37,37 +48,79 @@ func (m *TaskRepositoryMock) GetByID(ctx context.Context, id int64) (*domain.Tas
     return nil, nil
 }
 
-func (m *TaskRepositoryMock) Update(ctx context.Context, task *domain.Task) error {
+func (m *TaskRepositoryMock) Update(ctx context.Context, task *domain.Task, e domain.Event) error {
     if m.UpdateFn != nil {
-        return m.UpdateFn(ctx, task)
+        return m.UpdateFn(ctx, task, e)
     }
     return nil
 }
 
-func (m *TaskRepositoryMock) Delete(ctx context.Context, id int64) error {
+func (m *TaskRepositoryMock) Delete(ctx context.Context, id int64, e domain.Event) error {
     if m.DeleteFn != nil {
-        return m.DeleteFn(ctx, id)
+        return m.DeleteFn(ctx, id, e)
     }
     return nil
 }
 
-func (m *TaskRepositoryMock) CreateSubtask(ctx context.Context, sub *domain.Subtask) error {
+func (m *TaskRepositoryMock) ListSeries(ctx context.Context, parentID int64) ([]domain.Task, error) {
+    if m.ListSeriesFn != nil {
+        return m.ListSeriesFn(ctx, parentID)
+    }
+    return nil, nil
+}
+
+func (m *TaskRepositoryMock) UpdateWith(ctx context.Context, task *domain.Task, e domain.Event, effects domain.TaskEffects) error {
+    if m.UpdateWithFn != nil {
+        return m.UpdateWithFn(ctx, task, e, effects)
+    }
+    return nil
+}
+
+func (m *TaskRepositoryMock) UpdateSeries(ctx context.Context, tasks []*domain.Task, events []domain.Event) error {
+    if m.UpdateSeriesFn != nil {
+        return m.UpdateSeriesFn(ctx, tasks, events)
+    }
+    return nil
+}
+
+func (m *TaskRepositoryMock) CreateSubtask(ctx context.Context, sub *domain.Subtask, e domain.Event) error {
     if m.CreateSubtaskFn != nil {
-        return m.CreateSubtaskFn(ctx, sub)
+        return m.CreateSubtaskFn(ctx, sub, e)
     }
     return nil
 }
 
-func (m *TaskRepositoryMock) DeleteSubtask(ctx context.Context, id int64) error {
+func (m *TaskRepositoryMock) DeleteSubtask(ctx context.Context, id int64, e domain.Event) error {
     if m.DeleteSubtaskFn != nil {
-        return m.DeleteSubtaskFn(ctx, id)
+        return m.DeleteSubtaskFn(ctx, id, e)
     }
     return nil
 }
 
-func (m *TaskRepositoryMock) ToggleSubtask(ctx context.Context, id int64) error {
+func (m *TaskRepositoryMock) ToggleSubtask(ctx context.Context, id int64, e domain.Event) error {
     if m.ToggleSubtaskFn != nil {
-        return m.ToggleSubtaskFn(ctx, id)
+        return m.ToggleSubtaskFn(ctx, id, e)
     }
     return nil
 }
+
+func (m *TaskRepositoryMock) GetSubtaskTaskID(ctx context.Context, id int64) (int64, error) {
+    if m.GetSubtaskTaskIDFn != nil {
+        return m.GetSubtaskTaskIDFn(ctx, id)
+    }
+    return 0, nil
+}
+
+func (m *TaskRepositoryMock) AddAssignee(ctx context.Context, taskID int64, userID int64, assignedBy int64, at time.Time, e domain.Event) (bool, error) {
+    if m.AddAssigneeFn != nil {
+        return m.AddAssigneeFn(ctx, taskID, userID, assignedBy, at, e)
+    }
+    return true, nil
+}
+
+func (m *TaskRepositoryMock) RemoveAssignee(ctx context.Context, taskID int64, userID int64, e domain.Event) (bool, error) {
+    if m.RemoveAssigneeFn != nil {
+        return m.RemoveAssigneeFn(ctx, taskID, userID, e)
+    }
+    return true, nil
+}
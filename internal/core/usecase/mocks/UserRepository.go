@@ -2,6 +2,8 @@ package mocks
 
 import (
     "context"
+    "time"
+
     "simple-task-manager/internal/core/domain"
 )
 
@@ -9,6 +11,17 @@ type UserRepositoryMock struct {
     CreateFn     func(ctx context.Context, user *domain.User) error
     GetByEmailFn func(ctx context.Context, email string) (*domain.User, error)
     GetByIDFn    func(ctx context.Context, id int64) (*domain.User, error)
+
+    MarkEmailVerifiedFn func(ctx context.Context, id int64, at time.Time) error
+    UpdatePasswordFn    func(ctx context.Context, id int64, hashedPassword string, at time.Time) error
+    UpdateProfileFn     func(ctx context.Context, user *domain.User) error
+
+    SetTOTPSecretFn        func(ctx context.Context, id int64, secret string, at time.Time) error
+    EnableMFAFn            func(ctx context.Context, id int64, at time.Time) error
+    DisableMFAFn           func(ctx context.Context, id int64, at time.Time) error
+    ClaimTOTPStepFn        func(ctx context.Context, id int64, step int64) (bool, error)
+    ReplaceRecoveryCodesFn func(ctx context.Context, userID int64, hashes []string, at time.Time) error
+    UseRecoveryCodeFn      func(ctx context.Context, userID int64, hash string, at time.Time) (bool, error)
 }
 
 func (m *UserRepositoryMock) Create(ctx context.Context, user *domain.User) error {
@@ -31,3 +44,66 @@ func (m *UserRepositoryMock) GetByID(ctx context.Context, id int64) (*domain.Use
     }
     return nil, nil
 }
+
+func (m *UserRepositoryMock) MarkEmailVerified(ctx context.Context, id int64, at time.Time) error {
+    if m.MarkEmailVerifiedFn != nil {
+        return m.MarkEmailVerifiedFn(ctx, id, at)
+    }
+    return nil
+}
+
+func (m *UserRepositoryMock) UpdatePassword(ctx context.Context, id int64, hashedPassword string, at time.Time) error {
+    if m.UpdatePasswordFn != nil {
+        return m.UpdatePasswordFn(ctx, id, hashedPassword, at)
+    }
+    return nil
+}
+
+func (m *UserRepositoryMock) UpdateProfile(ctx context.Context, user *domain.User) error {
+    if m.UpdateProfileFn != nil {
+        return m.UpdateProfileFn(ctx, user)
+    }
+    return nil
+}
+
+func (m *UserRepositoryMock) SetTOTPSecret(ctx context.Context, id int64, secret string, at time.Time) error {
+    if m.SetTOTPSecretFn != nil {
+        return m.SetTOTPSecretFn(ctx, id, secret, at)
+    }
+    return nil
+}
+
+func (m *UserRepositoryMock) EnableMFA(ctx context.Context, id int64, at time.Time) error {
+    if m.EnableMFAFn != nil {
+        return m.EnableMFAFn(ctx, id, at)
+    }
+    return nil
+}
+
+func (m *UserRepositoryMock) DisableMFA(ctx context.Context, id int64, at time.Time) error {
+    if m.DisableMFAFn != nil {
+        return m.DisableMFAFn(ctx, id, at)
+    }
+    return nil
+}
+
+func (m *UserRepositoryMock) ClaimTOTPStep(ctx context.Context, id int64, step int64) (bool, error) {
+    if m.ClaimTOTPStepFn != nil {
+        return m.ClaimTOTPStepFn(ctx, id, step)
+    }
+    return true, nil
+}
+
+func (m *UserRepositoryMock) ReplaceRecoveryCodes(ctx context.Context, userID int64, hashes []string, at time.Time) error {
+    if m.ReplaceRecoveryCodesFn != nil {
+        return m.ReplaceRecoveryCodesFn(ctx, userID, hashes, at)
+    }
+    return nil
+}
+
+func (m *UserRepositoryMock) UseRecoveryCode(ctx context.Context, userID int64, hash string, at time.Time) (bool, error) {
+    if m.UseRecoveryCodeFn != nil {
+        return m.UseRecoveryCodeFn(ctx, userID, hash, at)
+    }
+    return false, nil
+}
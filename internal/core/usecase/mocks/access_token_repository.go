@@ -0,0 +1,46 @@
+package mocks
+
+import (
+    "context"
+    "time"
+
+    "simple-task-manager/internal/core/domain"
+
+    "github.com/stretchr/testify/mock"
+)
+
+// AccessTokenRepository adalah mock untuk domain.AccessTokenRepository
+type AccessTokenRepository struct {
+    mock.Mock
+}
+
+func (m *AccessTokenRepository) Create(ctx context.Context, t *domain.PersonalAccessToken) error {
+    args := m.Called(ctx, t)
+    return args.Error(0)
+}
+
+func (m *AccessTokenRepository) ListByUser(ctx context.Context, userID int64) ([]domain.PersonalAccessToken, error) {
+    args := m.Called(ctx, userID)
+    if t := args.Get(0); t != nil {
+        return t.([]domain.PersonalAccessToken), args.Error(1)
+    }
+    return nil, args.Error(1)
+}
+
+func (m *AccessTokenRepository) GetByHash(ctx context.Context, hash string) (*domain.PersonalAccessToken, error) {
+    args := m.Called(ctx, hash)
+    if t := args.Get(0); t != nil {
+        return t.(*domain.PersonalAccessToken), args.Error(1)
+    }
+    return nil, args.Error(1)
+}
+
+func (m *AccessTokenRepository) Revoke(ctx context.Context, id int64, userID int64, at time.Time) error {
+    args := m.Called(ctx, id, userID, at)
+    return args.Error(0)
+}
+
+func (m *AccessTokenRepository) TouchLastUsed(ctx context.Context, id int64, at time.Time) error {
+    args := m.Called(ctx, id, at)
+    return args.Error(0)
+}
@@ -0,0 +1,19 @@
+package mocks
+
+import (
+    "context"
+
+    "simple-task-manager/internal/core/domain"
+
+    "github.com/stretchr/testify/mock"
+)
+
+// EventPublisher adalah mock untuk domain.EventPublisher
+type EventPublisher struct {
+    mock.Mock
+}
+
+func (m *EventPublisher) Publish(ctx context.Context, e domain.Event) error {
+    args := m.Called(ctx, e)
+    return args.Error(0)
+}
@@ -0,0 +1,72 @@
+package mocks
+
+import (
+    "context"
+    "time"
+
+    "simple-task-manager/internal/core/domain"
+
+    "github.com/stretchr/testify/mock"
+)
+
+// JobRepository adalah mock untuk domain.JobRepository
+type JobRepository struct {
+    mock.Mock
+}
+
+func (m *JobRepository) Enqueue(ctx context.Context, job *domain.Job) error {
+    args := m.Called(ctx, job)
+    return args.Error(0)
+}
+
+func (m *JobRepository) Claim(ctx context.Context, jobType string, now time.Time, limit int) ([]domain.Job, error) {
+    args := m.Called(ctx, jobType, now, limit)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).([]domain.Job), args.Error(1)
+}
+
+func (m *JobRepository) Finish(ctx context.Context, job *domain.Job) (bool, error) {
+    args := m.Called(ctx, job)
+    return args.Bool(0), args.Error(1)
+}
+
+func (m *JobRepository) RequeueStale(ctx context.Context, before time.Time) (int64, error) {
+    args := m.Called(ctx, before)
+    return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *JobRepository) GetByID(ctx context.Context, id int64) (*domain.Job, error) {
+    args := m.Called(ctx, id)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).(*domain.Job), args.Error(1)
+}
+
+func (m *JobRepository) List(ctx context.Context, filter domain.JobFilter) (*domain.JobPage, error) {
+    args := m.Called(ctx, filter)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).(*domain.JobPage), args.Error(1)
+}
+
+func (m *JobRepository) Stats(ctx context.Context) ([]domain.JobCount, error) {
+    args := m.Called(ctx)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).([]domain.JobCount), args.Error(1)
+}
+
+func (m *JobRepository) Retry(ctx context.Context, id int64, at time.Time) (bool, error) {
+    args := m.Called(ctx, id, at)
+    return args.Bool(0), args.Error(1)
+}
+
+func (m *JobRepository) PurgeFinished(ctx context.Context, jobType string, before time.Time) (int64, error) {
+    args := m.Called(ctx, jobType, before)
+    return args.Get(0).(int64), args.Error(1)
+}
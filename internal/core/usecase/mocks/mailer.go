@@ -0,0 +1,18 @@
+package mocks
+
+import (
+    "context"
+    "simple-task-manager/internal/core/domain"
+
+    "github.com/stretchr/testify/mock"
+)
+
+// Mailer adalah mock untuk domain.Mailer
+type Mailer struct {
+    mock.Mock
+}
+
+func (m *Mailer) Send(ctx context.Context, msg domain.Mail) error {
+    args := m.Called(ctx, msg)
+    return args.Error(0)
+}
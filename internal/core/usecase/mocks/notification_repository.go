@@ -0,0 +1,43 @@
+package mocks
+
+import (
+    "context"
+    "time"
+
+    "simple-task-manager/internal/core/domain"
+
+    "github.com/stretchr/testify/mock"
+)
+
+// NotificationRepository adalah mock untuk domain.NotificationRepository
+type NotificationRepository struct {
+    mock.Mock
+}
+
+func (m *NotificationRepository) Create(ctx context.Context, n *domain.Notification) error {
+    args := m.Called(ctx, n)
+    return args.Error(0)
+}
+
+func (m *NotificationRepository) Fetch(ctx context.Context, filter domain.NotificationFilter) (*domain.NotificationPage, error) {
+    args := m.Called(ctx, filter)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).(*domain.NotificationPage), args.Error(1)
+}
+
+func (m *NotificationRepository) MarkRead(ctx context.Context, id int64, userID int64, at time.Time) (bool, error) {
+    args := m.Called(ctx, id, userID, at)
+    return args.Bool(0), args.Error(1)
+}
+
+func (m *NotificationRepository) MarkAllRead(ctx context.Context, userID int64, at time.Time) (int64, error) {
+    args := m.Called(ctx, userID, at)
+    return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *NotificationRepository) CountUnread(ctx context.Context, userID int64) (int64, error) {
+    args := m.Called(ctx, userID)
+    return args.Get(0).(int64), args.Error(1)
+}
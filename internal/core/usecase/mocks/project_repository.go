@@ -0,0 +1,45 @@
+package mocks
+
+import (
+    "context"
+
+    "simple-task-manager/internal/core/domain"
+
+    "github.com/stretchr/testify/mock"
+)
+
+// ProjectRepository adalah mock untuk domain.ProjectRepository
+type ProjectRepository struct {
+    mock.Mock
+}
+
+func (m *ProjectRepository) Create(ctx context.Context, p *domain.Project) error {
+    args := m.Called(ctx, p)
+    return args.Error(0)
+}
+
+func (m *ProjectRepository) GetByID(ctx context.Context, id int64) (*domain.Project, error) {
+    args := m.Called(ctx, id)
+    if p := args.Get(0); p != nil {
+        return p.(*domain.Project), args.Error(1)
+    }
+    return nil, args.Error(1)
+}
+
+func (m *ProjectRepository) ListVisible(ctx context.Context, userID int64, includeArchived bool) ([]domain.Project, error) {
+    args := m.Called(ctx, userID, includeArchived)
+    if p := args.Get(0); p != nil {
+        return p.([]domain.Project), args.Error(1)
+    }
+    return nil, args.Error(1)
+}
+
+func (m *ProjectRepository) Update(ctx context.Context, p *domain.Project) error {
+    args := m.Called(ctx, p)
+    return args.Error(0)
+}
+
+func (m *ProjectRepository) Delete(ctx context.Context, id int64) error {
+    args := m.Called(ctx, id)
+    return args.Error(0)
+}
@@ -0,0 +1,43 @@
+package mocks
+
+import (
+    "context"
+    "time"
+
+    "simple-task-manager/internal/core/domain"
+
+    "github.com/stretchr/testify/mock"
+)
+
+// ReminderRepository adalah mock untuk domain.ReminderRepository
+type ReminderRepository struct {
+    mock.Mock
+}
+
+func (m *ReminderRepository) ScheduleDue(ctx context.Context, now time.Time, lookback time.Duration, channels []string) (int64, error) {
+    args := m.Called(ctx, now, lookback, channels)
+    return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *ReminderRepository) ClaimDue(ctx context.Context, now time.Time, limit int) ([]domain.ReminderDelivery, error) {
+    args := m.Called(ctx, now, limit)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).([]domain.ReminderDelivery), args.Error(1)
+}
+
+func (m *ReminderRepository) MarkSent(ctx context.Context, id int64, at time.Time) error {
+    args := m.Called(ctx, id, at)
+    return args.Error(0)
+}
+
+func (m *ReminderRepository) MarkFailed(ctx context.Context, id int64, reason string, retryAt *time.Time, at time.Time) error {
+    args := m.Called(ctx, id, reason, retryAt, at)
+    return args.Error(0)
+}
+
+func (m *ReminderRepository) AbandonStale(ctx context.Context, before time.Time) (int64, error) {
+    args := m.Called(ctx, before)
+    return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,56 @@
+package mocks
+
+import (
+    "context"
+    "time"
+
+    "simple-task-manager/internal/core/domain"
+
+    "github.com/stretchr/testify/mock"
+)
+
+// SessionRepository adalah mock untuk domain.SessionRepository
+type SessionRepository struct {
+    mock.Mock
+}
+
+func (m *SessionRepository) CreateSession(ctx context.Context, s *domain.Session) error {
+    args := m.Called(ctx, s)
+    return args.Error(0)
+}
+
+func (m *SessionRepository) GetSession(ctx context.Context, id int64) (*domain.Session, error) {
+    args := m.Called(ctx, id)
+    if s := args.Get(0); s != nil {
+        return s.(*domain.Session), args.Error(1)
+    }
+    return nil, args.Error(1)
+}
+
+func (m *SessionRepository) RevokeSession(ctx context.Context, id int64, at time.Time) error {
+    args := m.Called(ctx, id, at)
+    return args.Error(0)
+}
+
+func (m *SessionRepository) RevokeUserSessions(ctx context.Context, userID int64, at time.Time) error {
+    args := m.Called(ctx, userID, at)
+    return args.Error(0)
+}
+
+func (m *SessionRepository) CreateRefreshToken(ctx context.Context, t *domain.RefreshToken) error {
+    args := m.Called(ctx, t)
+    return args.Error(0)
+}
+
+func (m *SessionRepository) GetRefreshTokenByHash(ctx context.Context, hash string) (*domain.RefreshToken, error) {
+    args := m.Called(ctx, hash)
+    if t := args.Get(0); t != nil {
+        return t.(*domain.RefreshToken), args.Error(1)
+    }
+    return nil, args.Error(1)
+}
+
+func (m *SessionRepository) MarkRefreshTokenUsed(ctx context.Context, id int64, at time.Time) (bool, error) {
+    args := m.Called(ctx, id, at)
+    return args.Bool(0), args.Error(1)
+}
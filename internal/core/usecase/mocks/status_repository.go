@@ -0,0 +1,36 @@
+package mocks
+
+import (
+    "context"
+    "simple-task-manager/internal/core/domain"
+
+    "github.com/stretchr/testify/mock"
+)
+
+// StatusRepository adalah mock untuk domain.StatusRepository
+type StatusRepository struct {
+    mock.Mock
+}
+
+func (m *StatusRepository) GetWorkflow(ctx context.Context, userID int64) (*domain.Workflow, error) {
+    args := m.Called(ctx, userID)
+    if wf := args.Get(0); wf != nil {
+        return wf.(*domain.Workflow), args.Error(1)
+    }
+    return nil, args.Error(1)
+}
+
+func (m *StatusRepository) SaveWorkflow(ctx context.Context, userID int64, wf *domain.Workflow) error {
+    args := m.Called(ctx, userID, wf)
+    return args.Error(0)
+}
+
+func (m *StatusRepository) DeleteWorkflow(ctx context.Context, userID int64) error {
+    args := m.Called(ctx, userID)
+    return args.Error(0)
+}
+
+func (m *StatusRepository) LogChange(ctx context.Context, change *domain.StatusChange) error {
+    args := m.Called(ctx, change)
+    return args.Error(0)
+}
@@ -0,0 +1,25 @@
+package mocks
+
+import (
+    "context"
+    "time"
+
+    "simple-task-manager/internal/core/domain"
+
+    "github.com/stretchr/testify/mock"
+)
+
+// TaskEventRepository adalah mock untuk domain.TaskEventRepository
+type TaskEventRepository struct {
+    mock.Mock
+}
+
+func (m *TaskEventRepository) Drain(ctx context.Context, limit, maxAttempts int, fn domain.EventHandler) (int, error) {
+    args := m.Called(ctx, limit, maxAttempts, fn)
+    return args.Int(0), args.Error(1)
+}
+
+func (m *TaskEventRepository) PurgePublished(ctx context.Context, before time.Time) (int64, error) {
+    args := m.Called(ctx, before)
+    return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,45 @@
+package mocks
+
+import (
+    "context"
+
+    "simple-task-manager/internal/core/domain"
+
+    "github.com/stretchr/testify/mock"
+)
+
+// TaskReminderRepository adalah mock untuk domain.TaskReminderRepository
+type TaskReminderRepository struct {
+    mock.Mock
+}
+
+func (m *TaskReminderRepository) Create(ctx context.Context, reminder *domain.TaskReminder) error {
+    args := m.Called(ctx, reminder)
+    return args.Error(0)
+}
+
+func (m *TaskReminderRepository) GetByID(ctx context.Context, id int64) (*domain.TaskReminder, error) {
+    args := m.Called(ctx, id)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).(*domain.TaskReminder), args.Error(1)
+}
+
+func (m *TaskReminderRepository) ListByTask(ctx context.Context, taskID int64) ([]domain.TaskReminder, error) {
+    args := m.Called(ctx, taskID)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).([]domain.TaskReminder), args.Error(1)
+}
+
+func (m *TaskReminderRepository) Update(ctx context.Context, reminder *domain.TaskReminder) error {
+    args := m.Called(ctx, reminder)
+    return args.Error(0)
+}
+
+func (m *TaskReminderRepository) Delete(ctx context.Context, id int64) error {
+    args := m.Called(ctx, id)
+    return args.Error(0)
+}
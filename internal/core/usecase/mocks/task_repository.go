@@ -2,6 +2,8 @@ package mocks
 
 import (
     "context"
+    "time"
+
     "simple-task-manager/internal/core/domain"
 
     "github.com/stretchr/testify/mock"
@@ -12,14 +14,17 @@ type TaskRepository struct {
     mock.Mock
 }
 
-func (m *TaskRepository) Create(ctx context.Context, task *domain.Task) error {
-    args := m.Called(ctx, task)
+func (m *TaskRepository) Create(ctx context.Context, task *domain.Task, e domain.Event) error {
+    args := m.Called(ctx, task, e)
     return args.Error(0)
 }
 
-func (m *TaskRepository) Fetch(ctx context.Context, userID int64) ([]domain.Task, error) {
-    args := m.Called(ctx, userID)
-    return args.Get(0).([]domain.Task), args.Error(1)
+func (m *TaskRepository) Fetch(ctx context.Context, filter domain.TaskFilter) (*domain.TaskPage, error) {
+    args := m.Called(ctx, filter)
+    if p := args.Get(0); p != nil {
+        return p.(*domain.TaskPage), args.Error(1)
+    }
+    return nil, args.Error(1)
 }
 
 func (m *TaskRepository) GetByID(ctx context.Context, id int64) (*domain.Task, error) {
@@ -27,27 +32,60 @@ func (m *TaskRepository) GetByID(ctx context.Context, id int64) (*domain.Task, e
     return args.Get(0).(*domain.Task), args.Error(1)
 }
 
-func (m *TaskRepository) Update(ctx context.Context, task *domain.Task) error {
-    args := m.Called(ctx, task)
+func (m *TaskRepository) Update(ctx context.Context, task *domain.Task, e domain.Event) error {
+    args := m.Called(ctx, task, e)
     return args.Error(0)
 }
 
-func (m *TaskRepository) Delete(ctx context.Context, id int64) error {
-    args := m.Called(ctx, id)
+func (m *TaskRepository) UpdateWith(ctx context.Context, task *domain.Task, e domain.Event, effects domain.TaskEffects) error {
+    args := m.Called(ctx, task, e, effects)
     return args.Error(0)
 }
 
-func (m *TaskRepository) CreateSubtask(ctx context.Context, sub *domain.Subtask) error {
-    args := m.Called(ctx, sub)
+func (m *TaskRepository) Delete(ctx context.Context, id int64, e domain.Event) error {
+    args := m.Called(ctx, id, e)
     return args.Error(0)
 }
 
-func (m *TaskRepository) DeleteSubtask(ctx context.Context, id int64) error {
-    args := m.Called(ctx, id)
+func (m *TaskRepository) ListSeries(ctx context.Context, parentID int64) ([]domain.Task, error) {
+    args := m.Called(ctx, parentID)
+    if t := args.Get(0); t != nil {
+        return t.([]domain.Task), args.Error(1)
+    }
+    return nil, args.Error(1)
+}
+
+func (m *TaskRepository) UpdateSeries(ctx context.Context, tasks []*domain.Task, events []domain.Event) error {
+    args := m.Called(ctx, tasks, events)
     return args.Error(0)
 }
 
-func (m *TaskRepository) ToggleSubtask(ctx context.Context, id int64) error {
-    args := m.Called(ctx, id)
+func (m *TaskRepository) CreateSubtask(ctx context.Context, sub *domain.Subtask, e domain.Event) error {
+    args := m.Called(ctx, sub, e)
     return args.Error(0)
 }
+
+func (m *TaskRepository) DeleteSubtask(ctx context.Context, id int64, e domain.Event) error {
+    args := m.Called(ctx, id, e)
+    return args.Error(0)
+}
+
+func (m *TaskRepository) ToggleSubtask(ctx context.Context, id int64, e domain.Event) error {
+    args := m.Called(ctx, id, e)
+    return args.Error(0)
+}
+
+func (m *TaskRepository) GetSubtaskTaskID(ctx context.Context, id int64) (int64, error) {
+    args := m.Called(ctx, id)
+    return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *TaskRepository) AddAssignee(ctx context.Context, taskID int64, userID int64, assignedBy int64, at time.Time, e domain.Event) (bool, error) {
+    args := m.Called(ctx, taskID, userID, assignedBy, at, e)
+    return args.Bool(0), args.Error(1)
+}
+
+func (m *TaskRepository) RemoveAssignee(ctx context.Context, taskID int64, userID int64, e domain.Event) (bool, error) {
+    args := m.Called(ctx, taskID, userID, e)
+    return args.Bool(0), args.Error(1)
+}
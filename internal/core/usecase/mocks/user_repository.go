@@ -2,6 +2,8 @@ package mocks
 
 import (
     "context"
+    "time"
+
     "simple-task-manager/internal/core/domain"
 
     "github.com/stretchr/testify/mock"
@@ -32,3 +34,48 @@ func (m *UserRepository) GetByID(ctx context.Context, id int64) (*domain.User, e
     }
     return nil, args.Error(1)
 }
+
+func (m *UserRepository) MarkEmailVerified(ctx context.Context, id int64, at time.Time) error {
+    args := m.Called(ctx, id, at)
+    return args.Error(0)
+}
+
+func (m *UserRepository) UpdatePassword(ctx context.Context, id int64, hashedPassword string, at time.Time) error {
+    args := m.Called(ctx, id, hashedPassword, at)
+    return args.Error(0)
+}
+
+func (m *UserRepository) UpdateProfile(ctx context.Context, user *domain.User) error {
+    args := m.Called(ctx, user)
+    return args.Error(0)
+}
+
+func (m *UserRepository) SetTOTPSecret(ctx context.Context, id int64, secret string, at time.Time) error {
+    args := m.Called(ctx, id, secret, at)
+    return args.Error(0)
+}
+
+func (m *UserRepository) EnableMFA(ctx context.Context, id int64, at time.Time) error {
+    args := m.Called(ctx, id, at)
+    return args.Error(0)
+}
+
+func (m *UserRepository) DisableMFA(ctx context.Context, id int64, at time.Time) error {
+    args := m.Called(ctx, id, at)
+    return args.Error(0)
+}
+
+func (m *UserRepository) ClaimTOTPStep(ctx context.Context, id int64, step int64) (bool, error) {
+    args := m.Called(ctx, id, step)
+    return args.Bool(0), args.Error(1)
+}
+
+func (m *UserRepository) ReplaceRecoveryCodes(ctx context.Context, userID int64, hashes []string, at time.Time) error {
+    args := m.Called(ctx, userID, hashes, at)
+    return args.Error(0)
+}
+
+func (m *UserRepository) UseRecoveryCode(ctx context.Context, userID int64, hash string, at time.Time) (bool, error) {
+    args := m.Called(ctx, userID, hash, at)
+    return args.Bool(0), args.Error(1)
+}
@@ -0,0 +1,38 @@
+package mocks
+
+import (
+    "context"
+    "time"
+
+    "simple-task-manager/internal/core/domain"
+
+    "github.com/stretchr/testify/mock"
+)
+
+// UserTokenRepository adalah mock untuk domain.UserTokenRepository
+type UserTokenRepository struct {
+    mock.Mock
+}
+
+func (m *UserTokenRepository) Create(ctx context.Context, t *domain.UserToken) error {
+    args := m.Called(ctx, t)
+    return args.Error(0)
+}
+
+func (m *UserTokenRepository) GetByHash(ctx context.Context, purpose string, hash string) (*domain.UserToken, error) {
+    args := m.Called(ctx, purpose, hash)
+    if t := args.Get(0); t != nil {
+        return t.(*domain.UserToken), args.Error(1)
+    }
+    return nil, args.Error(1)
+}
+
+func (m *UserTokenRepository) MarkUsed(ctx context.Context, id int64, at time.Time) (bool, error) {
+    args := m.Called(ctx, id, at)
+    return args.Bool(0), args.Error(1)
+}
+
+func (m *UserTokenRepository) InvalidateAll(ctx context.Context, userID int64, purpose string, at time.Time) error {
+    args := m.Called(ctx, userID, purpose, at)
+    return args.Error(0)
+}
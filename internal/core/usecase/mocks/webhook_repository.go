@@ -0,0 +1,85 @@
+package mocks
+
+import (
+    "context"
+    "time"
+
+    "simple-task-manager/internal/core/domain"
+
+    "github.com/stretchr/testify/mock"
+)
+
+// WebhookRepository adalah mock untuk domain.WebhookRepository
+type WebhookRepository struct {
+    mock.Mock
+}
+
+func (m *WebhookRepository) Create(ctx context.Context, hook *domain.Webhook) error {
+    args := m.Called(ctx, hook)
+    return args.Error(0)
+}
+
+func (m *WebhookRepository) GetByID(ctx context.Context, id int64) (*domain.Webhook, error) {
+    args := m.Called(ctx, id)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).(*domain.Webhook), args.Error(1)
+}
+
+func (m *WebhookRepository) List(ctx context.Context, userID int64, workspaceID *int64) ([]domain.Webhook, error) {
+    args := m.Called(ctx, userID, workspaceID)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).([]domain.Webhook), args.Error(1)
+}
+
+func (m *WebhookRepository) Update(ctx context.Context, hook *domain.Webhook) error {
+    args := m.Called(ctx, hook)
+    return args.Error(0)
+}
+
+func (m *WebhookRepository) Delete(ctx context.Context, id int64) error {
+    args := m.Called(ctx, id)
+    return args.Error(0)
+}
+
+func (m *WebhookRepository) ListSubscribed(ctx context.Context, eventType string, workspaceID *int64, userIDs []int64) ([]domain.Webhook, error) {
+    args := m.Called(ctx, eventType, workspaceID, userIDs)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).([]domain.Webhook), args.Error(1)
+}
+
+func (m *WebhookRepository) EnqueueDelivery(ctx context.Context, d *domain.WebhookDelivery) error {
+    args := m.Called(ctx, d)
+    return args.Error(0)
+}
+
+func (m *WebhookRepository) ClaimDeliveries(ctx context.Context, now time.Time, limit int) ([]domain.WebhookDelivery, error) {
+    args := m.Called(ctx, now, limit)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).([]domain.WebhookDelivery), args.Error(1)
+}
+
+func (m *WebhookRepository) CompleteDelivery(ctx context.Context, d *domain.WebhookDelivery) error {
+    args := m.Called(ctx, d)
+    return args.Error(0)
+}
+
+func (m *WebhookRepository) RequeueStale(ctx context.Context, before time.Time) (int64, error) {
+    args := m.Called(ctx, before)
+    return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *WebhookRepository) ListDeliveries(ctx context.Context, webhookID int64, limit int) ([]domain.WebhookDelivery, error) {
+    args := m.Called(ctx, webhookID, limit)
+    if args.Get(0) == nil {
+        return nil, args.Error(1)
+    }
+    return args.Get(0).([]domain.WebhookDelivery), args.Error(1)
+}
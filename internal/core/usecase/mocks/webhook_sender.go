@@ -0,0 +1,19 @@
+package mocks
+
+import (
+    "context"
+
+    "simple-task-manager/internal/core/domain"
+
+    "github.com/stretchr/testify/mock"
+)
+
+// WebhookSender adalah mock untuk domain.WebhookSender
+type WebhookSender struct {
+    mock.Mock
+}
+
+func (m *WebhookSender) Send(ctx context.Context, hook domain.Webhook, d domain.WebhookDelivery) (int, error) {
+    args := m.Called(ctx, hook, d)
+    return args.Int(0), args.Error(1)
+}
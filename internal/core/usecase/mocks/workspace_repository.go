@@ -0,0 +1,111 @@
+package mocks
+
+import (
+    "context"
+    "time"
+
+    "simple-task-manager/internal/core/domain"
+
+    "github.com/stretchr/testify/mock"
+)
+
+// WorkspaceRepository adalah mock untuk domain.WorkspaceRepository
+type WorkspaceRepository struct {
+    mock.Mock
+}
+
+func (m *WorkspaceRepository) Create(ctx context.Context, ws *domain.Workspace) error {
+    args := m.Called(ctx, ws)
+    return args.Error(0)
+}
+
+func (m *WorkspaceRepository) GetByID(ctx context.Context, id int64) (*domain.Workspace, error) {
+    args := m.Called(ctx, id)
+    if ws := args.Get(0); ws != nil {
+        return ws.(*domain.Workspace), args.Error(1)
+    }
+    return nil, args.Error(1)
+}
+
+func (m *WorkspaceRepository) ListByMember(ctx context.Context, userID int64) ([]domain.Workspace, error) {
+    args := m.Called(ctx, userID)
+    if ws := args.Get(0); ws != nil {
+        return ws.([]domain.Workspace), args.Error(1)
+    }
+    return nil, args.Error(1)
+}
+
+func (m *WorkspaceRepository) Update(ctx context.Context, ws *domain.Workspace) error {
+    args := m.Called(ctx, ws)
+    return args.Error(0)
+}
+
+func (m *WorkspaceRepository) Delete(ctx context.Context, id int64) error {
+    args := m.Called(ctx, id)
+    return args.Error(0)
+}
+
+func (m *WorkspaceRepository) GetMember(ctx context.Context, workspaceID int64, userID int64) (*domain.WorkspaceMember, error) {
+    args := m.Called(ctx, workspaceID, userID)
+    if wm := args.Get(0); wm != nil {
+        return wm.(*domain.WorkspaceMember), args.Error(1)
+    }
+    return nil, args.Error(1)
+}
+
+func (m *WorkspaceRepository) ListMembers(ctx context.Context, workspaceID int64) ([]domain.WorkspaceMember, error) {
+    args := m.Called(ctx, workspaceID)
+    if wm := args.Get(0); wm != nil {
+        return wm.([]domain.WorkspaceMember), args.Error(1)
+    }
+    return nil, args.Error(1)
+}
+
+func (m *WorkspaceRepository) UpdateMemberRole(ctx context.Context, workspaceID int64, userID int64, role string) error {
+    args := m.Called(ctx, workspaceID, userID, role)
+    return args.Error(0)
+}
+
+func (m *WorkspaceRepository) RemoveMember(ctx context.Context, workspaceID int64, userID int64) error {
+    args := m.Called(ctx, workspaceID, userID)
+    return args.Error(0)
+}
+
+func (m *WorkspaceRepository) CreateInvitation(ctx context.Context, inv *domain.WorkspaceInvitation) error {
+    args := m.Called(ctx, inv)
+    return args.Error(0)
+}
+
+func (m *WorkspaceRepository) GetInvitation(ctx context.Context, id int64) (*domain.WorkspaceInvitation, error) {
+    args := m.Called(ctx, id)
+    if inv := args.Get(0); inv != nil {
+        return inv.(*domain.WorkspaceInvitation), args.Error(1)
+    }
+    return nil, args.Error(1)
+}
+
+func (m *WorkspaceRepository) GetInvitationByHash(ctx context.Context, hash string) (*domain.WorkspaceInvitation, error) {
+    args := m.Called(ctx, hash)
+    if inv := args.Get(0); inv != nil {
+        return inv.(*domain.WorkspaceInvitation), args.Error(1)
+    }
+    return nil, args.Error(1)
+}
+
+func (m *WorkspaceRepository) ListInvitations(ctx context.Context, workspaceID int64) ([]domain.WorkspaceInvitation, error) {
+    args := m.Called(ctx, workspaceID)
+    if inv := args.Get(0); inv != nil {
+        return inv.([]domain.WorkspaceInvitation), args.Error(1)
+    }
+    return nil, args.Error(1)
+}
+
+func (m *WorkspaceRepository) RevokeInvitation(ctx context.Context, id int64, at time.Time) error {
+    args := m.Called(ctx, id, at)
+    return args.Error(0)
+}
+
+func (m *WorkspaceRepository) AcceptInvitation(ctx context.Context, invitationID int64, member *domain.WorkspaceMember, at time.Time) (bool, error) {
+    args := m.Called(ctx, invitationID, member, at)
+    return args.Bool(0), args.Error(1)
+}
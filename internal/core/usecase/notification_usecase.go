@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+)
+
+const (
+	defaultNotificationLimit = 20
+	maxNotificationLimit     = 100
+	maxNotificationTitle     = 255
+)
+
+type NotificationUsecase struct {
+	notificationRepo domain.NotificationRepository
+	events           domain.EventPublisher
+	contextTimeout   time.Duration
+}
+
+func NewNotificationUsecase(notificationRepo domain.NotificationRepository, events domain.EventPublisher, timeout time.Duration) *NotificationUsecase {
+	return &NotificationUsecase{
+		notificationRepo: notificationRepo,
+		events:           events,
+		contextTimeout:   timeout,
+	}
+}
+
+// List inbox user, terbaru lebih dulu
+func (u *NotificationUsecase) List(c context.Context, filter domain.NotificationFilter) (*domain.NotificationPage, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	switch {
+	case filter.Limit == 0:
+		filter.Limit = defaultNotificationLimit
+	case filter.Limit < 0 || filter.Limit > maxNotificationLimit:
+		return nil, domain.NewValidationError("limit", "must be between 1 and %d", maxNotificationLimit)
+	}
+
+	return u.notificationRepo.Fetch(ctx, filter)
+}
+
+// MarkRead menandai satu notifikasi sudah dibaca; idempoten
+func (u *NotificationUsecase) MarkRead(c context.Context, id int64, userID int64) error {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	ok, err := u.notificationRepo.MarkRead(ctx, id, userID, time.Now())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return domain.NotFound("notification not found")
+	}
+	return nil
+}
+
+// MarkAllRead menandai semua notifikasi user sudah dibaca dan mengembalikan jumlahnya
+func (u *NotificationUsecase) MarkAllRead(c context.Context, userID int64) (int64, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	return u.notificationRepo.MarkAllRead(ctx, userID, time.Now())
+}
+
+func (u *NotificationUsecase) UnreadCount(c context.Context, userID int64) (int64, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	return u.notificationRepo.CountUnread(ctx, userID)
+}
+
+// HandleEvent adalah subscriber event bus yang mengubah event menjadi notifikasi
+// untuk setiap penerima. Event yang tidak dikenal diabaikan.
+func (u *NotificationUsecase) HandleEvent(c context.Context, e domain.Event) error {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	taskTitle, _ := e.Data["title"].(string)
+	n := domain.Notification{CreatedAt: e.OccurredAt}
+	if e.ID != 0 {
+		n.EventID = &e.ID
+	}
+	if e.TaskID != 0 {
+		n.TaskID = &e.TaskID
+	}
+	if n.CreatedAt.IsZero() {
+		n.CreatedAt = time.Now()
+	}
+
+	switch e.Type {
+	case domain.EventReminderDue:
+		n.Type = domain.NotificationReminder
+		n.Title = fmt.Sprintf("Reminder: %s", taskTitle)
+		if due, ok := e.Data["due_at"].(*time.Time); ok && due != nil {
+			n.Body = "Due " + due.UTC().Format(time.RFC3339)
+		}
+	case domain.EventTaskAssigned:
+		n.Type = domain.NotificationAssigned
+		n.Title = fmt.Sprintf("You were assigned to %q", taskTitle)
+		n.ActorID = &e.ActorID
+	case domain.EventRecurrenceInstanceCreated:
+		n.Type = domain.NotificationRecurring
+		n.Title = fmt.Sprintf("New occurrence of %q", taskTitle)
+	default:
+		return nil
+	}
+	if r := []rune(n.Title); len(r) > maxNotificationTitle {
+		n.Title = string(r[:maxNotificationTitle])
+	}
+
+	for _, recipient := range e.Recipients {
+		// tidak perlu memberi tahu user tentang tindakannya sendiri
+		if n.ActorID != nil && recipient == *n.ActorID {
+			continue
+		}
+		notification := n
+		notification.UserID = recipient
+		if err := u.notificationRepo.Create(ctx, &notification); err != nil {
+			if errors.Is(err, domain.ErrConflict) {
+				continue // event dikirim ulang; notifikasi ini sudah dibuat
+			}
+			if errors.Is(err, domain.ErrNotFound) {
+				continue // task atau penerima dihapus sebelum event dikirim; tidak ada yang perlu diberi tahu
+			}
+			return err
+		}
+		// diteruskan ke stream real-time supaya badge unread langsung berubah
+		if err := u.events.Publish(ctx, domain.Event{
+			Type:       domain.EventNotificationCreated,
+			TaskID:     e.TaskID,
+			ActorID:    e.ActorID,
+			Recipients: []int64{recipient},
+			Data:       map[string]any{"notification": notification},
+			OccurredAt: notification.CreatedAt,
+		}); err != nil {
+			log.Printf("failed to publish %s for user %d: %v", domain.EventNotificationCreated, recipient, err)
+		}
+	}
+	return nil
+}
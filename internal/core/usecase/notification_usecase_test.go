@@ -0,0 +1,94 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+	"simple-task-manager/internal/core/usecase"
+	"simple-task-manager/internal/core/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// quietEvents menerima event apa pun; dipakai test yang tidak memeriksa event
+func quietEvents() *mocks.EventPublisher {
+	events := new(mocks.EventPublisher)
+	events.On("Publish", mock.Anything, mock.Anything).Return(nil).Maybe()
+	return events
+}
+
+func TestNotificationFromEvents(t *testing.T) {
+	t.Run("Assignment Notifies Assignee Only", func(t *testing.T) {
+		mockRepo := new(mocks.NotificationRepository)
+		u := usecase.NewNotificationUsecase(mockRepo, quietEvents(), 2*time.Second)
+
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(n *domain.Notification) bool {
+			return n.UserID == 2 && n.Type == domain.NotificationAssigned && *n.TaskID == 10 && *n.ActorID == 1
+		})).Return(nil).Once()
+
+		err := u.HandleEvent(context.Background(), domain.Event{
+			Type:       domain.EventTaskAssigned,
+			TaskID:     10,
+			ActorID:    1,
+			Recipients: []int64{1, 2}, // actor tidak perlu diberi tahu
+			Data:       map[string]any{"title": "Laporan"},
+			OccurredAt: time.Now(),
+		})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNumberOfCalls(t, "Create", 1)
+	})
+
+	t.Run("Reminder Has No Actor", func(t *testing.T) {
+		mockRepo := new(mocks.NotificationRepository)
+		u := usecase.NewNotificationUsecase(mockRepo, quietEvents(), 2*time.Second)
+
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(n *domain.Notification) bool {
+			return n.UserID == 3 && n.Type == domain.NotificationReminder && n.ActorID == nil && n.Title == "Reminder: Laporan"
+		})).Return(nil).Once()
+
+		err := u.HandleEvent(context.Background(), domain.Event{
+			Type:       domain.EventReminderDue,
+			TaskID:     10,
+			Recipients: []int64{3},
+			Data:       map[string]any{"title": "Laporan"},
+		})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Unknown Event Is Ignored", func(t *testing.T) {
+		mockRepo := new(mocks.NotificationRepository)
+		u := usecase.NewNotificationUsecase(mockRepo, quietEvents(), 2*time.Second)
+
+		err := u.HandleEvent(context.Background(), domain.Event{Type: domain.EventTaskUnassigned, TaskID: 10, Recipients: []int64{2}})
+
+		assert.NoError(t, err)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestMarkNotificationRead(t *testing.T) {
+	mockRepo := new(mocks.NotificationRepository)
+	u := usecase.NewNotificationUsecase(mockRepo, quietEvents(), 2*time.Second)
+
+	t.Run("Failed - Not Owner", func(t *testing.T) {
+		mockRepo.On("MarkRead", mock.Anything, int64(5), int64(2), mock.Anything).Return(false, nil).Once()
+
+		err := u.MarkRead(context.Background(), 5, 2)
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
+
+	t.Run("Failed - Limit Too Large", func(t *testing.T) {
+		_, err := u.List(context.Background(), domain.NotificationFilter{UserID: 2, Limit: 500})
+
+		assert.ErrorIs(t, err, domain.ErrBadParamInput)
+		mockRepo.AssertNotCalled(t, "Fetch", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"simple-task-manager/internal/core/domain"
+)
+
+// permission adalah aksi yang dicek policy terhadap sebuah resource
+type permission int
+
+const (
+	permView   permission = iota // membaca
+	permEdit                     // membuat & mengubah
+	permDelete                   // menghapus
+	permManage                   // mengelola workspace: anggota, undangan, pengaturan
+)
+
+// roleAllows memetakan role workspace ke aksi yang diizinkan
+func roleAllows(role string, perm permission) bool {
+	switch role {
+	case domain.RoleOwner, domain.RoleAdmin:
+		return true
+	case domain.RoleMember:
+		return perm <= permEdit
+	case domain.RoleViewer:
+		return perm == permView
+	}
+	return false
+}
+
+// policy memusatkan semua pengecekan akses. Resource pribadi (tanpa workspace)
+// hanya boleh diakses pembuatnya; resource workspace mengikuti role anggota,
+// dengan pengecualian member boleh menghapus resource yang ia buat sendiri.
+// Usecase wajib lewat sini, bukan membandingkan UserID sendiri-sendiri.
+type policy struct {
+	taskRepo      domain.TaskRepository
+	projectRepo   domain.ProjectRepository
+	workspaceRepo domain.WorkspaceRepository
+}
+
+func newPolicy(taskRepo domain.TaskRepository, projectRepo domain.ProjectRepository, workspaceRepo domain.WorkspaceRepository) *policy {
+	return &policy{taskRepo: taskRepo, projectRepo: projectRepo, workspaceRepo: workspaceRepo}
+}
+
+// authorizeWorkspace memastikan userID anggota workspace dengan role yang cukup.
+// Non-anggota mendapat NotFound supaya keberadaan workspace tidak bocor.
+func (p *policy) authorizeWorkspace(ctx context.Context, workspaceID int64, userID int64, perm permission) (*domain.WorkspaceMember, error) {
+	member, err := p.workspaceRepo.GetMember(ctx, workspaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil {
+		return nil, domain.NotFound("workspace %d not found", workspaceID)
+	}
+	if !roleAllows(member.Role, perm) {
+		return nil, domain.Forbidden("your role %q in workspace %d does not allow this action", member.Role, workspaceID)
+	}
+	return member, nil
+}
+
+// authorizeResource menerapkan aturan bersama untuk task dan project
+func (p *policy) authorizeResource(ctx context.Context, kind string, id int64, creatorID int64, workspaceID *int64, userID int64, perm permission) error {
+	if workspaceID == nil {
+		if creatorID != userID {
+			return domain.Forbidden("you don't own %s %d", kind, id)
+		}
+		return nil
+	}
+
+	member, err := p.workspaceRepo.GetMember(ctx, *workspaceID, userID)
+	if err != nil {
+		return err
+	}
+	if member == nil {
+		return domain.Forbidden("you don't have access to %s %d", kind, id)
+	}
+	if perm == permDelete && member.Role == domain.RoleMember && creatorID == userID {
+		return nil
+	}
+	if !roleAllows(member.Role, perm) {
+		return domain.Forbidden("your role %q does not allow this action on %s %d", member.Role, kind, id)
+	}
+	return nil
+}
+
+// authorizeTask mengambil task dan memastikan userID boleh melakukan perm terhadapnya
+func (p *policy) authorizeTask(ctx context.Context, taskID int64, userID int64, perm permission) (*domain.Task, error) {
+	task, err := p.taskRepo.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if task == nil {
+		return nil, domain.NotFound("task %d not found", taskID)
+	}
+
+	if err := p.authorizeResource(ctx, "task", taskID, task.UserID, task.WorkspaceID, userID, perm); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// canView bernilai true jika userID boleh melihat task, misalnya untuk memvalidasi assignee
+func (p *policy) canView(ctx context.Context, task *domain.Task, userID int64) (bool, error) {
+	err := p.authorizeResource(ctx, "task", task.ID, task.UserID, task.WorkspaceID, userID, permView)
+	if errors.Is(err, domain.ErrForbidden) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// authorizeSubtask memastikan userID boleh melakukan perm terhadap subtask lewat task induknya
+func (p *policy) authorizeSubtask(ctx context.Context, subtaskID int64, userID int64, perm permission) (*domain.Task, error) {
+	taskID, err := p.taskRepo.GetSubtaskTaskID(ctx, subtaskID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.NotFound("subtask %d not found", subtaskID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return p.authorizeTask(ctx, taskID, userID, perm)
+}
+
+// authorizeProject mengambil project dan memastikan userID boleh melakukan perm terhadapnya
+func (p *policy) authorizeProject(ctx context.Context, projectID int64, userID int64, perm permission) (*domain.Project, error) {
+	project, err := p.projectRepo.GetByID(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project == nil {
+		return nil, domain.NotFound("project %d not found", projectID)
+	}
+
+	if err := p.authorizeResource(ctx, "project", projectID, project.UserID, project.WorkspaceID, userID, perm); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// authorizeTaskTarget memastikan task boleh dimasukkan ke project: user boleh
+// mengubah isi project dan project belum diarsipkan
+func (p *policy) authorizeTaskTarget(ctx context.Context, projectID int64, userID int64) (*domain.Project, error) {
+	project, err := p.authorizeProject(ctx, projectID, userID, permEdit)
+	if err != nil {
+		return nil, err
+	}
+	if project.Archived() {
+		return nil, domain.Conflict("project %d is archived", projectID)
+	}
+	return project, nil
+}
+
+// sameWorkspace bernilai true jika kedua resource pribadi, atau berada di workspace yang sama
+func sameWorkspace(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
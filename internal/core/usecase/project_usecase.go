@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+)
+
+type ProjectUsecase struct {
+	projectRepo    domain.ProjectRepository
+	statusRepo     domain.StatusRepository
+	policy         *policy
+	contextTimeout time.Duration
+}
+
+func NewProjectUsecase(
+	projectRepo domain.ProjectRepository,
+	statusRepo domain.StatusRepository,
+	workspaceRepo domain.WorkspaceRepository,
+	timeout time.Duration,
+) *ProjectUsecase {
+	return &ProjectUsecase{
+		projectRepo:    projectRepo,
+		statusRepo:     statusRepo,
+		policy:         newPolicy(nil, projectRepo, workspaceRepo), // usecase ini tidak mengecek task
+		contextTimeout: timeout,
+	}
+}
+
+// Create project baru milik user
+func (u *ProjectUsecase) Create(c context.Context, project *domain.Project) error {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	project.Name = strings.TrimSpace(project.Name)
+	project.DefaultStatus = strings.TrimSpace(project.DefaultStatus)
+
+	if project.WorkspaceID != nil {
+		if _, err := u.policy.authorizeWorkspace(ctx, *project.WorkspaceID, project.UserID, permEdit); err != nil {
+			return err
+		}
+	}
+
+	if err := u.validate(ctx, project, &domain.ValidationError{}); err != nil {
+		return err
+	}
+
+	project.ArchivedAt = nil
+	project.CreatedAt = time.Now()
+	project.UpdatedAt = project.CreatedAt
+
+	return u.projectRepo.Create(ctx, project)
+}
+
+// List project yang terlihat oleh user (pribadi + workspace); project yang diarsipkan hanya ikut jika includeArchived
+func (u *ProjectUsecase) List(c context.Context, userID int64, includeArchived bool) ([]domain.Project, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	return u.projectRepo.ListVisible(ctx, userID, includeArchived)
+}
+
+// Get satu project
+func (u *ProjectUsecase) Get(c context.Context, id int64, userID int64) (*domain.Project, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	return u.policy.authorizeProject(ctx, id, userID, permView)
+}
+
+// Patch menerapkan perubahan parsial, termasuk mengarsipkan / membuka arsip project
+func (u *ProjectUsecase) Patch(c context.Context, id int64, userID int64, patch domain.ProjectPatch) (*domain.Project, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	project, err := u.policy.authorizeProject(ctx, id, userID, permEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	verr := &domain.ValidationError{}
+	now := time.Now()
+
+	if patch.Name.Set {
+		project.Name = ""
+		if patch.Name.Value != nil {
+			project.Name = strings.TrimSpace(*patch.Name.Value)
+		}
+	}
+	if patch.Description.Set {
+		project.Description = ""
+		if patch.Description.Value != nil {
+			project.Description = *patch.Description.Value
+		}
+	}
+	if patch.DefaultPriority.Set {
+		project.DefaultPriority = ""
+		if patch.DefaultPriority.Value != nil {
+			project.DefaultPriority = *patch.DefaultPriority.Value
+		}
+	}
+	if patch.DefaultStatus.Set {
+		project.DefaultStatus = ""
+		if patch.DefaultStatus.Value != nil {
+			project.DefaultStatus = strings.TrimSpace(*patch.DefaultStatus.Value)
+		}
+	}
+	if patch.Archived.Set {
+		switch {
+		case patch.Archived.Value == nil:
+			verr.Add("archived", "cannot be null")
+		case *patch.Archived.Value && project.ArchivedAt == nil:
+			project.ArchivedAt = &now
+		case !*patch.Archived.Value:
+			project.ArchivedAt = nil
+		}
+	}
+
+	if err := u.validate(ctx, project, verr); err != nil {
+		return nil, err
+	}
+
+	project.UpdatedAt = now
+	if err := u.projectRepo.Update(ctx, project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// Delete project; task di dalamnya tidak ikut terhapus
+func (u *ProjectUsecase) Delete(c context.Context, id int64, userID int64) error {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	if _, err := u.policy.authorizeProject(ctx, id, userID, permDelete); err != nil {
+		return err
+	}
+
+	return u.projectRepo.Delete(ctx, id)
+}
+
+// validate melengkapi verr dengan error field project. Default status harus ada
+// di workflow pemilik project supaya task baru tidak gagal dibuat.
+func (u *ProjectUsecase) validate(ctx context.Context, project *domain.Project, verr *domain.ValidationError) error {
+	switch {
+	case project.Name == "":
+		verr.Add("name", "cannot be empty")
+	case len(project.Name) > 255:
+		verr.Add("name", "is longer than 255 characters")
+	}
+
+	switch project.DefaultPriority {
+	case "", "low", "medium", "high":
+	default:
+		verr.Add("default_priority", "must be one of low, medium, high")
+	}
+
+	if project.DefaultStatus != "" {
+		machine, err := loadStatusMachine(ctx, u.statusRepo, project.UserID)
+		if err != nil {
+			return err
+		}
+		if _, ok := machine.statuses[project.DefaultStatus]; !ok {
+			verr.Add("default_status", "unknown status %q", project.DefaultStatus)
+		}
+	}
+
+	return verr.OrNil()
+}
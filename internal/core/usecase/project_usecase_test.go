@@ -0,0 +1,64 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+	"simple-task-manager/internal/core/usecase"
+	"simple-task-manager/internal/core/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateProject(t *testing.T) {
+	mockProjectRepo := new(mocks.ProjectRepository)
+	mockStatusRepo := new(mocks.StatusRepository)
+	u := usecase.NewProjectUsecase(mockProjectRepo, mockStatusRepo, new(mocks.WorkspaceRepository), 2*time.Second)
+
+	t.Run("Failed - Invalid Defaults", func(t *testing.T) {
+		mockStatusRepo.On("GetWorkflow", mock.Anything, int64(1)).Return(nil, nil).Once()
+
+		err := u.Create(context.Background(), &domain.Project{
+			UserID:          1,
+			Name:            "Website",
+			DefaultPriority: "urgent",
+			DefaultStatus:   "blocked",
+		})
+
+		var verr *domain.ValidationError
+		assert.ErrorAs(t, err, &verr)
+		assert.Len(t, verr.Fields, 2)
+		mockProjectRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		project := &domain.Project{UserID: 1, Name: "  Website  ", DefaultStatus: "in_progress"}
+		mockStatusRepo.On("GetWorkflow", mock.Anything, int64(1)).Return(nil, nil).Once()
+		mockProjectRepo.On("Create", mock.Anything, project).Return(nil).Once()
+
+		err := u.Create(context.Background(), project)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Website", project.Name)
+	})
+}
+
+func TestPatchProjectArchive(t *testing.T) {
+	mockProjectRepo := new(mocks.ProjectRepository)
+	u := usecase.NewProjectUsecase(mockProjectRepo, new(mocks.StatusRepository), new(mocks.WorkspaceRepository), 2*time.Second)
+
+	archived := true
+	mockProjectRepo.On("GetByID", mock.Anything, int64(3)).Return(&domain.Project{ID: 3, UserID: 1, Name: "Website"}, nil).Once()
+	mockProjectRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.Project")).Return(nil).Once()
+
+	project, err := u.Patch(context.Background(), 3, 1, domain.ProjectPatch{
+		Archived: domain.Optional[bool]{Set: true, Value: &archived},
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, project.Archived())
+	mockProjectRepo.AssertExpectations(t)
+}
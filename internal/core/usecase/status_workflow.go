@@ -0,0 +1,230 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+)
+
+// DefaultWorkflow dipakai untuk user yang belum mendefinisikan workflow sendiri.
+// Semua perpindahan antar tiga status bawaan diizinkan, kecuali membuka lagi
+// task yang sudah selesai langsung ke in_progress (harus lewat pending).
+func DefaultWorkflow() *domain.Workflow {
+	return &domain.Workflow{
+		Statuses: []domain.TaskStatus{
+			{Key: "pending", Name: "To Do", Category: domain.StatusCategoryTodo, Position: 0},
+			{Key: "in_progress", Name: "In Progress", Category: domain.StatusCategoryInProgress, Position: 1},
+			{Key: "done", Name: "Done", Category: domain.StatusCategoryDone, Position: 2},
+		},
+		Transitions: []domain.StatusTransition{
+			{From: "pending", To: "in_progress"},
+			{From: "pending", To: "done"},
+			{From: "in_progress", To: "pending"},
+			{From: "in_progress", To: "done"},
+			{From: "done", To: "pending"},
+		},
+	}
+}
+
+// statusMachine memvalidasi perpindahan status sesuai workflow dan mencap timestamp task
+type statusMachine struct {
+	statuses map[string]domain.TaskStatus
+	allowed  map[string]map[string]bool
+	initial  string
+}
+
+func newStatusMachine(wf *domain.Workflow) *statusMachine {
+	m := &statusMachine{
+		statuses: make(map[string]domain.TaskStatus, len(wf.Statuses)),
+		allowed:  make(map[string]map[string]bool),
+	}
+
+	ordered := append([]domain.TaskStatus(nil), wf.Statuses...)
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].Position < ordered[j].Position })
+	for _, st := range ordered {
+		m.statuses[st.Key] = st
+		if m.initial == "" && st.Category == domain.StatusCategoryTodo {
+			m.initial = st.Key
+		}
+	}
+
+	for _, tr := range wf.Transitions {
+		if m.allowed[tr.From] == nil {
+			m.allowed[tr.From] = make(map[string]bool)
+		}
+		m.allowed[tr.From][tr.To] = true
+	}
+	return m
+}
+
+// check mengembalikan *domain.InvalidTransitionError jika perpindahan tidak diizinkan.
+// Task yang status lamanya sudah tidak ada di workflow (misalnya setelah workflow
+// diganti) boleh dipindah ke status mana pun yang valid.
+func (m *statusMachine) check(from, to string) error {
+	if _, ok := m.statuses[to]; !ok {
+		return &domain.InvalidTransitionError{To: to}
+	}
+	if from == to {
+		return nil
+	}
+	if _, known := m.statuses[from]; !known {
+		return nil
+	}
+	if !m.allowed[from][to] {
+		return &domain.InvalidTransitionError{From: from, To: to}
+	}
+	return nil
+}
+
+// transition memindahkan task ke status baru dan mencap timestamp sesuai kategori.
+// Mengembalikan false jika status tidak berubah.
+func (m *statusMachine) transition(task *domain.Task, to string, now time.Time) (bool, error) {
+	if err := m.check(task.Status, to); err != nil {
+		return false, err
+	}
+	if task.Status == to {
+		return false, nil
+	}
+
+	task.Status = to
+	m.stamp(task, now)
+	return true, nil
+}
+
+// stamp mengisi StatusChangedAt, StartedAt dan CompletedAt berdasarkan kategori status saat ini
+func (m *statusMachine) stamp(task *domain.Task, now time.Time) {
+	task.StatusChangedAt = &now
+
+	switch m.statuses[task.Status].Category {
+	case domain.StatusCategoryInProgress:
+		if task.StartedAt == nil {
+			task.StartedAt = &now
+		}
+		task.CompletedAt = nil
+	case domain.StatusCategoryDone:
+		if task.StartedAt == nil {
+			task.StartedAt = &now
+		}
+		task.CompletedAt = &now
+	default:
+		task.CompletedAt = nil
+	}
+}
+
+// loadStatusMachine mengambil workflow user, atau workflow default jika belum ada
+func loadStatusMachine(ctx context.Context, repo domain.StatusRepository, userID int64) (*statusMachine, error) {
+	wf, err := repo.GetWorkflow(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if wf == nil {
+		wf = DefaultWorkflow()
+	}
+	return newStatusMachine(wf), nil
+}
+
+// validateWorkflow memastikan workflow custom bisa dipakai oleh statusMachine
+func validateWorkflow(wf *domain.Workflow) error {
+	if len(wf.Statuses) == 0 {
+		return domain.NewValidationError("statuses", "workflow needs at least one status")
+	}
+
+	seen := make(map[string]bool, len(wf.Statuses))
+	categories := make(map[string]bool)
+	for i := range wf.Statuses {
+		st := &wf.Statuses[i]
+		st.Key = strings.TrimSpace(st.Key)
+		st.Name = strings.TrimSpace(st.Name)
+
+		if st.Key == "" || len(st.Key) > 50 {
+			return domain.NewValidationError(fmt.Sprintf("statuses[%d].key", i), "must be 1-50 characters")
+		}
+		if seen[st.Key] {
+			return domain.NewValidationError(fmt.Sprintf("statuses[%d].key", i), "duplicate status %q", st.Key)
+		}
+		seen[st.Key] = true
+
+		switch st.Category {
+		case domain.StatusCategoryTodo, domain.StatusCategoryInProgress, domain.StatusCategoryDone:
+			categories[st.Category] = true
+		default:
+			return domain.NewValidationError(fmt.Sprintf("statuses[%d].category", i), "unknown category %q", st.Category)
+		}
+		if st.Name == "" {
+			st.Name = st.Key
+		}
+	}
+
+	if !categories[domain.StatusCategoryTodo] || !categories[domain.StatusCategoryDone] {
+		return domain.NewValidationError("statuses", "workflow needs at least one todo and one done status")
+	}
+
+	for i, tr := range wf.Transitions {
+		field := fmt.Sprintf("transitions[%d]", i)
+		if !seen[tr.From] || !seen[tr.To] {
+			return domain.NewValidationError(field, "%q -> %q references unknown status", tr.From, tr.To)
+		}
+		if tr.From == tr.To {
+			return domain.NewValidationError(field, "%q -> %q is a no-op", tr.From, tr.To)
+		}
+	}
+
+	return nil
+}
+
+// WorkflowUsecase mengelola workflow status custom per user
+type WorkflowUsecase struct {
+	statusRepo     domain.StatusRepository
+	contextTimeout time.Duration
+}
+
+func NewWorkflowUsecase(statusRepo domain.StatusRepository, timeout time.Duration) *WorkflowUsecase {
+	return &WorkflowUsecase{
+		statusRepo:     statusRepo,
+		contextTimeout: timeout,
+	}
+}
+
+// Get workflow user, diurutkan sesuai posisi kolom kanban
+func (u *WorkflowUsecase) Get(c context.Context, userID int64) (*domain.Workflow, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	wf, err := u.statusRepo.GetWorkflow(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if wf == nil {
+		wf = DefaultWorkflow()
+	}
+
+	sort.SliceStable(wf.Statuses, func(i, j int) bool { return wf.Statuses[i].Position < wf.Statuses[j].Position })
+	return wf, nil
+}
+
+// Save mengganti workflow user. Posisi kolom mengikuti urutan di request.
+func (u *WorkflowUsecase) Save(c context.Context, userID int64, wf *domain.Workflow) error {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	if err := validateWorkflow(wf); err != nil {
+		return err
+	}
+	for i := range wf.Statuses {
+		wf.Statuses[i].Position = i
+	}
+
+	return u.statusRepo.SaveWorkflow(ctx, userID, wf)
+}
+
+// Reset mengembalikan user ke workflow default
+func (u *WorkflowUsecase) Reset(c context.Context, userID int64) error {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	return u.statusRepo.DeleteWorkflow(ctx, userID)
+}
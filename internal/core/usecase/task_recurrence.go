@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+)
+
+const (
+	defaultRecurrencePreview = 5
+	maxRecurrencePreview     = 50
+)
+
+// ownerLocation mengembalikan zona waktu pembuat task; recurrence & tenggat all-day
+// dihitung di zona ini supaya semua anggota workspace melihat jadwal yang sama
+func (u *TaskUsecase) ownerLocation(ctx context.Context, ownerID int64) *time.Location {
+	owner, err := u.userRepo.GetByID(ctx, ownerID)
+	if err != nil {
+		log.Printf("failed to load time zone for user %d, falling back to UTC: %v", ownerID, err)
+	}
+	return owner.Location()
+}
+
+// prepareRecurrence memvalidasi recurrence_pattern, menyimpannya dalam bentuk kanonik,
+// lalu menjadikan kemunculan pertama pada atau setelah next_run sebagai DTSTART & next_run.
+// Mengubah next_run task recurring berarti memulai ulang seri (termasuk hitungan COUNT).
+// Mode completion tidak memakai next_run: jadwalnya dihitung saat task selesai.
+func (u *TaskUsecase) prepareRecurrence(ctx context.Context, task *domain.Task) error {
+	switch {
+	case task.MissedRunPolicy == "":
+		task.MissedRunPolicy = domain.MissedRunLatest
+	case !slices.Contains(domain.MissedRunPolicies, task.MissedRunPolicy):
+		return domain.NewValidationError("missed_run_policy", "must be one of all, latest, skip")
+	}
+	switch {
+	case task.RecurrenceMode == "":
+		task.RecurrenceMode = domain.RecurrenceModeSchedule
+	case !slices.Contains(domain.RecurrenceModes, task.RecurrenceMode):
+		return domain.NewValidationError("recurrence_mode", "must be one of schedule, completion")
+	}
+	if task.RecurrencePattern == "" {
+		task.RecurrenceStart = nil
+		return nil
+	}
+
+	rule, err := domain.ParseRecurrence(task.RecurrencePattern)
+	if err != nil {
+		return domain.NewValidationError("recurrence_pattern", "%s", err.Error())
+	}
+	if task.RecurrenceMode == domain.RecurrenceModeCompletion {
+		if rule.Count > 0 {
+			return domain.NewValidationError("recurrence_pattern", "COUNT is not supported for completion-based recurrence")
+		}
+		task.RecurrencePattern = rule.String()
+		task.RecurrenceStart, task.NextRun = nil, nil
+		return nil
+	}
+	if task.NextRun == nil {
+		return domain.NewValidationError("next_run", "is required for recurring tasks")
+	}
+
+	first, ok := rule.First(*task.NextRun, u.ownerLocation(ctx, task.UserID))
+	if !ok {
+		return domain.NewValidationError("recurrence_pattern", "has no occurrences on or after next_run")
+	}
+	first = first.UTC()
+	task.RecurrencePattern = rule.String()
+	task.RecurrenceStart, task.NextRun = &first, &first
+	return nil
+}
+
+// nextOccurrence menyiapkan instance berikutnya saat kemunculan seri berbasis penyelesaian
+// ditandai selesai, dengan tenggat dihitung dari waktu selesai. Instance disimpan bersama status
+// task (lihat TaskEffects), jadi seri tidak berhenti diam-diam jika penyimpanan gagal. Hanya satu
+// kemunculan yang terbuka per seri, jadi membuka & menyelesaikan ulang task lama tidak
+// menggandakan instance. Bernilai nil jika task bukan bagian seri berbasis penyelesaian.
+func (u *TaskUsecase) nextOccurrence(ctx context.Context, done *domain.Task, userID int64) (*domain.RecurrenceInstance, error) {
+	template := done
+	if done.RecurrenceParentID != nil {
+		parent, err := u.taskRepo.GetByID(ctx, *done.RecurrenceParentID)
+		if err != nil {
+			return nil, err
+		}
+		if parent == nil {
+			return nil, nil // template sudah dihapus: seri berhenti
+		}
+		template = parent
+	}
+	if template.RecurrencePattern == "" || template.RecurrenceMode != domain.RecurrenceModeCompletion {
+		return nil, nil
+	}
+
+	rule, err := domain.ParseRecurrence(template.RecurrencePattern)
+	if err != nil {
+		// Aturan lama yang tidak valid tidak boleh menghalangi task diselesaikan
+		log.Printf("invalid recurrence %q on task %d: %v", template.RecurrencePattern, template.ID, err)
+		return nil, nil
+	}
+	loc := u.ownerLocation(ctx, template.UserID)
+	next, ok := rule.AfterCompletion(*done.CompletedAt, loc)
+	if !ok {
+		return nil, nil // UNTIL sudah lewat
+	}
+	machine, err := loadStatusMachine(ctx, u.statusRepo, template.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	occurrence := next.UTC()
+	inst := template.Instance(occurrence, loc)
+	inst.DueAt, inst.ReminderTime = &next, nil
+	_ = normalizeDue(&inst) // tenggat selalu ada; all-day dipotong ke tanggal di zona waktu pemilik
+	inst.Status = machine.initial
+	machine.stamp(&inst, now)
+	inst.CreatedAt, inst.UpdatedAt = now, now
+
+	e := taskEvent(domain.EventRecurrenceInstanceCreated, &inst, userID, map[string]any{
+		"title":        inst.Title,
+		"parent_id":    template.ID,
+		"occurrence":   occurrence,
+		"completed_id": done.ID,
+	})
+	e.Recipients = []int64{template.UserID}
+	return &domain.RecurrenceInstance{Task: inst, ReminderBase: u.reminderBase(ctx, &inst), Event: e}, nil
+}
+
+// saveTask menyimpan task beserta efek turunannya dalam satu transaksi
+func (u *TaskUsecase) saveTask(ctx context.Context, task *domain.Task, e domain.Event, effects domain.TaskEffects) error {
+	if effects == (domain.TaskEffects{}) {
+		return u.taskRepo.Update(ctx, task, e)
+	}
+	return u.taskRepo.UpdateWith(ctx, task, e, effects)
+}
+
+// RecurrencePreview adalah hasil pratinjau sebuah aturan
+type RecurrencePreview struct {
+	Rule        string      `json:"rule"`     // bentuk kanonik yang akan disimpan
+	Timezone    string      `json:"timezone"` // zona waktu tempat aturan dihitung
+	Occurrences []time.Time `json:"occurrences"`
+}
+
+// PreviewRecurrence menghitung count kemunculan pertama aturan mulai start (default: sekarang)
+// di zona waktu user, tanpa menyimpan apa pun
+func (u *TaskUsecase) PreviewRecurrence(c context.Context, userID int64, pattern string, start *time.Time, count int) (*RecurrencePreview, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	switch {
+	case count == 0:
+		count = defaultRecurrencePreview
+	case count < 0 || count > maxRecurrencePreview:
+		return nil, domain.NewValidationError("count", "must be between 1 and %d", maxRecurrencePreview)
+	}
+
+	rule, err := domain.ParseRecurrence(strings.TrimSpace(pattern))
+	if err != nil {
+		return nil, domain.NewValidationError("rule", "%s", err.Error())
+	}
+
+	from := time.Now().Truncate(time.Minute)
+	if start != nil {
+		from = *start
+	}
+	loc := u.ownerLocation(ctx, userID)
+
+	preview := &RecurrencePreview{Rule: rule.String(), Timezone: loc.String(), Occurrences: []time.Time{}}
+	preview.Occurrences = append(preview.Occurrences, rule.After(from, time.Time{}, count, loc)...)
+	return preview, nil
+}
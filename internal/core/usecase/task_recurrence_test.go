@@ -0,0 +1,316 @@
+package usecase_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+	"simple-task-manager/internal/core/usecase"
+	"simple-task-manager/internal/core/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPreviewRecurrence(t *testing.T) {
+	mockUserRepo := new(mocks.UserRepository)
+	u := usecase.NewTaskUsecase(new(mocks.TaskRepository), new(mocks.StatusRepository), new(mocks.ProjectRepository), new(mocks.WorkspaceRepository), mockUserRepo, new(mocks.TaskReminderRepository), 2*time.Second)
+	mockUserRepo.On("GetByID", mock.Anything, int64(1)).Return(&domain.User{ID: 1, Timezone: "UTC"}, nil)
+	mockUserRepo.On("GetByID", mock.Anything, int64(2)).Return(&domain.User{ID: 2, Timezone: "America/New_York"}, nil)
+
+	// Kamis, 5 Maret 2026 09:00 UTC
+	start := time.Date(2026, 3, 5, 9, 0, 0, 0, time.UTC)
+
+	dates := func(p *usecase.RecurrencePreview) []string {
+		out := make([]string, len(p.Occurrences))
+		for i, o := range p.Occurrences {
+			out[i] = o.Format("2006-01-02 15:04")
+		}
+		return out
+	}
+
+	cases := []struct {
+		name  string
+		rule  string
+		count int
+		canon string
+		want  []string
+	}{
+		{"Every Weekday", "weekdays", 4, "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR",
+			[]string{"2026-03-05 09:00", "2026-03-06 09:00", "2026-03-09 09:00", "2026-03-10 09:00"}},
+		{"Every Other Week On Monday And Thursday", "RRULE:freq=weekly;interval=2;byday=MO,TH", 4, "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH",
+			[]string{"2026-03-05 09:00", "2026-03-16 09:00", "2026-03-19 09:00", "2026-03-30 09:00"}},
+		{"Last Friday Of The Month", "FREQ=MONTHLY;BYDAY=-1FR", 3, "FREQ=MONTHLY;BYDAY=-1FR",
+			[]string{"2026-03-27 09:00", "2026-04-24 09:00", "2026-05-29 09:00"}},
+		{"Month End Skips Short Months", "FREQ=MONTHLY;BYMONTHDAY=31", 3, "FREQ=MONTHLY;BYMONTHDAY=31",
+			[]string{"2026-03-31 09:00", "2026-05-31 09:00", "2026-07-31 09:00"}},
+		{"Count Limits The Series", "FREQ=DAILY;INTERVAL=3;COUNT=2", 5, "FREQ=DAILY;INTERVAL=3;COUNT=2",
+			[]string{"2026-03-05 09:00", "2026-03-08 09:00"}},
+		{"Until Date Is Inclusive", "FREQ=WEEKLY;UNTIL=20260319", 5, "FREQ=WEEKLY;UNTIL=20260319",
+			[]string{"2026-03-05 09:00", "2026-03-12 09:00", "2026-03-19 09:00"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := u.PreviewRecurrence(context.Background(), 1, tc.rule, &start, tc.count)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.canon, p.Rule)
+			assert.Equal(t, tc.want, dates(p))
+		})
+	}
+
+	t.Run("Keeps Wall Clock Across DST In User Time Zone", func(t *testing.T) {
+		// 09:00 di New York sebelum & sesudah DST mulai (8 Maret 2026)
+		nyStart := time.Date(2026, 3, 6, 14, 0, 0, 0, time.UTC)
+
+		p, err := u.PreviewRecurrence(context.Background(), 2, "daily", &nyStart, 4)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "America/New_York", p.Timezone)
+		for _, o := range p.Occurrences {
+			assert.Equal(t, 9, o.Hour())
+		}
+		assert.Equal(t, 13, p.Occurrences[3].UTC().Hour())
+	})
+
+	t.Run("Failed - Invalid Rules", func(t *testing.T) {
+		for _, rule := range []string{"hourly", "FREQ=WEEKLY;BYDAY=XX", "FREQ=DAILY;COUNT=2;UNTIL=20260101", "FREQ=WEEKLY;BYDAY=2MO", "FREQ=DAILY;BYHOUR=9"} {
+			_, err := u.PreviewRecurrence(context.Background(), 1, rule, &start, 3)
+			assert.ErrorIs(t, err, domain.ErrBadParamInput, rule)
+		}
+	})
+}
+
+func TestPatchRecurrence(t *testing.T) {
+	mockTaskRepo := new(mocks.TaskRepository)
+	mockUserRepo := new(mocks.UserRepository)
+	u := usecase.NewTaskUsecase(mockTaskRepo, new(mocks.StatusRepository), new(mocks.ProjectRepository), new(mocks.WorkspaceRepository), mockUserRepo, new(mocks.TaskReminderRepository), 2*time.Second)
+	mockUserRepo.On("GetByID", mock.Anything, int64(1)).Return(&domain.User{ID: 1, Timezone: "UTC"}, nil)
+
+	t.Run("Success - Rule Is Canonicalized And Next Run Snapped To First Occurrence", func(t *testing.T) {
+		existing := &domain.Task{ID: 20, UserID: 1, Title: "Standup", Priority: "low"}
+		var patch domain.TaskPatch
+		// Kamis; aturan hanya Senin
+		_ = json.Unmarshal([]byte(`{"recurrence_pattern":"freq=weekly;byday=mo","next_run":"2026-03-05T09:00:00Z"}`), &patch)
+
+		mockTaskRepo.On("GetByID", mock.Anything, int64(20)).Return(existing, nil).Once()
+		mockTaskRepo.On("Update", mock.Anything, existing, mock.Anything).Return(nil).Once()
+
+		task, err := u.Patch(context.Background(), 20, 1, patch)
+
+		assert.NoError(t, err)
+		monday := time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC)
+		assert.Equal(t, "FREQ=WEEKLY;BYDAY=MO", task.RecurrencePattern)
+		assert.Equal(t, monday, *task.NextRun)
+		assert.Equal(t, monday, *task.RecurrenceStart)
+	})
+
+	t.Run("Failed - Invalid Rule Is Rejected", func(t *testing.T) {
+		existing := &domain.Task{ID: 21, UserID: 1, Title: "Standup", Priority: "low"}
+		var patch domain.TaskPatch
+		_ = json.Unmarshal([]byte(`{"recurrence_pattern":"FREQ=FORTNIGHTLY","next_run":"2026-03-05T09:00:00Z"}`), &patch)
+
+		mockTaskRepo.On("GetByID", mock.Anything, int64(21)).Return(existing, nil).Once()
+
+		_, err := u.Patch(context.Background(), 21, 1, patch)
+
+		assert.ErrorIs(t, err, domain.ErrBadParamInput)
+		mockTaskRepo.AssertNotCalled(t, "Update", mock.Anything, existing, mock.Anything)
+	})
+
+	t.Run("Success - Missed Run Policy Changes Without Restarting The Series", func(t *testing.T) {
+		start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+		next := time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC)
+		existing := &domain.Task{ID: 22, UserID: 1, Title: "Standup", Priority: "low",
+			RecurrencePattern: "FREQ=WEEKLY;BYDAY=MO", RecurrenceStart: &start, NextRun: &next, MissedRunPolicy: domain.MissedRunLatest}
+		var patch domain.TaskPatch
+		_ = json.Unmarshal([]byte(`{"missed_run_policy":"all"}`), &patch)
+
+		mockTaskRepo.On("GetByID", mock.Anything, int64(22)).Return(existing, nil).Once()
+		mockTaskRepo.On("Update", mock.Anything, existing, mock.Anything).Return(nil).Once()
+
+		task, err := u.Patch(context.Background(), 22, 1, patch)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.MissedRunAll, task.MissedRunPolicy)
+		assert.Equal(t, start, *task.RecurrenceStart)
+		assert.Equal(t, next, *task.NextRun)
+	})
+
+	t.Run("Failed - Unknown Missed Run Policy", func(t *testing.T) {
+		existing := &domain.Task{ID: 23, UserID: 1, Title: "Standup", Priority: "low"}
+		var patch domain.TaskPatch
+		_ = json.Unmarshal([]byte(`{"missed_run_policy":"newest"}`), &patch)
+
+		mockTaskRepo.On("GetByID", mock.Anything, int64(23)).Return(existing, nil).Once()
+
+		_, err := u.Patch(context.Background(), 23, 1, patch)
+
+		assert.ErrorIs(t, err, domain.ErrBadParamInput)
+		mockTaskRepo.AssertNotCalled(t, "Update", mock.Anything, existing, mock.Anything)
+	})
+}
+
+func TestRecurrenceCatchUp(t *testing.T) {
+	rule, err := domain.ParseRecurrence("daily")
+	assert.NoError(t, err)
+
+	// Seri harian 09:00; server mati sejak 1 Maret, hidup lagi 7 Maret 09:05
+	start := time.Date(2026, 2, 20, 9, 0, 0, 0, time.UTC)
+	nextRun := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	now := time.Date(2026, 3, 7, 9, 5, 0, 0, time.UTC)
+	wantNext := time.Date(2026, 3, 8, 9, 0, 0, 0, time.UTC)
+
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 9, 0, 0, 0, time.UTC) }
+
+	cases := []struct {
+		policy  string
+		create  []time.Time
+		skipped int
+	}{
+		{domain.MissedRunAll, []time.Time{day(1), day(2), day(3), day(4), day(5), day(6), day(7)}, 0},
+		{domain.MissedRunLatest, []time.Time{day(7)}, 6},
+		{domain.MissedRunSkip, []time.Time{day(7)}, 6},
+	}
+	for _, tc := range cases {
+		t.Run("Policy "+tc.policy, func(t *testing.T) {
+			plan := rule.CatchUp(start, nextRun, now, tc.policy, time.UTC)
+
+			assert.Equal(t, tc.create, plan.Create)
+			assert.Equal(t, tc.skipped, plan.Skipped)
+			assert.Equal(t, wantNext, *plan.Next)
+		})
+	}
+
+	t.Run("Skip Drops Occurrences Outside The Grace Window", func(t *testing.T) {
+		plan := rule.CatchUp(start, nextRun, now.Add(time.Hour), domain.MissedRunSkip, time.UTC)
+
+		assert.Empty(t, plan.Create)
+		assert.Equal(t, 7, plan.Skipped)
+		assert.Equal(t, wantNext, *plan.Next)
+	})
+
+	t.Run("All Is Capped For Very Long Outages", func(t *testing.T) {
+		plan := rule.CatchUp(start, nextRun, nextRun.AddDate(1, 0, 0).Add(time.Minute), domain.MissedRunAll, time.UTC)
+
+		assert.Len(t, plan.Create, 100)
+		assert.Equal(t, 266, plan.Skipped)
+		assert.Equal(t, nextRun.AddDate(1, 0, 0), plan.Create[len(plan.Create)-1])
+	})
+
+	t.Run("Ended Series Has No Next Run", func(t *testing.T) {
+		counted, err := domain.ParseRecurrence("FREQ=DAILY;COUNT=12")
+		assert.NoError(t, err)
+
+		plan := counted.CatchUp(start, nextRun, now, domain.MissedRunAll, time.UTC)
+
+		assert.Len(t, plan.Create, 3) // 1-3 Maret adalah kemunculan ke-10 s.d. 12
+		assert.Nil(t, plan.Next)
+	})
+}
+
+func TestCompletionRecurrence(t *testing.T) {
+	mockTaskRepo := new(mocks.TaskRepository)
+	mockStatusRepo := new(mocks.StatusRepository)
+	mockUserRepo := new(mocks.UserRepository)
+	u := usecase.NewTaskUsecase(mockTaskRepo, mockStatusRepo, new(mocks.ProjectRepository), new(mocks.WorkspaceRepository), mockUserRepo, new(mocks.TaskReminderRepository), 2*time.Second)
+	mockUserRepo.On("GetByID", mock.Anything, int64(1)).Return(&domain.User{ID: 1, Timezone: "UTC"}, nil)
+	mockStatusRepo.On("GetWorkflow", mock.Anything, int64(1)).Return(nil, nil)
+	mockStatusRepo.On("LogChange", mock.Anything, mock.Anything).Return(nil)
+
+	chore := func(id int64) *domain.Task {
+		return &domain.Task{ID: id, UserID: 1, Title: "Water plants", Status: "pending", Priority: "low",
+			RecurrencePattern: "FREQ=DAILY;INTERVAL=3", RecurrenceMode: domain.RecurrenceModeCompletion}
+	}
+
+	t.Run("Success - Completion Mode Needs No Next Run", func(t *testing.T) {
+		existing := &domain.Task{ID: 80, UserID: 1, Title: "Water plants", Priority: "low"}
+		var patch domain.TaskPatch
+		_ = json.Unmarshal([]byte(`{"recurrence_pattern":"freq=daily;interval=3","recurrence_mode":"completion"}`), &patch)
+
+		mockTaskRepo.On("GetByID", mock.Anything, int64(80)).Return(existing, nil).Once()
+		mockTaskRepo.On("Update", mock.Anything, existing, mock.Anything).Return(nil).Once()
+
+		task, err := u.Patch(context.Background(), 80, 1, patch)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "FREQ=DAILY;INTERVAL=3", task.RecurrencePattern)
+		assert.Nil(t, task.NextRun)
+		assert.Nil(t, task.RecurrenceStart)
+	})
+
+	t.Run("Failed - Count Is Rejected In Completion Mode", func(t *testing.T) {
+		existing := &domain.Task{ID: 81, UserID: 1, Title: "Water plants", Priority: "low"}
+		var patch domain.TaskPatch
+		_ = json.Unmarshal([]byte(`{"recurrence_pattern":"FREQ=DAILY;COUNT=3","recurrence_mode":"completion"}`), &patch)
+
+		mockTaskRepo.On("GetByID", mock.Anything, int64(81)).Return(existing, nil).Once()
+
+		_, err := u.Patch(context.Background(), 81, 1, patch)
+
+		assert.ErrorIs(t, err, domain.ErrBadParamInput)
+	})
+
+	t.Run("Success - Done Creates Next Instance Due After Completion", func(t *testing.T) {
+		template := chore(82)
+		mockTaskRepo.On("GetByID", mock.Anything, int64(82)).Return(template, nil).Once()
+
+		var created *domain.Task
+		mockTaskRepo.On("UpdateWith", mock.Anything, template, mock.Anything,
+			mock.MatchedBy(func(fx domain.TaskEffects) bool {
+				return fx.NextInstance != nil && fx.NextInstance.Event.Type == domain.EventRecurrenceInstanceCreated
+			})).
+			Run(func(args mock.Arguments) { created = &args.Get(3).(domain.TaskEffects).NextInstance.Task }).
+			Return(nil).Once()
+
+		err := u.UpdateStatus(context.Background(), 82, 1, "done")
+
+		assert.NoError(t, err)
+		if assert.NotNil(t, created) {
+			want := template.CompletedAt.Truncate(time.Second).AddDate(0, 0, 3)
+			assert.Equal(t, int64(82), *created.RecurrenceParentID)
+			assert.True(t, want.Equal(*created.DueAt), "due %v, want %v", created.DueAt, want)
+			assert.True(t, want.Equal(*created.OccurrenceAt))
+			assert.Equal(t, "pending", created.Status)
+			assert.Nil(t, created.CompletedAt)
+		}
+	})
+
+	t.Run("Success - Completing An Instance Continues From The Template", func(t *testing.T) {
+		template := chore(84)
+		done := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+		template.Status, template.CompletedAt = "done", &done
+		occurrence := done.AddDate(0, 0, 3)
+		instance := &domain.Task{ID: 85, UserID: 1, Title: "Water plants", Status: "pending", RecurrenceParentID: &template.ID, OccurrenceAt: &occurrence}
+
+		mockTaskRepo.On("GetByID", mock.Anything, int64(85)).Return(instance, nil).Once()
+		mockTaskRepo.On("GetByID", mock.Anything, int64(84)).Return(template, nil).Once()
+		// Repo yang memastikan belum ada kemunculan terbuka di transaksi yang sama
+		mockTaskRepo.On("UpdateWith", mock.Anything, instance, mock.Anything,
+			mock.MatchedBy(func(fx domain.TaskEffects) bool {
+				return fx.NextInstance != nil && fx.NextInstance.Event.Data["parent_id"] == int64(84) &&
+					fx.NextInstance.Event.Data["completed_id"] == int64(85)
+			})).
+			Return(nil).Once()
+
+		err := u.UpdateStatus(context.Background(), 85, 1, "done")
+
+		assert.NoError(t, err)
+		mockTaskRepo.AssertNotCalled(t, "ListSeries", mock.Anything, int64(84))
+	})
+
+	t.Run("Failed - Status Is Not Saved Without The Next Instance", func(t *testing.T) {
+		template := chore(86)
+		mockTaskRepo.On("GetByID", mock.Anything, int64(86)).Return(template, nil).Once()
+		mockTaskRepo.On("UpdateWith", mock.Anything, template, mock.Anything, mock.Anything).
+			Return(errors.New("insert failed")).Once()
+
+		err := u.UpdateStatus(context.Background(), 86, 1, "done")
+
+		assert.EqualError(t, err, "insert failed")
+		mockTaskRepo.AssertNotCalled(t, "Update", mock.Anything, template, mock.Anything)
+	})
+}
@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+)
+
+// maxReminderOffset membatasi reminder relatif paling jauh satu tahun sebelum tenggat
+const maxReminderOffset = 365 * 24 * 60
+
+// reminderBase menghitung titik acuan reminder relatif. Tenggat all-day dibaca di
+// zona waktu pembuat task, karena reminder-nya berlaku untuk semua penerima.
+func (u *TaskUsecase) reminderBase(ctx context.Context, task *domain.Task) *time.Time {
+	if task.DueAt == nil || !task.DueAllDay {
+		return task.ReminderBase(time.UTC)
+	}
+	return task.ReminderBase(u.ownerLocation(ctx, task.UserID))
+}
+
+// applyReminderInput memvalidasi input lalu mengisi definisi dan waktu efektif reminder
+func (u *TaskUsecase) applyReminderInput(ctx context.Context, task *domain.Task, reminder *domain.TaskReminder, in domain.TaskReminderInput) error {
+	switch {
+	case in.RemindAt != nil && in.OffsetMinutes != nil:
+		return domain.NewValidationError("remind_at", "cannot be combined with offset_minutes")
+	case in.RemindAt != nil:
+		at := in.RemindAt.UTC()
+		reminder.RemindAt = &at
+		reminder.OffsetMinutes = nil
+	case in.OffsetMinutes != nil:
+		if *in.OffsetMinutes < 0 || *in.OffsetMinutes > maxReminderOffset {
+			return domain.NewValidationError("offset_minutes", "must be between 0 and %d", maxReminderOffset)
+		}
+		offset := *in.OffsetMinutes
+		reminder.OffsetMinutes = &offset
+		reminder.RemindAt = nil
+		if base := u.reminderBase(ctx, task); base != nil {
+			at := base.Add(-time.Duration(offset) * time.Minute)
+			reminder.RemindAt = &at
+		}
+	default:
+		return domain.NewValidationError("remind_at", "either remind_at or offset_minutes is required")
+	}
+	return nil
+}
+
+// getReminder memastikan reminder ada dan milik task yang dimaksud
+func (u *TaskUsecase) getReminder(ctx context.Context, taskID int64, reminderID int64) (*domain.TaskReminder, error) {
+	reminder, err := u.reminderRepo.GetByID(ctx, reminderID)
+	if err != nil {
+		return nil, err
+	}
+	if reminder == nil || reminder.TaskID != taskID {
+		return nil, domain.NotFound("reminder not found")
+	}
+	return reminder, nil
+}
+
+// ListReminders mengembalikan semua reminder task
+func (u *TaskUsecase) ListReminders(c context.Context, taskID int64, userID int64) ([]domain.TaskReminder, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	if _, err := u.policy.authorizeTask(ctx, taskID, userID, permView); err != nil {
+		return nil, err
+	}
+	return u.reminderRepo.ListByTask(ctx, taskID)
+}
+
+// AddReminder menambahkan reminder absolut atau relatif ke task
+func (u *TaskUsecase) AddReminder(c context.Context, taskID int64, userID int64, in domain.TaskReminderInput) (*domain.TaskReminder, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	task, err := u.policy.authorizeTask(ctx, taskID, userID, permEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := u.reminderRepo.ListByTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= domain.MaxTaskReminders {
+		return nil, domain.Conflict("a task can have at most %d reminders", domain.MaxTaskReminders)
+	}
+
+	reminder := &domain.TaskReminder{TaskID: taskID}
+	if err := u.applyReminderInput(ctx, task, reminder, in); err != nil {
+		return nil, err
+	}
+	reminder.CreatedAt = time.Now()
+	reminder.UpdatedAt = reminder.CreatedAt
+
+	if err := u.reminderRepo.Create(ctx, reminder); err != nil {
+		return nil, err
+	}
+	return reminder, nil
+}
+
+// UpdateReminder mengganti definisi reminder, termasuk berpindah antara absolut dan relatif
+func (u *TaskUsecase) UpdateReminder(c context.Context, taskID int64, reminderID int64, userID int64, in domain.TaskReminderInput) (*domain.TaskReminder, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	task, err := u.policy.authorizeTask(ctx, taskID, userID, permEdit)
+	if err != nil {
+		return nil, err
+	}
+	reminder, err := u.getReminder(ctx, taskID, reminderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.applyReminderInput(ctx, task, reminder, in); err != nil {
+		return nil, err
+	}
+	reminder.UpdatedAt = time.Now()
+
+	if err := u.reminderRepo.Update(ctx, reminder); err != nil {
+		return nil, err
+	}
+	return reminder, nil
+}
+
+// DeleteReminder menghapus satu reminder task
+func (u *TaskUsecase) DeleteReminder(c context.Context, taskID int64, reminderID int64, userID int64) error {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	if _, err := u.policy.authorizeTask(ctx, taskID, userID, permEdit); err != nil {
+		return err
+	}
+	if _, err := u.getReminder(ctx, taskID, reminderID); err != nil {
+		return err
+	}
+	return u.reminderRepo.Delete(ctx, reminderID)
+}
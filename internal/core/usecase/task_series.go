@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+)
+
+// Series mengembalikan seri recurring tempat task id berada: template, instance yang sudah
+// dibuat, dan upcoming kemunculan berikutnya. id boleh template maupun salah satu instance.
+func (u *TaskUsecase) Series(c context.Context, id int64, userID int64, upcoming int) (*domain.TaskSeries, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	switch {
+	case upcoming == 0:
+		upcoming = defaultRecurrencePreview
+	case upcoming < 0 || upcoming > maxRecurrencePreview:
+		return nil, domain.NewValidationError("upcoming", "must be between 1 and %d", maxRecurrencePreview)
+	}
+
+	template, err := u.seriesTemplate(ctx, id, userID, permView)
+	if err != nil {
+		return nil, err
+	}
+	instances, err := u.taskRepo.ListSeries(ctx, template.ID)
+	if err != nil {
+		return nil, err
+	}
+	if template.RecurrencePattern == "" && len(instances) == 0 {
+		return nil, domain.NewValidationError("id", "is not part of a recurring series")
+	}
+
+	clock := u.newDueClock(userID)
+	clock.mark(ctx, template)
+	for i := range instances {
+		clock.mark(ctx, &instances[i])
+	}
+
+	series := &domain.TaskSeries{Template: *template, Instances: instances, Upcoming: []time.Time{}}
+	if template.RecurrencePattern == "" || template.NextRun == nil {
+		return series, nil
+	}
+	rule, err := domain.ParseRecurrence(template.RecurrencePattern)
+	if err != nil {
+		return series, nil // aturan rusak akan dimatikan scheduler; seri tetap bisa dilihat
+	}
+	start := *template.NextRun
+	if template.RecurrenceStart != nil {
+		start = *template.RecurrenceStart
+	}
+	series.Upcoming = append(series.Upcoming, *template.NextRun)
+	for _, o := range rule.After(start, *template.NextRun, upcoming-1, u.ownerLocation(ctx, template.UserID)) {
+		series.Upcoming = append(series.Upcoming, o.UTC())
+	}
+	return series, nil
+}
+
+// seriesTemplate mengembalikan template dari task id (task itu sendiri jika bukan instance)
+func (u *TaskUsecase) seriesTemplate(ctx context.Context, id int64, userID int64, perm permission) (*domain.Task, error) {
+	task, err := u.policy.authorizeTask(ctx, id, userID, perm)
+	if err != nil {
+		return nil, err
+	}
+	if task.RecurrenceParentID == nil {
+		return task, nil
+	}
+	return u.policy.authorizeTask(ctx, *task.RecurrenceParentID, userID, perm)
+}
+
+// PatchFuture menerapkan patch ke kemunculan ini dan semua kemunculan sesudahnya, seperti
+// "edit semua acara berikutnya" di aplikasi kalender. Template ikut diubah supaya instance
+// yang dibuat nanti memakai nilai baru; instance lama yang sudah selesai tidak disentuh.
+// Field yang melekat pada satu kemunculan (status, tenggat, reminder) hanya bisa diubah per kemunculan.
+func (u *TaskUsecase) PatchFuture(c context.Context, id int64, userID int64, patch domain.TaskPatch) (*domain.Task, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	verr := &domain.ValidationError{}
+	for _, f := range []struct {
+		name string
+		set  bool
+	}{
+		{"status", patch.Status.Set},
+		{"reminder_time", patch.ReminderTime.Set},
+		{"due_at", patch.DueAt.Set},
+		{"due_all_day", patch.DueAllDay.Set},
+	} {
+		if f.set {
+			verr.Add(f.name, "can only be changed for a single occurrence")
+		}
+	}
+	if err := verr.OrNil(); err != nil {
+		return nil, err
+	}
+
+	task, err := u.policy.authorizeTask(ctx, id, userID, permEdit)
+	if err != nil {
+		return nil, err
+	}
+	template, anchor := task, (*time.Time)(nil)
+	switch {
+	case task.RecurrenceParentID != nil:
+		if template, err = u.policy.authorizeTask(ctx, *task.RecurrenceParentID, userID, permEdit); err != nil {
+			return nil, err
+		}
+		anchor = task.OccurrenceAt
+	case task.RecurrencePattern == "":
+		return nil, domain.NewValidationError("scope", "task is not part of a recurring series")
+	}
+
+	// Template menerima seluruh patch, termasuk perubahan aturan recurrence
+	if err := applyTaskPatch(template, patch); err != nil {
+		return nil, err
+	}
+	if patch.RecurrencePattern.Set || patch.NextRun.Set || patch.RecurrenceMode.Set {
+		if err := u.prepareRecurrence(ctx, template); err != nil {
+			return nil, err
+		}
+	}
+	if patch.ProjectID.Set {
+		if err := u.moveToProject(ctx, template, patch.ProjectID.Value, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	instances, err := u.taskRepo.ListSeries(ctx, template.ID)
+	if err != nil {
+		return nil, err
+	}
+	shared := domain.TaskPatch{Title: patch.Title, Description: patch.Description, Priority: patch.Priority, Labels: patch.Labels}
+
+	now := time.Now()
+	clock := u.newDueClock(userID)
+	result := template
+	tasks := []*domain.Task{template}
+	for i := range instances {
+		inst := &instances[i]
+		following := inst.CompletedAt == nil && (anchor == nil || (inst.OccurrenceAt != nil && !inst.OccurrenceAt.Before(*anchor)))
+		if inst.ID != task.ID && !following {
+			continue
+		}
+		if err := applyTaskPatch(inst, shared); err != nil {
+			return nil, err
+		}
+		if patch.ProjectID.Set {
+			inst.ProjectID = template.ProjectID
+		}
+		if inst.ID == task.ID {
+			result = inst
+		}
+		tasks = append(tasks, inst)
+	}
+
+	events := make([]domain.Event, len(tasks))
+	for i, t := range tasks {
+		t.UpdatedAt = now
+		clock.mark(ctx, t)
+		events[i] = taskEvent(domain.EventTaskUpdated, t, userID, map[string]any{"task": t})
+	}
+	if err := u.taskRepo.UpdateSeries(ctx, tasks, events); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
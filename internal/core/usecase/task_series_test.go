@@ -0,0 +1,168 @@
+package usecase_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+	"simple-task-manager/internal/core/usecase"
+	"simple-task-manager/internal/core/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// weeklySeries membuat template mingguan (Senin 09:00) beserta instance untuk setiap kemunculan
+func weeklySeries(templateID int64, occurrences ...time.Time) (*domain.Task, []domain.Task) {
+	start := occurrences[0]
+	next := occurrences[len(occurrences)-1].AddDate(0, 0, 7)
+	template := &domain.Task{ID: templateID, UserID: 1, Title: "Weekly review", Priority: "medium",
+		RecurrencePattern: "FREQ=WEEKLY;BYDAY=MO", RecurrenceStart: &start, NextRun: &next, MissedRunPolicy: domain.MissedRunLatest}
+
+	instances := make([]domain.Task, len(occurrences))
+	for i := range occurrences {
+		instances[i] = domain.Task{ID: templateID + int64(i) + 1, UserID: 1, Title: "Weekly review", Priority: "medium",
+			RecurrenceParentID: &template.ID, OccurrenceAt: &occurrences[i]}
+	}
+	return template, instances
+}
+
+func taskIDs(tasks []*domain.Task) []int64 {
+	ids := make([]int64, len(tasks))
+	for i, t := range tasks {
+		ids[i] = t.ID
+	}
+	return ids
+}
+
+func TestSeries(t *testing.T) {
+	mockTaskRepo := new(mocks.TaskRepository)
+	mockUserRepo := new(mocks.UserRepository)
+	u := usecase.NewTaskUsecase(mockTaskRepo, new(mocks.StatusRepository), new(mocks.ProjectRepository), new(mocks.WorkspaceRepository), mockUserRepo, new(mocks.TaskReminderRepository), 2*time.Second)
+	mockUserRepo.On("GetByID", mock.Anything, int64(1)).Return(&domain.User{ID: 1, Timezone: "UTC"}, nil)
+
+	monday := func(d int) time.Time { return time.Date(2026, 3, d, 9, 0, 0, 0, time.UTC) }
+
+	t.Run("Success - Instance Resolves To Its Template", func(t *testing.T) {
+		template, instances := weeklySeries(30, monday(2), monday(9))
+		mockTaskRepo.On("GetByID", mock.Anything, int64(32)).Return(&instances[1], nil).Once()
+		mockTaskRepo.On("GetByID", mock.Anything, int64(30)).Return(template, nil).Once()
+		mockTaskRepo.On("ListSeries", mock.Anything, int64(30)).Return(instances, nil).Once()
+
+		series, err := u.Series(context.Background(), 32, 1, 3)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(30), series.Template.ID)
+		assert.Len(t, series.Instances, 2)
+		assert.Equal(t, []time.Time{monday(16), monday(23), monday(30)}, series.Upcoming)
+	})
+
+	t.Run("Failed - Task Is Not Recurring", func(t *testing.T) {
+		mockTaskRepo.On("GetByID", mock.Anything, int64(35)).Return(&domain.Task{ID: 35, UserID: 1, Title: "One-off"}, nil).Once()
+		mockTaskRepo.On("ListSeries", mock.Anything, int64(35)).Return([]domain.Task{}, nil).Once()
+
+		_, err := u.Series(context.Background(), 35, 1, 0)
+
+		assert.ErrorIs(t, err, domain.ErrBadParamInput)
+	})
+
+	t.Run("Failed - Other User Cannot View The Series", func(t *testing.T) {
+		template, _ := weeklySeries(36, monday(2))
+		mockTaskRepo.On("GetByID", mock.Anything, int64(36)).Return(template, nil).Once()
+
+		_, err := u.Series(context.Background(), 36, 2, 0)
+
+		assert.Error(t, err)
+		mockTaskRepo.AssertNotCalled(t, "ListSeries", mock.Anything, int64(36))
+	})
+}
+
+func TestPatchFuture(t *testing.T) {
+	mockTaskRepo := new(mocks.TaskRepository)
+	mockUserRepo := new(mocks.UserRepository)
+	u := usecase.NewTaskUsecase(mockTaskRepo, new(mocks.StatusRepository), new(mocks.ProjectRepository), new(mocks.WorkspaceRepository), mockUserRepo, new(mocks.TaskReminderRepository), 2*time.Second)
+	mockUserRepo.On("GetByID", mock.Anything, int64(1)).Return(&domain.User{ID: 1, Timezone: "UTC"}, nil)
+
+	monday := func(d int) time.Time { return time.Date(2026, 3, d, 9, 0, 0, 0, time.UTC) }
+	titlePatch := func() domain.TaskPatch {
+		var patch domain.TaskPatch
+		_ = json.Unmarshal([]byte(`{"title":"Weekly planning","priority":"high"}`), &patch)
+		return patch
+	}
+
+	t.Run("Success - This And Following Open Occurrences Plus Template", func(t *testing.T) {
+		template, instances := weeklySeries(40, monday(2), monday(9), monday(16), monday(23))
+		done := monday(20)
+		instances[2].CompletedAt = &done // 16 Maret sudah selesai: tidak diubah
+
+		mockTaskRepo.On("GetByID", mock.Anything, int64(42)).Return(&instances[1], nil).Once()
+		mockTaskRepo.On("GetByID", mock.Anything, int64(40)).Return(template, nil).Once()
+		mockTaskRepo.On("ListSeries", mock.Anything, int64(40)).Return(instances, nil).Once()
+		mockTaskRepo.On("UpdateSeries", mock.Anything, mock.MatchedBy(func(tasks []*domain.Task) bool {
+			return assert.ObjectsAreEqual([]int64{40, 42, 44}, taskIDs(tasks))
+		}), mock.AnythingOfType("[]domain.Event")).Return(nil).Once()
+
+		task, err := u.PatchFuture(context.Background(), 42, 1, titlePatch())
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(42), task.ID)
+		assert.Equal(t, "Weekly planning", task.Title)
+		assert.Equal(t, "Weekly planning", template.Title)
+		assert.Equal(t, "high", instances[3].Priority)
+		assert.Equal(t, "Weekly review", instances[0].Title)
+		assert.Equal(t, "Weekly review", instances[2].Title)
+		mockTaskRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success - Template Changes Every Open Occurrence", func(t *testing.T) {
+		template, instances := weeklySeries(50, monday(2), monday(9))
+		mockTaskRepo.On("GetByID", mock.Anything, int64(50)).Return(template, nil).Once()
+		mockTaskRepo.On("ListSeries", mock.Anything, int64(50)).Return(instances, nil).Once()
+		mockTaskRepo.On("UpdateSeries", mock.Anything, mock.MatchedBy(func(tasks []*domain.Task) bool {
+			return assert.ObjectsAreEqual([]int64{50, 51, 52}, taskIDs(tasks))
+		}), mock.AnythingOfType("[]domain.Event")).Return(nil).Once()
+
+		task, err := u.PatchFuture(context.Background(), 50, 1, titlePatch())
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(50), task.ID)
+		assert.Equal(t, "Weekly planning", instances[0].Title)
+	})
+
+	t.Run("Failed - Per-Occurrence Fields Are Rejected", func(t *testing.T) {
+		var patch domain.TaskPatch
+		_ = json.Unmarshal([]byte(`{"status":"done","due_at":"2026-03-10T09:00:00Z"}`), &patch)
+
+		_, err := u.PatchFuture(context.Background(), 42, 1, patch)
+
+		assert.ErrorIs(t, err, domain.ErrBadParamInput)
+		var verr *domain.ValidationError
+		assert.ErrorAs(t, err, &verr)
+		if assert.Len(t, verr.Fields, 2) {
+			assert.Equal(t, "status", verr.Fields[0].Field)
+			assert.Equal(t, "due_at", verr.Fields[1].Field)
+		}
+	})
+
+	t.Run("Failed - Task Is Not Recurring", func(t *testing.T) {
+		mockTaskRepo.On("GetByID", mock.Anything, int64(60)).Return(&domain.Task{ID: 60, UserID: 1, Title: "One-off"}, nil).Once()
+
+		_, err := u.PatchFuture(context.Background(), 60, 1, titlePatch())
+
+		assert.ErrorIs(t, err, domain.ErrBadParamInput)
+	})
+
+	t.Run("Failed - Single Occurrence Cannot Change The Rule", func(t *testing.T) {
+		_, instances := weeklySeries(70, monday(2))
+		var patch domain.TaskPatch
+		_ = json.Unmarshal([]byte(`{"recurrence_pattern":"daily"}`), &patch)
+		mockTaskRepo.On("GetByID", mock.Anything, int64(71)).Return(&instances[0], nil).Once()
+
+		_, err := u.Patch(context.Background(), 71, 1, patch)
+
+		assert.ErrorIs(t, err, domain.ErrBadParamInput)
+		mockTaskRepo.AssertNotCalled(t, "Update", mock.Anything, &instances[0], mock.Anything)
+	})
+}
@@ -2,7 +2,9 @@ package usecase
 
 import (
     "context"
-    "errors"
+    "log"
+    "slices"
+    "strings"
     "time"
 
     "simple-task-manager/internal/core/domain"
@@ -10,16 +12,50 @@ import (
 
 type TaskUsecase struct {
     taskRepo       domain.TaskRepository
+    statusRepo     domain.StatusRepository
+    userRepo       domain.UserRepository
+    reminderRepo   domain.TaskReminderRepository
+    policy         *policy
     contextTimeout time.Duration
 }
 
-func NewTaskUsecase(taskRepo domain.TaskRepository, timeout time.Duration) *TaskUsecase {
+func NewTaskUsecase(
+    taskRepo domain.TaskRepository,
+    statusRepo domain.StatusRepository,
+    projectRepo domain.ProjectRepository,
+    workspaceRepo domain.WorkspaceRepository,
+    userRepo domain.UserRepository,
+    reminderRepo domain.TaskReminderRepository,
+    timeout time.Duration,
+) *TaskUsecase {
     return &TaskUsecase{
         taskRepo:       taskRepo,
+        statusRepo:     statusRepo,
+        userRepo:       userRepo,
+        reminderRepo:   reminderRepo,
+        policy:         newPolicy(taskRepo, projectRepo, workspaceRepo),
         contextTimeout: timeout,
     }
 }
 
+// taskEvent menyusun event perubahan task untuk semua yang bisa melihatnya: pemilik untuk
+// task pribadi, atau seluruh anggota workspace lewat WorkspaceID. Event ditulis repository
+// ke outbox bersama perubahannya, lalu dikirim relay ke subscriber.
+func taskEvent(eventType string, task *domain.Task, actorID int64, data map[string]any) domain.Event {
+    e := domain.Event{
+        Type:        eventType,
+        TaskID:      task.ID,
+        ActorID:     actorID,
+        WorkspaceID: task.WorkspaceID,
+        Data:        data,
+        OccurredAt:  time.Now(),
+    }
+    if task.WorkspaceID == nil {
+        e.Recipients = []int64{task.UserID}
+    }
+    return e
+}
+
 // --- TASK METHODS ---
 
 // Create task baru
@@ -30,19 +66,283 @@ func (u *TaskUsecase) Create(c context.Context, task *domain.Task) error {
     task.CreatedAt = time.Now()
     task.UpdatedAt = time.Now()
 
+    if err := normalizeDue(task); err != nil {
+        return err
+    }
+
+    machine, err := loadStatusMachine(ctx, u.statusRepo, task.UserID)
+    if err != nil {
+        return err
+    }
+
+    if task.WorkspaceID != nil {
+        if _, err := u.policy.authorizeWorkspace(ctx, *task.WorkspaceID, task.UserID, permEdit); err != nil {
+            return err
+        }
+    }
+
+    // Default dari project dipakai hanya jika client tidak mengirim nilainya
+    if task.ProjectID != nil {
+        project, err := u.policy.authorizeTaskTarget(ctx, *task.ProjectID, task.UserID)
+        if err != nil {
+            return err
+        }
+        // Task ikut workspace project-nya
+        if task.WorkspaceID == nil {
+            task.WorkspaceID = project.WorkspaceID
+        }
+        if !sameWorkspace(task.WorkspaceID, project.WorkspaceID) {
+            return domain.NewValidationError("project_id", "belongs to a different workspace")
+        }
+        if task.Priority == "" {
+            task.Priority = project.DefaultPriority
+        }
+        if _, ok := machine.statuses[project.DefaultStatus]; task.Status == "" && ok {
+            task.Status = project.DefaultStatus
+        }
+    }
+
     if task.Status == "" {
-        task.Status = "pending"
+        task.Status = machine.initial
+    }
+    if err := machine.check("", task.Status); err != nil {
+        return err
+    }
+    task.StartedAt, task.CompletedAt = nil, nil
+    machine.stamp(task, task.CreatedAt)
+    if err := u.prepareRecurrence(ctx, task); err != nil {
+        return err
+    }
+    task.AssigneeIDs = []int64{} // assignee diatur lewat Assign setelah task dibuat
+
+    u.newDueClock(task.UserID).mark(ctx, task)
+    if err := u.taskRepo.Create(ctx, task, taskEvent(domain.EventTaskCreated, task, task.UserID, map[string]any{"task": task})); err != nil {
+        return err
+    }
+    return nil
+}
+
+// normalizeDue memastikan tenggat all-day disimpan sebagai tanggal saja (00:00 UTC)
+// sesuai tanggal yang dikirim client, tanpa bergeser karena offset zona waktunya
+func normalizeDue(task *domain.Task) error {
+    if !task.DueAllDay {
+        return nil
+    }
+    if task.DueAt == nil {
+        return domain.NewValidationError("due_at", "is required for all-day due dates")
+    }
+    y, m, d := task.DueAt.Date()
+    date := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+    task.DueAt = &date
+    return nil
+}
+
+// dueClock menghitung IsOverdue untuk user yang meminta. Zona waktu user
+// baru dimuat saat ada tenggat all-day, karena hanya itu yang bergantung padanya.
+type dueClock struct {
+    u      *TaskUsecase
+    userID int64
+    now    time.Time
+    loc    *time.Location
+}
+
+func (u *TaskUsecase) newDueClock(userID int64) *dueClock {
+    return &dueClock{u: u, userID: userID, now: time.Now()}
+}
+
+func (c *dueClock) location(ctx context.Context) *time.Location {
+    if c.loc == nil {
+        user, err := c.u.userRepo.GetByID(ctx, c.userID)
+        if err != nil {
+            log.Printf("failed to load time zone for user %d, falling back to UTC: %v", c.userID, err)
+        }
+        c.loc = user.Location()
     }
+    return c.loc
+}
 
-    return u.taskRepo.Create(ctx, task)
+func (c *dueClock) mark(ctx context.Context, task *domain.Task) {
+    switch {
+    case task.DueAt == nil:
+        task.IsOverdue = false
+    case task.DueAllDay:
+        task.IsOverdue = task.Overdue(c.now, c.location(ctx))
+    default:
+        task.IsOverdue = task.Overdue(c.now, time.UTC)
+    }
 }
 
-// Fetch semua task milik user tertentu
-func (u *TaskUsecase) Fetch(c context.Context, userID int64) ([]domain.Task, error) {
+const (
+    defaultFetchLimit = 50
+    maxFetchLimit     = 200
+)
+
+// Fetch task milik user sesuai filter, satu halaman per panggilan
+func (u *TaskUsecase) Fetch(c context.Context, filter domain.TaskFilter) (*domain.TaskPage, error) {
     ctx, cancel := context.WithTimeout(c, u.contextTimeout)
     defer cancel()
 
-    return u.taskRepo.Fetch(ctx, userID)
+    switch filter.SortBy {
+    case "":
+        filter.SortBy = domain.TaskSortCreatedAt
+        filter.SortDesc = true
+    case domain.TaskSortCreatedAt, domain.TaskSortUpdatedAt, domain.TaskSortReminderTime,
+        domain.TaskSortPriority, domain.TaskSortTitle, domain.TaskSortDueAt:
+    default:
+        return nil, domain.NewValidationError("sort", "unknown sort key %q", filter.SortBy)
+    }
+
+    if filter.Limit < 0 || filter.Limit > maxFetchLimit {
+        return nil, domain.NewValidationError("limit", "must be between 1 and %d", maxFetchLimit)
+    }
+    if filter.Limit == 0 {
+        filter.Limit = defaultFetchLimit
+    }
+
+    if filter.ProjectID != nil && filter.WithoutProject {
+        return nil, domain.NewValidationError("project_id", "cannot filter by a project and by no project at once")
+    }
+    if filter.WorkspaceID != nil && filter.PersonalOnly {
+        return nil, domain.NewValidationError("workspace_id", "cannot filter by a workspace and by personal tasks at once")
+    }
+    if filter.WorkspaceID != nil {
+        if _, err := u.policy.authorizeWorkspace(ctx, *filter.WorkspaceID, filter.UserID, permView); err != nil {
+            return nil, err
+        }
+    }
+
+    if err := checkRange("created", filter.CreatedFrom, filter.CreatedTo); err != nil {
+        return nil, err
+    }
+    if err := checkRange("updated", filter.UpdatedFrom, filter.UpdatedTo); err != nil {
+        return nil, err
+    }
+    if err := checkRange("reminder", filter.ReminderFrom, filter.ReminderTo); err != nil {
+        return nil, err
+    }
+    if err := checkRange("due", filter.DueFrom, filter.DueTo); err != nil {
+        return nil, err
+    }
+
+    page, err := u.taskRepo.Fetch(ctx, filter)
+    if err != nil {
+        return nil, err
+    }
+    clock := u.newDueClock(filter.UserID)
+    for i := range page.Tasks {
+        clock.mark(ctx, &page.Tasks[i])
+    }
+    return page, nil
+}
+
+const (
+    defaultUpcomingDays = 7
+    maxUpcomingDays     = 90
+    maxDuePages         = 10 // batas aman jumlah halaman yang dikumpulkan Due
+)
+
+// Due mengelompokkan task yang belum selesai ke overdue, today (jatuh tempo
+// sebelum akhir hari ini) dan upcoming (dalam `days` hari setelah hari ini),
+// semuanya menurut zona waktu user
+func (u *TaskUsecase) Due(c context.Context, userID int64, days int) (*domain.TaskDueBuckets, error) {
+    ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+    defer cancel()
+
+    if days == 0 {
+        days = defaultUpcomingDays
+    }
+    if days < 0 || days > maxUpcomingDays {
+        return nil, domain.NewValidationError("days", "must be between 1 and %d", maxUpcomingDays)
+    }
+
+    clock := u.newDueClock(userID)
+    loc := clock.location(ctx)
+    now := clock.now.In(loc)
+    y, m, d := now.Date()
+    startOfToday := time.Date(y, m, d, 0, 0, 0, 0, loc)
+    endOfToday := startOfToday.AddDate(0, 0, 1)
+    endOfUpcoming := endOfToday.AddDate(0, 0, days)
+
+    buckets := &domain.TaskDueBuckets{
+        Timezone: loc.String(),
+        Overdue:  []domain.Task{},
+        Today:    []domain.Task{},
+        Upcoming: []domain.Task{},
+    }
+    classify := func(t domain.Task) *[]domain.Task {
+        due, _ := t.DueBy(loc)
+        switch {
+        case t.Overdue(now, loc):
+            return &buckets.Overdue
+        case !due.After(endOfToday):
+            return &buckets.Today
+        case !due.After(endOfUpcoming):
+            return &buckets.Upcoming
+        }
+        return nil
+    }
+
+    // Overdue dan today/upcoming diambil dengan jendela terpisah supaya tumpukan task lama
+    // yang terlewat tidak menghabiskan batas halaman milik hari ini. Tenggat all-day disimpan
+    // 00:00 UTC, jadi setiap jendela dilebarkan satu hari agar cocok di zona waktu mana pun.
+    // Overdue diurutkan terbaru dulu sehingga yang terpotong adalah yang paling lama.
+    overdueTo := now.Add(24 * time.Hour)
+    upcomingFrom := startOfToday.Add(-24 * time.Hour)
+    upcomingTo := endOfUpcoming.Add(24 * time.Hour)
+    windows := []struct {
+        filter  domain.TaskFilter
+        overdue bool
+    }{
+        {domain.TaskFilter{DueTo: &overdueTo, SortDesc: true}, true},
+        {domain.TaskFilter{DueFrom: &upcomingFrom, DueTo: &upcomingTo}, false},
+    }
+    for _, w := range windows {
+        filter := w.filter
+        filter.UserID, filter.Incomplete, filter.SortBy, filter.Limit = userID, true, domain.TaskSortDueAt, maxFetchLimit
+
+        page := 0
+        for ; page < maxDuePages; page++ {
+            result, err := u.taskRepo.Fetch(ctx, filter)
+            if err != nil {
+                return nil, err
+            }
+            for _, t := range result.Tasks {
+                bucket := classify(t)
+                // Task di perbatasan muncul di kedua jendela; masing-masing hanya mengisi bucket-nya
+                if bucket == nil || (bucket == &buckets.Overdue) != w.overdue {
+                    continue
+                }
+                t.IsOverdue = w.overdue
+                *bucket = append(*bucket, t)
+            }
+            if result.NextCursor == "" {
+                break
+            }
+            filter.Cursor = result.NextCursor
+        }
+        if page == maxDuePages {
+            buckets.Truncated = true
+        }
+    }
+
+    // Urutan dari repository memakai due_at mentah; all-day perlu diurutkan ulang menurut zona waktu user
+    byDue := func(a, b domain.Task) int {
+        da, _ := a.DueBy(loc)
+        db, _ := b.DueBy(loc)
+        return da.Compare(db)
+    }
+    slices.SortStableFunc(buckets.Overdue, byDue)
+    slices.SortStableFunc(buckets.Today, byDue)
+    slices.SortStableFunc(buckets.Upcoming, byDue)
+    return buckets, nil
+}
+
+// checkRange memastikan batas bawah tidak melewati batas atas
+func checkRange(name string, from, to *time.Time) error {
+    if from != nil && to != nil && from.After(*to) {
+        return domain.NewValidationError(name+"_from", "is after %s_to", name)
+    }
+    return nil
 }
 
 // Update status task
@@ -50,22 +350,247 @@ func (u *TaskUsecase) UpdateStatus(c context.Context, id int64, userID int64, st
     ctx, cancel := context.WithTimeout(c, u.contextTimeout)
     defer cancel()
 
-    task, err := u.taskRepo.GetByID(ctx, id)
+    task, err := u.policy.authorizeTask(ctx, id, userID, permEdit)
+    if err != nil {
+        return err
+    }
+
+    machine, err := loadStatusMachine(ctx, u.statusRepo, task.UserID)
     if err != nil {
         return err
     }
-    if task == nil {
-        return errors.New("task not found")
+
+    from, wasDone := task.Status, task.CompletedAt != nil
+    task.UpdatedAt = time.Now()
+    changed, err := machine.transition(task, strings.TrimSpace(status), task.UpdatedAt)
+    if err != nil {
+        return err
     }
 
-    if task.UserID != userID {
-        return errors.New("unauthorized: you don't own this task")
+    var effects domain.TaskEffects
+    if !wasDone && task.CompletedAt != nil {
+        if effects.NextInstance, err = u.nextOccurrence(ctx, task, userID); err != nil {
+            return err
+        }
+    }
+    if err := u.saveTask(ctx, task, taskEvent(domain.EventTaskUpdated, task, userID, map[string]any{"task": task}), effects); err != nil {
+        return err
+    }
+    if changed {
+        u.logStatusChange(ctx, task, from)
+    }
+    return nil
+}
+
+// logStatusChange mencatat riwayat status; kegagalan hanya di-log karena task sudah tersimpan
+func (u *TaskUsecase) logStatusChange(ctx context.Context, task *domain.Task, from string) {
+    change := &domain.StatusChange{
+        TaskID:    task.ID,
+        UserID:    task.UserID,
+        From:      from,
+        To:        task.Status,
+        ChangedAt: *task.StatusChangedAt,
+    }
+    if err := u.statusRepo.LogChange(ctx, change); err != nil {
+        log.Printf("failed to log status change for task %d: %v", task.ID, err)
+    }
+}
+
+// Patch menerapkan perubahan parsial ke task; hanya field yang dikirim yang divalidasi & diubah
+func (u *TaskUsecase) Patch(c context.Context, id int64, userID int64, patch domain.TaskPatch) (*domain.Task, error) {
+    ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+    defer cancel()
+
+    task, err := u.policy.authorizeTask(ctx, id, userID, permEdit)
+    if err != nil {
+        return nil, err
+    }
+
+    // Aturan recurrence milik template; instance hanya bisa diubah lewat scope=future
+    if task.RecurrenceParentID != nil && (patch.RecurrencePattern.Set || patch.NextRun.Set || patch.RecurrenceMode.Set || patch.MissedRunPolicy.Set) {
+        return nil, domain.NewValidationError("recurrence_pattern", "is managed by the series template; use scope=future")
+    }
+    if err := applyTaskPatch(task, patch); err != nil {
+        return nil, err
+    }
+    if patch.RecurrencePattern.Set || patch.NextRun.Set || patch.RecurrenceMode.Set {
+        if err := u.prepareRecurrence(ctx, task); err != nil {
+            return nil, err
+        }
+    }
+
+    if patch.ProjectID.Set {
+        if err := u.moveToProject(ctx, task, patch.ProjectID.Value, userID); err != nil {
+            return nil, err
+        }
     }
 
-    task.Status = status
     task.UpdatedAt = time.Now()
 
-    return u.taskRepo.Update(ctx, task)
+    from, changed, wasDone := task.Status, false, task.CompletedAt != nil
+    if patch.Status.Set {
+        if patch.Status.Value == nil {
+            return nil, domain.NewValidationError("status", "cannot be null")
+        }
+        machine, err := loadStatusMachine(ctx, u.statusRepo, task.UserID)
+        if err != nil {
+            return nil, err
+        }
+        changed, err = machine.transition(task, strings.TrimSpace(*patch.Status.Value), task.UpdatedAt)
+        if err != nil {
+            return nil, err
+        }
+    }
+
+    u.newDueClock(userID).mark(ctx, task)
+    var effects domain.TaskEffects
+    if !wasDone && task.CompletedAt != nil {
+        if effects.NextInstance, err = u.nextOccurrence(ctx, task, userID); err != nil {
+            return nil, err
+        }
+    }
+    if patch.DueAt.Set || patch.DueAllDay.Set {
+        // Reminder relatif mengikuti tenggat baru, disimpan bersama task
+        effects.RecomputeReminders, effects.ReminderBase = true, u.reminderBase(ctx, task)
+    }
+    if err := u.saveTask(ctx, task, taskEvent(domain.EventTaskUpdated, task, userID, map[string]any{"task": task}), effects); err != nil {
+        return nil, err
+    }
+    if changed {
+        u.logStatusChange(ctx, task, from)
+    }
+    return task, nil
+}
+
+// moveToProject memindahkan task ke project to (nil = tanpa project).
+// Task hanya boleh dipindah ke project milik user yang belum diarsipkan.
+func (u *TaskUsecase) moveToProject(ctx context.Context, task *domain.Task, to *int64, userID int64) error {
+    moved := to != nil && (task.ProjectID == nil || *task.ProjectID != *to)
+    if moved {
+        project, err := u.policy.authorizeTaskTarget(ctx, *to, userID)
+        if err != nil {
+            return err
+        }
+        if !sameWorkspace(task.WorkspaceID, project.WorkspaceID) {
+            return domain.NewValidationError("project_id", "belongs to a different workspace")
+        }
+    }
+    task.ProjectID = to
+    return nil
+}
+
+// applyTaskPatch memvalidasi setiap field yang dikirim lalu menyalinnya ke task.
+// Semua field yang tidak valid dilaporkan sekaligus dalam satu ValidationError.
+// Status tidak ditangani di sini karena harus melewati statusMachine.
+func applyTaskPatch(task *domain.Task, patch domain.TaskPatch) error {
+    verr := &domain.ValidationError{}
+
+    if patch.Title.Set {
+        title := ""
+        if patch.Title.Value != nil {
+            title = strings.TrimSpace(*patch.Title.Value)
+        }
+        switch {
+        case title == "":
+            verr.Add("title", "cannot be empty")
+        case len(title) > 255:
+            verr.Add("title", "is longer than 255 characters")
+        default:
+            task.Title = title
+        }
+    }
+
+    if patch.Description.Set {
+        task.Description = ""
+        if patch.Description.Value != nil {
+            task.Description = *patch.Description.Value
+        }
+    }
+
+    if patch.Priority.Set {
+        switch {
+        case patch.Priority.Value == nil:
+            verr.Add("priority", "cannot be null")
+        case *patch.Priority.Value == "low", *patch.Priority.Value == "medium", *patch.Priority.Value == "high":
+            task.Priority = *patch.Priority.Value
+        default:
+            verr.Add("priority", "must be one of low, medium, high")
+        }
+    }
+
+    if patch.Labels.Set {
+        task.Labels = []string{}
+        if patch.Labels.Value != nil {
+            for _, l := range *patch.Labels.Value {
+                if l = strings.TrimSpace(l); l != "" {
+                    task.Labels = append(task.Labels, l)
+                }
+            }
+        }
+    }
+
+    if patch.ReminderTime.Set {
+        task.ReminderTime = patch.ReminderTime.Value
+    }
+
+    // Aturan divalidasi & dinormalisasi oleh prepareRecurrence setelah patch diterapkan
+    if patch.RecurrencePattern.Set {
+        task.RecurrencePattern = ""
+        if patch.RecurrencePattern.Value != nil {
+            task.RecurrencePattern = strings.TrimSpace(*patch.RecurrencePattern.Value)
+        }
+    }
+
+    if patch.NextRun.Set {
+        task.NextRun = patch.NextRun.Value
+    }
+
+    if patch.RecurrenceMode.Set {
+        switch {
+        case patch.RecurrenceMode.Value == nil:
+            verr.Add("recurrence_mode", "cannot be null")
+        case slices.Contains(domain.RecurrenceModes, *patch.RecurrenceMode.Value):
+            task.RecurrenceMode = *patch.RecurrenceMode.Value
+        default:
+            verr.Add("recurrence_mode", "must be one of schedule, completion")
+        }
+    }
+
+    if patch.MissedRunPolicy.Set {
+        switch {
+        case patch.MissedRunPolicy.Value == nil:
+            verr.Add("missed_run_policy", "cannot be null")
+        case slices.Contains(domain.MissedRunPolicies, *patch.MissedRunPolicy.Value):
+            task.MissedRunPolicy = *patch.MissedRunPolicy.Value
+        default:
+            verr.Add("missed_run_policy", "must be one of all, latest, skip")
+        }
+    }
+
+    if patch.DueAt.Set {
+        task.DueAt = patch.DueAt.Value
+    }
+    if patch.DueAllDay.Set {
+        if patch.DueAllDay.Value == nil {
+            verr.Add("due_all_day", "cannot be null")
+        } else {
+            task.DueAllDay = *patch.DueAllDay.Value
+        }
+    }
+    // Menghapus tenggat sekaligus mematikan all-day
+    if patch.DueAt.Set && task.DueAt == nil && !patch.DueAllDay.Set {
+        task.DueAllDay = false
+    }
+    if err := normalizeDue(task); err != nil {
+        verr.Add("due_at", "is required for all-day due dates")
+    }
+
+    // Scheduler hanya memproses task recurring yang punya next_run; mode completion tidak memakainya
+    if task.RecurrencePattern != "" && task.NextRun == nil && task.RecurrenceMode != domain.RecurrenceModeCompletion {
+        verr.Add("next_run", "is required for recurring tasks")
+    }
+
+    return verr.OrNil()
 }
 
 // Delete task
@@ -73,45 +598,136 @@ func (u *TaskUsecase) Delete(c context.Context, id int64, userID int64) error {
     ctx, cancel := context.WithTimeout(c, u.contextTimeout)
     defer cancel()
 
-    task, err := u.taskRepo.GetByID(ctx, id)
+    task, err := u.policy.authorizeTask(ctx, id, userID, permDelete)
     if err != nil {
         return err
     }
-    if task == nil {
-        return errors.New("task not found")
+
+    return u.taskRepo.Delete(ctx, id, taskEvent(domain.EventTaskDeleted, task, userID, map[string]any{"title": task.Title}))
+}
+
+// --- ASSIGNEE METHODS ---
+
+// Assign menambahkan assignee ke task. Assignee harus bisa melihat task:
+// task pribadi hanya bisa di-assign ke pembuatnya, task workspace ke anggota workspace.
+func (u *TaskUsecase) Assign(c context.Context, taskID int64, userID int64, assigneeID int64) (*domain.Task, error) {
+    ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+    defer cancel()
+
+    task, err := u.policy.authorizeTask(ctx, taskID, userID, permEdit)
+    if err != nil {
+        return nil, err
     }
 
-    if task.UserID != userID {
-        return errors.New("unauthorized: you don't own this task")
+    visible, err := u.policy.canView(ctx, task, assigneeID)
+    if err != nil {
+        return nil, err
+    }
+    if !visible {
+        return nil, domain.NewValidationError("user_id", "user %d cannot see task %d", assigneeID, taskID)
     }
 
-    return u.taskRepo.Delete(ctx, id)
+    now := time.Now()
+    added, err := u.taskRepo.AddAssignee(ctx, taskID, assigneeID, userID, now, domain.Event{
+        Type:        domain.EventTaskAssigned,
+        TaskID:      taskID,
+        ActorID:     userID,
+        WorkspaceID: task.WorkspaceID,
+        Recipients:  []int64{assigneeID},
+        Data:        map[string]any{"assignee_id": assigneeID, "title": task.Title},
+        OccurredAt:  now,
+    })
+    if err != nil {
+        return nil, err
+    }
+    if added {
+        task.AssigneeIDs = append(task.AssigneeIDs, assigneeID)
+    }
+    return task, nil
+}
+
+// Unassign melepas assignee dari task. Assignee boleh melepas dirinya sendiri
+// walau hanya punya akses baca.
+func (u *TaskUsecase) Unassign(c context.Context, taskID int64, userID int64, assigneeID int64) (*domain.Task, error) {
+    ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+    defer cancel()
+
+    perm := permEdit
+    if assigneeID == userID {
+        perm = permView
+    }
+    task, err := u.policy.authorizeTask(ctx, taskID, userID, perm)
+    if err != nil {
+        return nil, err
+    }
+
+    removed, err := u.taskRepo.RemoveAssignee(ctx, taskID, assigneeID, domain.Event{
+        Type:        domain.EventTaskUnassigned,
+        TaskID:      taskID,
+        ActorID:     userID,
+        WorkspaceID: task.WorkspaceID,
+        Recipients:  []int64{assigneeID},
+        Data:        map[string]any{"assignee_id": assigneeID, "title": task.Title},
+        OccurredAt:  time.Now(),
+    })
+    if err != nil {
+        return nil, err
+    }
+    if !removed {
+        return nil, domain.NotFound("user %d is not assigned to task %d", assigneeID, taskID)
+    }
+
+    task.AssigneeIDs = slices.DeleteFunc(task.AssigneeIDs, func(id int64) bool { return id == assigneeID })
+    return task, nil
 }
 
 // --- SUBTASK METHODS ---
 
-func (u *TaskUsecase) AddSubtask(c context.Context, taskID int64, title string) error {
+func (u *TaskUsecase) AddSubtask(c context.Context, taskID int64, userID int64, title string) (*domain.Subtask, error) {
     ctx, cancel := context.WithTimeout(c, u.contextTimeout)
     defer cancel()
 
+    title = strings.TrimSpace(title)
+    if title == "" || len(title) > 255 {
+        return nil, domain.NewValidationError("title", "must be 1-255 characters")
+    }
+
+    task, err := u.policy.authorizeTask(ctx, taskID, userID, permEdit)
+    if err != nil {
+        return nil, err
+    }
+
     sub := &domain.Subtask{
         TaskID: taskID,
         Title:  title,
         IsDone: false,
     }
-    return u.taskRepo.CreateSubtask(ctx, sub)
+    if err := u.taskRepo.CreateSubtask(ctx, sub, taskEvent(domain.EventSubtaskCreated, task, userID, map[string]any{"subtask": sub})); err != nil {
+        return nil, err
+    }
+    return sub, nil
 }
 
-func (u *TaskUsecase) ToggleSubtask(c context.Context, id int64) error {
+func (u *TaskUsecase) ToggleSubtask(c context.Context, id int64, userID int64) error {
     ctx, cancel := context.WithTimeout(c, u.contextTimeout)
     defer cancel()
 
-    return u.taskRepo.ToggleSubtask(ctx, id)
+    task, err := u.policy.authorizeSubtask(ctx, id, userID, permEdit)
+    if err != nil {
+        return err
+    }
+
+    return u.taskRepo.ToggleSubtask(ctx, id, taskEvent(domain.EventSubtaskToggled, task, userID, map[string]any{"subtask_id": id}))
 }
 
-func (u *TaskUsecase) DeleteSubtask(c context.Context, id int64) error {
+func (u *TaskUsecase) DeleteSubtask(c context.Context, id int64, userID int64) error {
     ctx, cancel := context.WithTimeout(c, u.contextTimeout)
     defer cancel()
 
-    return u.taskRepo.DeleteSubtask(ctx, id)
+    task, err := u.policy.authorizeSubtask(ctx, id, userID, permEdit)
+    if err != nil {
+        return err
+    }
+
+    return u.taskRepo.DeleteSubtask(ctx, id, taskEvent(domain.EventSubtaskDeleted, task, userID, map[string]any{"subtask_id": id}))
 }
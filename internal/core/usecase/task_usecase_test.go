@@ -2,6 +2,8 @@ package usecase_test
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"testing"
 	"time"
 
@@ -15,8 +17,10 @@ import (
 
 func TestCreateTask(t *testing.T) {
 	mockTaskRepo := new(mocks.TaskRepository)
+	mockStatusRepo := new(mocks.StatusRepository)
+	mockProjectRepo := new(mocks.ProjectRepository)
 	timeout := 2 * time.Second
-	u := usecase.NewTaskUsecase(mockTaskRepo, timeout)
+	u := usecase.NewTaskUsecase(mockTaskRepo, mockStatusRepo, mockProjectRepo, new(mocks.WorkspaceRepository), new(mocks.UserRepository), new(mocks.TaskReminderRepository), timeout)
 
 	t.Run("Success Create Task", func(t *testing.T) {
 		task := &domain.Task{
@@ -24,12 +28,401 @@ func TestCreateTask(t *testing.T) {
 			Title:  "Belajar Golang",
 		}
 
-		// Expectation: Repo Create dipanggil sekali
-		mockTaskRepo.On("Create", mock.Anything, task).Return(nil).Once()
+		// Expectation: Repo Create dipanggil sekali, bersama event untuk outbox
+		mockStatusRepo.On("GetWorkflow", mock.Anything, int64(1)).Return(nil, nil).Once()
+		mockTaskRepo.On("Create", mock.Anything, task, mock.MatchedBy(func(e domain.Event) bool {
+			return e.Type == domain.EventTaskCreated && e.ActorID == 1 && len(e.Recipients) == 1 && e.Recipients[0] == 1
+		})).Return(nil).Once()
 
 		err := u.Create(context.Background(), task)
 
 		assert.NoError(t, err)
 		assert.Equal(t, "pending", task.Status)
 	})
-}
\ No newline at end of file
+
+	t.Run("Project Defaults Apply When Omitted", func(t *testing.T) {
+		projectID := int64(5)
+		task := &domain.Task{UserID: 1, Title: "Deploy", ProjectID: &projectID}
+
+		mockStatusRepo.On("GetWorkflow", mock.Anything, int64(1)).Return(nil, nil).Once()
+		mockProjectRepo.On("GetByID", mock.Anything, projectID).
+			Return(&domain.Project{ID: 5, UserID: 1, DefaultPriority: "high", DefaultStatus: "in_progress"}, nil).Once()
+		mockTaskRepo.On("Create", mock.Anything, task, mock.Anything).Return(nil).Once()
+
+		err := u.Create(context.Background(), task)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "high", task.Priority)
+		assert.Equal(t, "in_progress", task.Status)
+		assert.NotNil(t, task.StartedAt)
+	})
+
+	t.Run("Failed - Archived Project", func(t *testing.T) {
+		projectID := int64(6)
+		archivedAt := time.Now()
+		task := &domain.Task{UserID: 1, Title: "Deploy", ProjectID: &projectID}
+
+		mockStatusRepo.On("GetWorkflow", mock.Anything, int64(1)).Return(nil, nil).Once()
+		mockProjectRepo.On("GetByID", mock.Anything, projectID).
+			Return(&domain.Project{ID: 6, UserID: 1, ArchivedAt: &archivedAt}, nil).Once()
+
+		err := u.Create(context.Background(), task)
+
+		assert.ErrorIs(t, err, domain.ErrConflict)
+	})
+
+	t.Run("Failed - Someone Else's Project", func(t *testing.T) {
+		projectID := int64(7)
+		task := &domain.Task{UserID: 1, Title: "Deploy", ProjectID: &projectID}
+
+		mockStatusRepo.On("GetWorkflow", mock.Anything, int64(1)).Return(nil, nil).Once()
+		mockProjectRepo.On("GetByID", mock.Anything, projectID).Return(&domain.Project{ID: 7, UserID: 2}, nil).Once()
+
+		err := u.Create(context.Background(), task)
+
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+		mockTaskRepo.AssertExpectations(t)
+	})
+}
+func TestFetchTasks(t *testing.T) {
+	mockTaskRepo := new(mocks.TaskRepository)
+	u := usecase.NewTaskUsecase(mockTaskRepo, new(mocks.StatusRepository), new(mocks.ProjectRepository), new(mocks.WorkspaceRepository), new(mocks.UserRepository), new(mocks.TaskReminderRepository), 2*time.Second)
+
+	t.Run("Default Sort and Limit", func(t *testing.T) {
+		expected := domain.TaskFilter{
+			UserID:   1,
+			SortBy:   domain.TaskSortCreatedAt,
+			SortDesc: true,
+			Limit:    50,
+		}
+		mockTaskRepo.On("Fetch", mock.Anything, expected).Return(&domain.TaskPage{}, nil).Once()
+
+		_, err := u.Fetch(context.Background(), domain.TaskFilter{UserID: 1})
+
+		assert.NoError(t, err)
+		mockTaskRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failed - Unknown Sort Key", func(t *testing.T) {
+		_, err := u.Fetch(context.Background(), domain.TaskFilter{UserID: 1, SortBy: "password"})
+
+		assert.ErrorIs(t, err, domain.ErrBadParamInput)
+	})
+
+	t.Run("Failed - Inverted Range", func(t *testing.T) {
+		from := time.Now()
+		to := from.Add(-time.Hour)
+
+		_, err := u.Fetch(context.Background(), domain.TaskFilter{UserID: 1, CreatedFrom: &from, CreatedTo: &to})
+
+		assert.ErrorIs(t, err, domain.ErrBadParamInput)
+	})
+}
+
+func TestPatchTask(t *testing.T) {
+	mockTaskRepo := new(mocks.TaskRepository)
+	u := usecase.NewTaskUsecase(mockTaskRepo, new(mocks.StatusRepository), new(mocks.ProjectRepository), new(mocks.WorkspaceRepository), new(mocks.UserRepository), new(mocks.TaskReminderRepository), 2*time.Second)
+
+	t.Run("Omitted Fields Are Kept, Null Clears Pointer", func(t *testing.T) {
+		reminder := time.Now()
+		existing := &domain.Task{ID: 10, UserID: 1, Title: "Lama", Priority: "low", ReminderTime: &reminder}
+
+		var patch domain.TaskPatch
+		err := json.Unmarshal([]byte(`{"priority":"high","reminder_time":null}`), &patch)
+		assert.NoError(t, err)
+
+		mockTaskRepo.On("GetByID", mock.Anything, int64(10)).Return(existing, nil).Once()
+		mockTaskRepo.On("Update", mock.Anything, existing, mock.Anything).Return(nil).Once()
+
+		task, err := u.Patch(context.Background(), 10, 1, patch)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Lama", task.Title)
+		assert.Equal(t, "high", task.Priority)
+		assert.Nil(t, task.ReminderTime)
+		mockTaskRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failed - Invalid Priority", func(t *testing.T) {
+		existing := &domain.Task{ID: 11, UserID: 1, Title: "Lama"}
+
+		var patch domain.TaskPatch
+		_ = json.Unmarshal([]byte(`{"priority":"urgent"}`), &patch)
+
+		mockTaskRepo.On("GetByID", mock.Anything, int64(11)).Return(existing, nil).Once()
+
+		_, err := u.Patch(context.Background(), 11, 1, patch)
+
+		assert.ErrorIs(t, err, domain.ErrBadParamInput)
+		mockTaskRepo.AssertNotCalled(t, "Update", mock.Anything, existing, mock.Anything)
+	})
+}
+
+func TestUpdateStatus(t *testing.T) {
+	mockTaskRepo := new(mocks.TaskRepository)
+	mockStatusRepo := new(mocks.StatusRepository)
+	u := usecase.NewTaskUsecase(mockTaskRepo, mockStatusRepo, new(mocks.ProjectRepository), new(mocks.WorkspaceRepository), new(mocks.UserRepository), new(mocks.TaskReminderRepository), 2*time.Second)
+
+	t.Run("Success - Done Stamps CompletedAt", func(t *testing.T) {
+		existing := &domain.Task{ID: 20, UserID: 1, Status: "in_progress"}
+
+		mockTaskRepo.On("GetByID", mock.Anything, int64(20)).Return(existing, nil).Once()
+		mockStatusRepo.On("GetWorkflow", mock.Anything, int64(1)).Return(nil, nil).Once()
+		mockTaskRepo.On("Update", mock.Anything, existing, mock.Anything).Return(nil).Once()
+		mockStatusRepo.On("LogChange", mock.Anything, mock.MatchedBy(func(c *domain.StatusChange) bool {
+			return c.From == "in_progress" && c.To == "done"
+		})).Return(nil).Once()
+
+		err := u.UpdateStatus(context.Background(), 20, 1, "done")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "done", existing.Status)
+		assert.NotNil(t, existing.CompletedAt)
+		assert.NotNil(t, existing.StartedAt)
+		mockTaskRepo.AssertExpectations(t)
+		mockStatusRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failed - Unknown Status", func(t *testing.T) {
+		existing := &domain.Task{ID: 21, UserID: 1, Status: "pending"}
+
+		mockTaskRepo.On("GetByID", mock.Anything, int64(21)).Return(existing, nil).Once()
+		mockStatusRepo.On("GetWorkflow", mock.Anything, int64(1)).Return(nil, nil).Once()
+
+		err := u.UpdateStatus(context.Background(), 21, 1, "dnoe")
+
+		var transitionErr *domain.InvalidTransitionError
+		assert.ErrorAs(t, err, &transitionErr)
+		assert.Equal(t, "pending", existing.Status)
+	})
+
+	t.Run("Failed - Transition Not Allowed By Custom Workflow", func(t *testing.T) {
+		existing := &domain.Task{ID: 22, UserID: 2, Status: "backlog"}
+		workflow := &domain.Workflow{
+			Statuses: []domain.TaskStatus{
+				{Key: "backlog", Category: domain.StatusCategoryTodo},
+				{Key: "review", Category: domain.StatusCategoryInProgress},
+				{Key: "shipped", Category: domain.StatusCategoryDone},
+			},
+			Transitions: []domain.StatusTransition{
+				{From: "backlog", To: "review"},
+				{From: "review", To: "shipped"},
+			},
+		}
+
+		mockTaskRepo.On("GetByID", mock.Anything, int64(22)).Return(existing, nil).Once()
+		mockStatusRepo.On("GetWorkflow", mock.Anything, int64(2)).Return(workflow, nil).Once()
+
+		err := u.UpdateStatus(context.Background(), 22, 2, "shipped")
+
+		var transitionErr *domain.InvalidTransitionError
+		assert.ErrorAs(t, err, &transitionErr)
+		assert.Equal(t, "backlog", transitionErr.From)
+		mockTaskRepo.AssertNotCalled(t, "Update", mock.Anything, existing, mock.Anything)
+	})
+}
+
+func TestAssignTask(t *testing.T) {
+	wsID := int64(4)
+	mockTaskRepo := new(mocks.TaskRepository)
+	mockWorkspaceRepo := new(mocks.WorkspaceRepository)
+	u := usecase.NewTaskUsecase(mockTaskRepo, new(mocks.StatusRepository), new(mocks.ProjectRepository), mockWorkspaceRepo, new(mocks.UserRepository), new(mocks.TaskReminderRepository), 2*time.Second)
+
+	t.Run("Failed - Personal Task Cannot Be Assigned To Others", func(t *testing.T) {
+		mockTaskRepo.On("GetByID", mock.Anything, int64(10)).Return(&domain.Task{ID: 10, UserID: 1}, nil).Once()
+
+		_, err := u.Assign(context.Background(), 10, 1, 2)
+
+		assert.ErrorIs(t, err, domain.ErrBadParamInput)
+		mockTaskRepo.AssertNotCalled(t, "AddAssignee", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failed - Assignee Not In Workspace", func(t *testing.T) {
+		mockTaskRepo.On("GetByID", mock.Anything, int64(11)).Return(&domain.Task{ID: 11, UserID: 1, WorkspaceID: &wsID}, nil).Once()
+		mockWorkspaceRepo.On("GetMember", mock.Anything, wsID, int64(1)).Return(member(wsID, 1, domain.RoleOwner), nil).Once()
+		mockWorkspaceRepo.On("GetMember", mock.Anything, wsID, int64(9)).Return(nil, nil).Once()
+
+		_, err := u.Assign(context.Background(), 11, 1, 9)
+
+		assert.ErrorIs(t, err, domain.ErrBadParamInput)
+	})
+
+	t.Run("Success - Writes Event With Assignment", func(t *testing.T) {
+		mockTaskRepo.On("GetByID", mock.Anything, int64(12)).Return(&domain.Task{ID: 12, UserID: 1, WorkspaceID: &wsID, Title: "Rilis"}, nil).Once()
+		mockWorkspaceRepo.On("GetMember", mock.Anything, wsID, int64(1)).Return(member(wsID, 1, domain.RoleMember), nil).Once()
+		mockWorkspaceRepo.On("GetMember", mock.Anything, wsID, int64(3)).Return(member(wsID, 3, domain.RoleViewer), nil).Once()
+		mockTaskRepo.On("AddAssignee", mock.Anything, int64(12), int64(3), int64(1), mock.Anything, mock.MatchedBy(func(e domain.Event) bool {
+			return e.Type == domain.EventTaskAssigned && e.TaskID == 12 && e.ActorID == 1 && len(e.Recipients) == 1 && e.Recipients[0] == 3
+		})).Return(true, nil).Once()
+
+		task, err := u.Assign(context.Background(), 12, 1, 3)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{3}, task.AssigneeIDs)
+		mockTaskRepo.AssertExpectations(t)
+	})
+
+	t.Run("Assignee Can Unassign Themselves", func(t *testing.T) {
+		mockTaskRepo.On("GetByID", mock.Anything, int64(13)).Return(&domain.Task{ID: 13, UserID: 1, WorkspaceID: &wsID, AssigneeIDs: []int64{3}}, nil).Once()
+		mockWorkspaceRepo.On("GetMember", mock.Anything, wsID, int64(3)).Return(member(wsID, 3, domain.RoleViewer), nil).Once()
+		mockTaskRepo.On("RemoveAssignee", mock.Anything, int64(13), int64(3), mock.MatchedBy(func(e domain.Event) bool {
+			return e.Type == domain.EventTaskUnassigned && e.TaskID == 13
+		})).Return(true, nil).Once()
+
+		task, err := u.Unassign(context.Background(), 13, 3, 3)
+
+		assert.NoError(t, err)
+		assert.Empty(t, task.AssigneeIDs)
+	})
+}
+
+func TestDueBuckets(t *testing.T) {
+	mockTaskRepo := new(mocks.TaskRepository)
+	mockUserRepo := new(mocks.UserRepository)
+	u := usecase.NewTaskUsecase(mockTaskRepo, new(mocks.StatusRepository), new(mocks.ProjectRepository), new(mocks.WorkspaceRepository), mockUserRepo, new(mocks.TaskReminderRepository), 2*time.Second)
+
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	assert.NoError(t, err)
+	y, m, d := time.Now().In(loc).Date()
+	date := func(offset int) *time.Time {
+		v := time.Date(y, m, d+offset, 0, 0, 0, 0, time.UTC)
+		return &v
+	}
+	at := func(v time.Time) *time.Time { return &v }
+
+	tasks := []domain.Task{
+		{ID: 1, DueAt: date(-1), DueAllDay: true},        // kemarin waktu Jakarta
+		{ID: 2, DueAt: date(0), DueAllDay: true},         // hari ini sampai tengah malam WIB
+		{ID: 3, DueAt: at(time.Now().Add(-time.Minute))}, // baru lewat
+		{ID: 4, DueAt: date(3), DueAllDay: true},         // 3 hari lagi
+		{ID: 5, DueAt: at(time.Now().AddDate(0, 0, 30))}, // di luar jangkauan upcoming
+	}
+
+	mockUserRepo.On("GetByID", mock.Anything, int64(1)).Return(&domain.User{ID: 1, Timezone: "Asia/Jakarta"}, nil)
+	overdueWindow := func(f domain.TaskFilter) bool {
+		return f.UserID == 1 && f.Incomplete && f.SortBy == domain.TaskSortDueAt && f.SortDesc && f.DueFrom == nil && f.DueTo != nil
+	}
+	upcomingWindow := func(f domain.TaskFilter) bool {
+		return f.UserID == 1 && f.Incomplete && f.SortBy == domain.TaskSortDueAt && !f.SortDesc && f.DueFrom != nil && f.DueTo != nil
+	}
+	ids := func(ts []domain.Task) []int64 {
+		out := []int64{}
+		for _, t := range ts {
+			out = append(out, t.ID)
+		}
+		return out
+	}
+
+	t.Run("Success - Buckets By User Time Zone", func(t *testing.T) {
+		// Task 3 berada di perbatasan kedua jendela dan dikembalikan dua kali
+		mockTaskRepo.On("Fetch", mock.Anything, mock.MatchedBy(overdueWindow)).
+			Return(&domain.TaskPage{Tasks: []domain.Task{tasks[2], tasks[0]}}, nil).Once()
+		mockTaskRepo.On("Fetch", mock.Anything, mock.MatchedBy(upcomingWindow)).
+			Return(&domain.TaskPage{Tasks: []domain.Task{tasks[2], tasks[1], tasks[3], tasks[4]}}, nil).Once()
+
+		buckets, err := u.Due(context.Background(), 1, 7)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Asia/Jakarta", buckets.Timezone)
+		assert.Equal(t, []int64{1, 3}, ids(buckets.Overdue))
+		assert.Equal(t, []int64{2}, ids(buckets.Today))
+		assert.Equal(t, []int64{4}, ids(buckets.Upcoming))
+		assert.True(t, buckets.Overdue[0].IsOverdue)
+		assert.False(t, buckets.Today[0].IsOverdue)
+		assert.False(t, buckets.Truncated)
+	})
+
+	t.Run("Success - Old Overdue Tasks Do Not Hide Today", func(t *testing.T) {
+		// Overdue selalu punya halaman berikutnya sampai batas; today tetap diambil lewat jendelanya sendiri
+		mockTaskRepo.On("Fetch", mock.Anything, mock.MatchedBy(overdueWindow)).
+			Return(&domain.TaskPage{Tasks: []domain.Task{tasks[0]}, NextCursor: "next"}, nil).Times(10)
+		mockTaskRepo.On("Fetch", mock.Anything, mock.MatchedBy(upcomingWindow)).
+			Return(&domain.TaskPage{Tasks: []domain.Task{tasks[1]}}, nil).Once()
+
+		buckets, err := u.Due(context.Background(), 1, 7)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{2}, ids(buckets.Today))
+		assert.Len(t, buckets.Overdue, 10)
+		assert.True(t, buckets.Truncated)
+		mockTaskRepo.AssertExpectations(t)
+	})
+}
+
+func TestTaskReminders(t *testing.T) {
+	mockTaskRepo := new(mocks.TaskRepository)
+	mockUserRepo := new(mocks.UserRepository)
+	mockReminderRepo := new(mocks.TaskReminderRepository)
+	u := usecase.NewTaskUsecase(mockTaskRepo, new(mocks.StatusRepository), new(mocks.ProjectRepository), new(mocks.WorkspaceRepository), mockUserRepo, mockReminderRepo, 2*time.Second)
+
+	mockUserRepo.On("GetByID", mock.Anything, int64(1)).Return(&domain.User{ID: 1, Timezone: "Asia/Jakarta"}, nil)
+	due := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Relative To All-Day Due Uses Owner Time Zone", func(t *testing.T) {
+		mockTaskRepo.On("GetByID", mock.Anything, int64(10)).Return(&domain.Task{ID: 10, UserID: 1, DueAt: &due, DueAllDay: true}, nil).Once()
+		mockReminderRepo.On("ListByTask", mock.Anything, int64(10)).Return([]domain.TaskReminder{}, nil).Once()
+		mockReminderRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.TaskReminder")).Return(nil).Once()
+
+		offset := 60
+		reminder, err := u.AddReminder(context.Background(), 10, 1, domain.TaskReminderInput{OffsetMinutes: &offset})
+
+		assert.NoError(t, err)
+		assert.True(t, reminder.Relative())
+		// satu jam sebelum 10 Maret 00:00 WIB
+		assert.Equal(t, time.Date(2026, 3, 9, 16, 0, 0, 0, time.UTC), reminder.RemindAt.UTC())
+	})
+
+	t.Run("Failed - Absolute And Relative Together", func(t *testing.T) {
+		mockTaskRepo.On("GetByID", mock.Anything, int64(11)).Return(&domain.Task{ID: 11, UserID: 1}, nil).Once()
+		mockReminderRepo.On("ListByTask", mock.Anything, int64(11)).Return([]domain.TaskReminder{}, nil).Once()
+
+		offset := 15
+		_, err := u.AddReminder(context.Background(), 11, 1, domain.TaskReminderInput{RemindAt: &due, OffsetMinutes: &offset})
+
+		assert.ErrorIs(t, err, domain.ErrBadParamInput)
+		mockReminderRepo.AssertNotCalled(t, "Create", mock.Anything, mock.MatchedBy(func(r *domain.TaskReminder) bool { return r.TaskID == 11 }))
+	})
+
+	t.Run("Failed - Limit Reached", func(t *testing.T) {
+		mockTaskRepo.On("GetByID", mock.Anything, int64(12)).Return(&domain.Task{ID: 12, UserID: 1}, nil).Once()
+		mockReminderRepo.On("ListByTask", mock.Anything, int64(12)).Return(make([]domain.TaskReminder, domain.MaxTaskReminders), nil).Once()
+
+		_, err := u.AddReminder(context.Background(), 12, 1, domain.TaskReminderInput{RemindAt: &due})
+
+		assert.ErrorIs(t, err, domain.ErrConflict)
+	})
+
+	t.Run("Changing Due Date Recomputes Relative Reminders", func(t *testing.T) {
+		existing := &domain.Task{ID: 13, UserID: 1, Title: "Lama", Priority: "low"}
+		newDue := time.Date(2026, 4, 1, 9, 30, 0, 0, time.UTC)
+
+		var patch domain.TaskPatch
+		_ = json.Unmarshal([]byte(`{"due_at":"2026-04-01T09:30:00Z"}`), &patch)
+
+		mockTaskRepo.On("GetByID", mock.Anything, int64(13)).Return(existing, nil).Once()
+		// Tenggat & reminder relatif disimpan dalam satu transaksi
+		mockTaskRepo.On("UpdateWith", mock.Anything, existing, mock.Anything, mock.MatchedBy(func(fx domain.TaskEffects) bool {
+			return fx.RecomputeReminders && fx.ReminderBase != nil && fx.ReminderBase.Equal(newDue)
+		})).Return(nil).Once()
+
+		_, err := u.Patch(context.Background(), 13, 1, patch)
+
+		assert.NoError(t, err)
+		mockTaskRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failed - Due Date Is Not Saved When Reminders Cannot Be Recomputed", func(t *testing.T) {
+		existing := &domain.Task{ID: 14, UserID: 1, Title: "Lama", Priority: "low"}
+
+		var patch domain.TaskPatch
+		_ = json.Unmarshal([]byte(`{"due_at":"2026-04-01T09:30:00Z"}`), &patch)
+
+		mockTaskRepo.On("GetByID", mock.Anything, int64(14)).Return(existing, nil).Once()
+		mockTaskRepo.On("UpdateWith", mock.Anything, existing, mock.Anything, mock.Anything).Return(errors.New("recompute failed")).Once()
+
+		_, err := u.Patch(context.Background(), 14, 1, patch)
+
+		assert.EqualError(t, err, "recompute failed")
+		mockTaskRepo.AssertNotCalled(t, "Update", mock.Anything, existing, mock.Anything)
+	})
+}
@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+	"simple-task-manager/pkg/util"
+)
+
+const minPasswordLength = 8
+
+// isValidEmail menerima alamat polos (tanpa display name) yang lolos parser RFC 5322
+func isValidEmail(email string) bool {
+	addr, err := mail.ParseAddress(email)
+	return err == nil && addr.Address == email && strings.Contains(email, "@")
+}
+
+// normalizeEmail: alamat email dibandingkan tanpa membedakan huruf besar/kecil, jadi disimpan
+// & dicari dalam huruf kecil
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// checkPasswordStrength mengembalikan pesan error, atau string kosong jika password bisa dipakai
+func checkPasswordStrength(password string) string {
+	if len(password) < minPasswordLength {
+		return fmt.Sprintf("must be at least %d characters", minPasswordLength)
+	}
+	if len(password) > 72 {
+		// bcrypt mengabaikan byte setelah 72
+		return "must be at most 72 characters"
+	}
+	return ""
+}
+
+// issueUserToken membatalkan token lama dengan purpose yang sama lalu membuat token baru.
+// Yang dikembalikan adalah token mentah untuk dikirim lewat email.
+func (u *UserUsecase) issueUserToken(ctx context.Context, userID int64, purpose string, ttl time.Duration) (string, error) {
+	now := time.Now()
+	if err := u.userTokenRepo.InvalidateAll(ctx, userID, purpose, now); err != nil {
+		return "", err
+	}
+
+	raw, hash, err := util.GenerateOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+
+	token := &domain.UserToken{
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: hash,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}
+	if err := u.userTokenRepo.Create(ctx, token); err != nil {
+		return "", err
+	}
+	return raw, nil
+}
+
+// consumeUserToken memvalidasi token lalu menandainya terpakai, sehingga hanya bisa dipakai sekali
+func (u *UserUsecase) consumeUserToken(ctx context.Context, purpose string, raw string) (*domain.UserToken, error) {
+	invalid := domain.NewValidationError("token", "is invalid or has expired")
+
+	token, err := u.userTokenRepo.GetByHash(ctx, purpose, util.HashToken(strings.TrimSpace(raw)))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if token == nil || token.UsedAt != nil || !now.Before(token.ExpiresAt) {
+		return nil, invalid
+	}
+
+	fresh, err := u.userTokenRepo.MarkUsed(ctx, token.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !fresh {
+		return nil, invalid
+	}
+	return token, nil
+}
+
+// appLink membuat URL frontend dengan token sebagai query string
+func (u *UserUsecase) appLink(path string, token string) string {
+	return strings.TrimRight(u.cfg.AppURL, "/") + path + "?token=" + url.QueryEscape(token)
+}
+
+func (u *UserUsecase) sendVerification(ctx context.Context, user *domain.User) error {
+	raw, err := u.issueUserToken(ctx, user.ID, domain.UserTokenVerifyEmail, u.cfg.VerifyEmailTTL)
+	if err != nil {
+		return err
+	}
+
+	return u.mailer.Send(ctx, domain.Mail{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hi %s,\n\nPlease confirm your email address by opening the link below:\n\n%s\n\nThe link expires in %s.\n",
+			user.Name, u.appLink("/verify-email", raw), u.cfg.VerifyEmailTTL),
+	})
+}
+
+// VerifyEmail menandai email user terverifikasi menggunakan token dari email
+func (u *UserUsecase) VerifyEmail(c context.Context, rawToken string) error {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	token, err := u.consumeUserToken(ctx, domain.UserTokenVerifyEmail, rawToken)
+	if err != nil {
+		return err
+	}
+
+	return u.userRepo.MarkEmailVerified(ctx, token.UserID, time.Now())
+}
+
+// ResendVerification mengirim ulang link verifikasi. Selalu sukses untuk email
+// yang tidak terdaftar atau sudah terverifikasi, supaya tidak membocorkan data akun.
+func (u *UserUsecase) ResendVerification(c context.Context, email string) error {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	user, err := u.userRepo.GetByEmail(ctx, normalizeEmail(email))
+	if err != nil {
+		return err
+	}
+	if user == nil || user.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	return u.sendVerification(ctx, user)
+}
+
+// RequestPasswordReset mengirim link reset password. Selalu sukses untuk email
+// yang tidak terdaftar, supaya endpoint ini tidak bisa dipakai menebak akun.
+func (u *UserUsecase) RequestPasswordReset(c context.Context, email string) error {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	user, err := u.userRepo.GetByEmail(ctx, normalizeEmail(email))
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return nil
+	}
+
+	raw, err := u.issueUserToken(ctx, user.ID, domain.UserTokenResetPassword, u.cfg.ResetPasswordTTL)
+	if err != nil {
+		return err
+	}
+
+	return u.mailer.Send(ctx, domain.Mail{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\nSomeone requested a password reset for your account. If it was you, open the link below:\n\n%s\n\nThe link expires in %s. If you did not request this, you can ignore this email.\n",
+			user.Name, u.appLink("/reset-password", raw), u.cfg.ResetPasswordTTL),
+	})
+}
+
+// ResetPassword mengganti password memakai token dari email lalu mencabut semua session user
+func (u *UserUsecase) ResetPassword(c context.Context, rawToken string, newPassword string) error {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	newPassword = strings.TrimSpace(newPassword)
+	if msg := checkPasswordStrength(newPassword); msg != "" {
+		return domain.NewValidationError("password", "%s", msg)
+	}
+
+	token, err := u.consumeUserToken(ctx, domain.UserTokenResetPassword, rawToken)
+	if err != nil {
+		return err
+	}
+
+	hashed, err := util.HashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if err := u.userRepo.UpdatePassword(ctx, token.UserID, hashed, now); err != nil {
+		return err
+	}
+
+	// Link reset membuktikan kepemilikan inbox
+	if err := u.userRepo.MarkEmailVerified(ctx, token.UserID, now); err != nil {
+		return err
+	}
+
+	if err := u.sessionRepo.RevokeUserSessions(ctx, token.UserID, now); err != nil {
+		log.Printf("failed to revoke sessions after password reset for user %d: %v", token.UserID, err)
+	}
+	return nil
+}
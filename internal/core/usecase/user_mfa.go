@@ -0,0 +1,252 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"strings"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+	"simple-task-manager/pkg/util"
+)
+
+const (
+	recoveryCodeCount = 10
+	// Toleransi satu time-step (±30 detik) untuk jam perangkat yang sedikit meleset
+	totpSkew = 1
+)
+
+// MFASetup dikembalikan saat enrollment dimulai; URI ditampilkan sebagai QR code di client
+type MFASetup struct {
+	Secret string `json:"secret"`
+	URI    string `json:"otpauth_uri"`
+}
+
+// RecoveryCodes hanya ditampilkan sekali; yang disimpan di database hanya hash-nya
+type RecoveryCodes struct {
+	Codes []string `json:"recovery_codes"`
+}
+
+// mfaChallenge menerbitkan challenge sekali pakai sebagai pengganti access token
+func (u *UserUsecase) mfaChallenge(ctx context.Context, user *domain.User) (*LoginResponse, error) {
+	raw, err := u.issueUserToken(ctx, user.ID, domain.UserTokenMFAChallenge, u.cfg.MFAChallengeTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LoginResponse{
+		MFARequired: true,
+		MFAToken:    raw,
+	}, nil
+}
+
+// VerifyMFA menyelesaikan login dua langkah dengan kode TOTP atau recovery code.
+// Challenge hanya bisa dicoba sekali supaya kode 6 digit tidak bisa di-brute force.
+func (u *UserUsecase) VerifyMFA(c context.Context, mfaToken string, code string) (*LoginResponse, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	token, err := u.consumeUserToken(ctx, domain.UserTokenMFAChallenge, mfaToken)
+	if err != nil {
+		if errors.Is(err, domain.ErrBadParamInput) {
+			return nil, domain.Unauthorized("login challenge is invalid or has expired, please sign in again")
+		}
+		return nil, err
+	}
+
+	user, err := u.userRepo.GetByID(ctx, token.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domain.Unauthorized("login challenge is invalid or has expired, please sign in again")
+	}
+
+	// 2FA bisa saja dimatikan setelah challenge diterbitkan; password sudah terverifikasi
+	if user.MFAEnabledAt != nil {
+		ok, err := u.checkSecondFactor(ctx, user, code)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, domain.Unauthorized("invalid authentication code, please sign in again")
+		}
+	}
+
+	return u.completeLogin(ctx, user)
+}
+
+// checkSecondFactor menerima kode TOTP yang belum pernah dipakai, atau recovery code yang masih berlaku
+func (u *UserUsecase) checkSecondFactor(ctx context.Context, user *domain.User, code string) (bool, error) {
+	code = strings.TrimSpace(code)
+	if code == "" || user.TOTPSecret == "" {
+		return false, nil
+	}
+
+	if step, ok := util.ValidateTOTP(user.TOTPSecret, code, time.Now(), totpSkew); ok {
+		return u.userRepo.ClaimTOTPStep(ctx, user.ID, step)
+	}
+
+	return u.userRepo.UseRecoveryCode(ctx, user.ID, util.HashToken(normalizeRecoveryCode(code)), time.Now())
+}
+
+// SetupMFA memulai enrollment: secret baru disimpan tetapi 2FA belum aktif sampai dikonfirmasi
+func (u *UserUsecase) SetupMFA(c context.Context, userID int64) (*MFASetup, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	user, err := u.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.MFAEnabledAt != nil {
+		return nil, domain.Conflict("two-factor authentication is already enabled")
+	}
+
+	secret, err := util.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	if err := u.userRepo.SetTOTPSecret(ctx, user.ID, secret, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return &MFASetup{
+		Secret: secret,
+		URI:    util.TOTPURI(u.cfg.MFAIssuer, user.Email, secret),
+	}, nil
+}
+
+// ConfirmMFA mengaktifkan 2FA setelah user membuktikan authenticator-nya menghasilkan kode yang benar
+func (u *UserUsecase) ConfirmMFA(c context.Context, userID int64, code string) (*RecoveryCodes, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	user, err := u.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.MFAEnabledAt != nil {
+		return nil, domain.Conflict("two-factor authentication is already enabled")
+	}
+	if user.TOTPSecret == "" {
+		return nil, domain.Conflict("two-factor setup has not been started")
+	}
+
+	step, ok := util.ValidateTOTP(user.TOTPSecret, code, time.Now(), totpSkew)
+	if ok {
+		if ok, err = u.userRepo.ClaimTOTPStep(ctx, user.ID, step); err != nil {
+			return nil, err
+		}
+	}
+	if !ok {
+		return nil, domain.NewValidationError("code", "is invalid")
+	}
+
+	codes, err := u.replaceRecoveryCodes(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.userRepo.EnableMFA(ctx, user.ID, time.Now()); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// DisableMFA mematikan 2FA; butuh password dan kode kedua supaya session yang dicuri tidak cukup
+func (u *UserUsecase) DisableMFA(c context.Context, userID int64, password string, code string) error {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	user, err := u.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.MFAEnabledAt == nil {
+		return domain.Conflict("two-factor authentication is not enabled")
+	}
+
+	if err := util.CheckPassword(strings.TrimSpace(password), user.Password); err != nil {
+		return domain.NewValidationError("password", "is incorrect")
+	}
+	ok, err := u.checkSecondFactor(ctx, user, code)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return domain.NewValidationError("code", "is invalid")
+	}
+
+	return u.userRepo.DisableMFA(ctx, user.ID, time.Now())
+}
+
+// RegenerateRecoveryCodes mengganti semua recovery code; kode lama langsung tidak berlaku
+func (u *UserUsecase) RegenerateRecoveryCodes(c context.Context, userID int64, code string) (*RecoveryCodes, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	user, err := u.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.MFAEnabledAt == nil {
+		return nil, domain.Conflict("two-factor authentication is not enabled")
+	}
+
+	ok, err := u.checkSecondFactor(ctx, user, code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, domain.NewValidationError("code", "is invalid")
+	}
+
+	return u.replaceRecoveryCodes(ctx, user.ID)
+}
+
+func (u *UserUsecase) getUser(ctx context.Context, userID int64) (*domain.User, error) {
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domain.NotFound("user %d not found", userID)
+	}
+	return user, nil
+}
+
+func (u *UserUsecase) replaceRecoveryCodes(ctx context.Context, userID int64) (*RecoveryCodes, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+		hashes = append(hashes, util.HashToken(normalizeRecoveryCode(code)))
+	}
+
+	if err := u.userRepo.ReplaceRecoveryCodes(ctx, userID, hashes, time.Now()); err != nil {
+		return nil, err
+	}
+	return &RecoveryCodes{Codes: codes}, nil
+}
+
+// generateRecoveryCode membuat kode 10 karakter base32 (50 bit) dengan format XXXXX-XXXXX
+func generateRecoveryCode() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	s := base32.StdEncoding.EncodeToString(b)[:10]
+	return s[:5] + "-" + s[5:], nil
+}
+
+// normalizeRecoveryCode membuat input user tidak peka huruf besar/kecil, spasi, dan tanda hubung
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToUpper(code)
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+)
+
+// validTimezone bernilai true untuk nama zona IANA yang dikenal sistem
+func validTimezone(name string) bool {
+	if name == "" || name == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
+
+// GetProfile mengembalikan data user yang sedang login
+func (u *UserUsecase) GetProfile(c context.Context, userID int64) (*domain.User, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	return u.getUser(ctx, userID)
+}
+
+// UpdateProfile mengubah nama dan/atau zona waktu user
+func (u *UserUsecase) UpdateProfile(c context.Context, userID int64, patch domain.UserPatch) (*domain.User, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	user, err := u.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	verr := &domain.ValidationError{}
+	if patch.Name.Set {
+		name := ""
+		if patch.Name.Value != nil {
+			name = strings.TrimSpace(*patch.Name.Value)
+		}
+		if name == "" {
+			verr.Add("name", "is required")
+		}
+		user.Name = name
+	}
+	if patch.Timezone.Set {
+		tz := ""
+		if patch.Timezone.Value != nil {
+			tz = strings.TrimSpace(*patch.Timezone.Value)
+		}
+		if !validTimezone(tz) {
+			verr.Add("timezone", "unknown time zone %q", tz)
+		}
+		user.Timezone = tz
+	}
+	if err := verr.OrNil(); err != nil {
+		return nil, err
+	}
+
+	user.UpdatedAt = time.Now()
+	if err := u.userRepo.UpdateProfile(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
@@ -2,8 +2,7 @@ package usecase
 
 import (
     "context"
-    "errors"
-    "fmt"
+    "log"
     "strings"
     "time"
 
@@ -11,17 +10,45 @@ import (
     "simple-task-manager/pkg/util"
 )
 
+// AuthConfig mengatur token dan aturan akun yang dipakai UserUsecase
+type AuthConfig struct {
+    Secret     string
+    AccessTTL  time.Duration // access token JWT, sebaiknya pendek (menit)
+    RefreshTTL time.Duration // umur maksimal satu session / token family
+
+    AppURL               string        // base URL frontend untuk link di email
+    VerifyEmailTTL       time.Duration // masa berlaku link verifikasi email
+    ResetPasswordTTL     time.Duration // masa berlaku link reset password
+    RequireVerifiedEmail bool          // tolak login jika email belum diverifikasi
+
+    MFAIssuer       string        // nama aplikasi yang tampil di authenticator
+    MFAChallengeTTL time.Duration // masa berlaku challenge token antara langkah password dan kode 2FA
+}
+
 type UserUsecase struct {
     userRepo       domain.UserRepository
+    sessionRepo    domain.SessionRepository
+    userTokenRepo  domain.UserTokenRepository
+    mailer         domain.Mailer
     contextTimeout time.Duration
-    jwtSecret      string // Secret key disuntikkan saat inisialisasi
+    cfg            AuthConfig // Secret key disuntikkan saat inisialisasi
 }
 
-func NewUserUsecase(userRepo domain.UserRepository, timeout time.Duration, secret string) *UserUsecase {
+func NewUserUsecase(
+    userRepo domain.UserRepository,
+    sessionRepo domain.SessionRepository,
+    userTokenRepo domain.UserTokenRepository,
+    mailer domain.Mailer,
+    timeout time.Duration,
+    cfg AuthConfig,
+) *UserUsecase {
     return &UserUsecase{
         userRepo:       userRepo,
+        sessionRepo:    sessionRepo,
+        userTokenRepo:  userTokenRepo,
+        mailer:         mailer,
         contextTimeout: timeout,
-        jwtSecret:      secret,
+        cfg:            cfg,
     }
 }
 
@@ -32,13 +59,33 @@ func (u *UserUsecase) Register(c context.Context, user *domain.User) error {
 
     // 1. SANITISASI: Hapus spasi di awal/akhir nama, email, dan password
     user.Name = strings.TrimSpace(user.Name)
-    user.Email = strings.TrimSpace(user.Email)
+    user.Email = normalizeEmail(user.Email)
     cleanPassword := strings.TrimSpace(user.Password)
 
+    verr := &domain.ValidationError{}
+    if user.Name == "" {
+        verr.Add("name", "is required")
+    }
+    if !isValidEmail(user.Email) {
+        verr.Add("email", "is not a valid email address")
+    }
+    if msg := checkPasswordStrength(cleanPassword); msg != "" {
+        verr.Add("password", "%s", msg)
+    }
+    user.Timezone = strings.TrimSpace(user.Timezone)
+    if user.Timezone == "" {
+        user.Timezone = "UTC"
+    } else if !validTimezone(user.Timezone) {
+        verr.Add("timezone", "unknown time zone %q", user.Timezone)
+    }
+    if err := verr.OrNil(); err != nil {
+        return err
+    }
+
     // 2. Cek apakah email sudah ada
     existUser, err := u.userRepo.GetByEmail(ctx, user.Email)
     if err == nil && existUser != nil {
-        return errors.New("email already exists")
+        return domain.Conflict("email already exists")
     }
 
     // 3. Hash Password yang sudah dibersihkan
@@ -53,12 +100,34 @@ func (u *UserUsecase) Register(c context.Context, user *domain.User) error {
     user.UpdatedAt = time.Now()
 
     // 5. Simpan ke DB
-    return u.userRepo.Create(ctx, user)
+    if err := u.userRepo.Create(ctx, user); err != nil {
+        return err
+    }
+
+    // 6. Kirim link verifikasi; kegagalan kirim email tidak membatalkan registrasi
+    if err := u.sendVerification(ctx, user); err != nil {
+        log.Printf("failed to send verification email to user %d: %v", user.ID, err)
+    }
+    return nil
 }
 
+// LoginResponse berisi token session, atau hanya MFAToken jika user masih harus
+// menyelesaikan langkah kedua lewat VerifyMFA
 type LoginResponse struct {
-    AccessToken string      `json:"access_token"`
-    User        domain.User `json:"user"`
+    AccessToken  string       `json:"access_token,omitempty"`
+    RefreshToken string       `json:"refresh_token,omitempty"`
+    ExpiresIn    int64        `json:"expires_in,omitempty"` // detik sampai access token kedaluwarsa
+    User         *domain.User `json:"user,omitempty"`
+
+    MFARequired bool   `json:"mfa_required,omitempty"`
+    MFAToken    string `json:"mfa_token,omitempty"` // challenge sekali pakai untuk VerifyMFA
+}
+
+// TokenPair adalah hasil rotasi refresh token
+type TokenPair struct {
+    AccessToken  string `json:"access_token"`
+    RefreshToken string `json:"refresh_token"`
+    ExpiresIn    int64  `json:"expires_in"`
 }
 
 // Login user
@@ -67,7 +136,7 @@ func (u *UserUsecase) Login(c context.Context, email string, password string) (*
     defer cancel()
 
     // 1. SANITISASI INPUT LOGIN
-    cleanEmail := strings.TrimSpace(email)
+    cleanEmail := normalizeEmail(email)
     cleanPassword := strings.TrimSpace(password)
 
     // 2. Cari user by email
@@ -76,35 +145,157 @@ func (u *UserUsecase) Login(c context.Context, email string, password string) (*
         return nil, err
     }
     if user == nil {
-        return nil, errors.New("invalid email or password")
+        return nil, domain.Unauthorized("invalid email or password")
     }
 
-    // --- DEBUGGING AREA ---
-    fmt.Println("------------------------------------------------")
-    fmt.Println("DEBUG LOGIN:")
-    fmt.Printf("1. Email Input: %s\n", cleanEmail)
-    fmt.Printf("2. Email di DB: %s\n", user.Email)
-    fmt.Printf("3. Password Input: %s\n", cleanPassword)
-    fmt.Printf("4. Password Hash di DB: %s\n", user.Password)
-    fmt.Println("------------------------------------------------")
-    // ----------------------
-
     // 3. Cek Password (gunakan cleanPassword)
     err = util.CheckPassword(cleanPassword, user.Password)
     if err != nil {
-        fmt.Printf(">>> BCRYPT ERROR: %v\n", err)
-        return nil, errors.New("invalid email or password")
+        return nil, domain.Unauthorized("invalid email or password")
+    }
+
+    if u.cfg.RequireVerifiedEmail && user.EmailVerifiedAt == nil {
+        return nil, domain.Forbidden("email address has not been verified")
+    }
+
+    // 4. Jika 2FA aktif, session baru dibuat setelah kode diverifikasi
+    if user.MFAEnabledAt != nil {
+        return u.mfaChallenge(ctx, user)
     }
 
-    // 4. Generate JWT Token (valid 24 jam)
-    accessToken, err := util.CreateAccessToken(user.ID, u.jwtSecret, 24*time.Hour)
+    // 5. Buat session baru (token family) + access & refresh token
+    return u.completeLogin(ctx, user)
+}
+
+// completeLogin membuat session untuk user yang sudah lolos semua faktor autentikasi
+func (u *UserUsecase) completeLogin(ctx context.Context, user *domain.User) (*LoginResponse, error) {
+    pair, err := u.startSession(ctx, user.ID)
     if err != nil {
         return nil, err
     }
 
-    // 5. Return response
     return &LoginResponse{
-        AccessToken: accessToken,
-        User:        *user,
+        AccessToken:  pair.AccessToken,
+        RefreshToken: pair.RefreshToken,
+        ExpiresIn:    pair.ExpiresIn,
+        User:         user,
     }, nil
 }
+
+// startSession membuat session baru untuk user dan menerbitkan pasangan token pertamanya
+func (u *UserUsecase) startSession(ctx context.Context, userID int64) (*TokenPair, error) {
+    now := time.Now()
+    session := &domain.Session{
+        UserID:    userID,
+        CreatedAt: now,
+        ExpiresAt: now.Add(u.cfg.RefreshTTL),
+    }
+    if err := u.sessionRepo.CreateSession(ctx, session); err != nil {
+        return nil, err
+    }
+
+    return u.issueTokens(ctx, session)
+}
+
+// issueTokens menerbitkan access token dan refresh token baru di dalam session yang sama
+func (u *UserUsecase) issueTokens(ctx context.Context, session *domain.Session) (*TokenPair, error) {
+    accessToken, err := util.CreateAccessToken(session.UserID, session.ID, u.cfg.Secret, u.cfg.AccessTTL)
+    if err != nil {
+        return nil, err
+    }
+
+    rawRefresh, hash, err := util.GenerateOpaqueToken()
+    if err != nil {
+        return nil, err
+    }
+    refresh := &domain.RefreshToken{
+        SessionID: session.ID,
+        TokenHash: hash,
+        ExpiresAt: session.ExpiresAt,
+        CreatedAt: time.Now(),
+    }
+    if err := u.sessionRepo.CreateRefreshToken(ctx, refresh); err != nil {
+        return nil, err
+    }
+
+    return &TokenPair{
+        AccessToken:  accessToken,
+        RefreshToken: rawRefresh,
+        ExpiresIn:    int64(u.cfg.AccessTTL.Seconds()),
+    }, nil
+}
+
+// Refresh merotasi refresh token: token lama ditandai terpakai dan pasangan token baru diterbitkan.
+// Jika token yang sudah pernah dirotasi dipakai lagi, seluruh family (session) dicabut.
+func (u *UserUsecase) Refresh(c context.Context, rawToken string) (*TokenPair, error) {
+    ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+    defer cancel()
+
+    invalid := domain.Unauthorized("invalid or expired refresh token")
+
+    token, err := u.sessionRepo.GetRefreshTokenByHash(ctx, util.HashToken(strings.TrimSpace(rawToken)))
+    if err != nil {
+        return nil, err
+    }
+    if token == nil {
+        return nil, invalid
+    }
+
+    session, err := u.sessionRepo.GetSession(ctx, token.SessionID)
+    if err != nil {
+        return nil, err
+    }
+    now := time.Now()
+    if session == nil || !session.Active(now) || !now.Before(token.ExpiresAt) {
+        return nil, invalid
+    }
+
+    if token.UsedAt != nil {
+        return nil, u.revokeOnReuse(ctx, session.ID)
+    }
+
+    fresh, err := u.sessionRepo.MarkRefreshTokenUsed(ctx, token.ID, now)
+    if err != nil {
+        return nil, err
+    }
+    if !fresh {
+        // Request lain merotasi token ini lebih dulu
+        return nil, u.revokeOnReuse(ctx, session.ID)
+    }
+
+    return u.issueTokens(ctx, session)
+}
+
+func (u *UserUsecase) revokeOnReuse(ctx context.Context, sessionID int64) error {
+    log.Printf("[AUTH] refresh token reuse detected, revoking session %d", sessionID)
+    if err := u.sessionRepo.RevokeSession(ctx, sessionID, time.Now()); err != nil {
+        return err
+    }
+    return domain.Unauthorized("refresh token reuse detected, session revoked")
+}
+
+// Logout mencabut session saat ini, atau semua session user jika all bernilai true
+func (u *UserUsecase) Logout(c context.Context, userID int64, sessionID int64, all bool) error {
+    ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+    defer cancel()
+
+    if all {
+        return u.sessionRepo.RevokeUserSessions(ctx, userID, time.Now())
+    }
+    return u.sessionRepo.RevokeSession(ctx, sessionID, time.Now())
+}
+
+// ValidateSession dipakai AuthMiddleware untuk menolak access token dari session yang sudah dicabut
+func (u *UserUsecase) ValidateSession(c context.Context, userID int64, sessionID int64) error {
+    ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+    defer cancel()
+
+    session, err := u.sessionRepo.GetSession(ctx, sessionID)
+    if err != nil {
+        return err
+    }
+    if session == nil || session.UserID != userID || !session.Active(time.Now()) {
+        return domain.Unauthorized("session has been revoked or expired")
+    }
+    return nil
+}
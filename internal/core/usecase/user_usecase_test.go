@@ -2,13 +2,13 @@ package usecase_test
 
 import (
 	"context"
-	// "errors" <-- HAPUS BARIS INI
 	"testing"
 	"time"
 
 	"simple-task-manager/internal/core/domain"
 	"simple-task-manager/internal/core/usecase"
 	"simple-task-manager/internal/core/usecase/mocks"
+	"simple-task-manager/pkg/util"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -23,12 +23,18 @@ func TestRegister(t *testing.T) {
 		Password: "password123",
 	}
 
+	mockTokenRepo := new(mocks.UserTokenRepository)
+	mockMailer := new(mocks.Mailer)
+
 	timeout := 2 * time.Second
-	u := usecase.NewUserUsecase(mockUserRepo, timeout, "secret_key")
+	u := usecase.NewUserUsecase(mockUserRepo, new(mocks.SessionRepository), mockTokenRepo, mockMailer, timeout, usecase.AuthConfig{Secret: "secret_key"})
 
 	t.Run("Success Register", func(t *testing.T) {
 		mockUserRepo.On("GetByEmail", mock.Anything, user.Email).Return(nil, nil).Once()
 		mockUserRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.User")).Return(nil).Once()
+		mockTokenRepo.On("InvalidateAll", mock.Anything, mock.Anything, domain.UserTokenVerifyEmail, mock.Anything).Return(nil).Once()
+		mockTokenRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.UserToken")).Return(nil).Once()
+		mockMailer.On("Send", mock.Anything, mock.MatchedBy(func(m domain.Mail) bool { return m.To == user.Email })).Return(nil).Once()
 
 		err := u.Register(context.Background(), user)
 
@@ -49,4 +55,346 @@ func TestRegister(t *testing.T) {
 		
 		mockUserRepo.AssertNotCalled(t, "Create")
 	})
-}
\ No newline at end of file
+
+	t.Run("Failed - Email Exists With Different Case", func(t *testing.T) {
+		existingUser := &domain.User{ID: 1, Email: "test@example.com"}
+		mockUserRepo.On("GetByEmail", mock.Anything, "test@example.com").Return(existingUser, nil).Once()
+
+		err := u.Register(context.Background(), &domain.User{Name: "X", Email: " Test@Example.COM ", Password: "password123"})
+
+		assert.ErrorIs(t, err, domain.ErrConflict)
+	})
+
+	t.Run("Failed - Invalid Email", func(t *testing.T) {
+		err := u.Register(context.Background(), &domain.User{Name: "X", Email: "not-an-email", Password: "password123"})
+
+		var verr *domain.ValidationError
+		assert.ErrorAs(t, err, &verr)
+		assert.Equal(t, "email", verr.Fields[0].Field)
+	})
+}
+
+func newAuthUsecase() (*usecase.UserUsecase, *mocks.UserRepository, *mocks.SessionRepository) {
+	u, mockUserRepo, mockSessionRepo, _, _ := newAccountUsecase(usecase.AuthConfig{})
+	return u, mockUserRepo, mockSessionRepo
+}
+
+func newAccountUsecase(cfg usecase.AuthConfig) (*usecase.UserUsecase, *mocks.UserRepository, *mocks.SessionRepository, *mocks.UserTokenRepository, *mocks.Mailer) {
+	mockUserRepo := new(mocks.UserRepository)
+	mockSessionRepo := new(mocks.SessionRepository)
+	mockTokenRepo := new(mocks.UserTokenRepository)
+	mockMailer := new(mocks.Mailer)
+
+	cfg.Secret = "secret_key"
+	cfg.AccessTTL = 15 * time.Minute
+	cfg.RefreshTTL = 24 * time.Hour
+	u := usecase.NewUserUsecase(mockUserRepo, mockSessionRepo, mockTokenRepo, mockMailer, 2*time.Second, cfg)
+	return u, mockUserRepo, mockSessionRepo, mockTokenRepo, mockMailer
+}
+
+func TestLogin(t *testing.T) {
+	u, mockUserRepo, mockSessionRepo := newAuthUsecase()
+
+	hashed, _ := util.HashPassword("password123")
+	user := &domain.User{ID: 1, Email: "test@example.com", Password: hashed}
+
+	t.Run("Success Issues Access And Refresh Token", func(t *testing.T) {
+		mockUserRepo.On("GetByEmail", mock.Anything, user.Email).Return(user, nil).Once()
+		mockSessionRepo.On("CreateSession", mock.Anything, mock.AnythingOfType("*domain.Session")).
+			Run(func(args mock.Arguments) { args.Get(1).(*domain.Session).ID = 42 }).
+			Return(nil).Once()
+		mockSessionRepo.On("CreateRefreshToken", mock.Anything, mock.MatchedBy(func(rt *domain.RefreshToken) bool {
+			return rt.SessionID == 42 && len(rt.TokenHash) == 64
+		})).Return(nil).Once()
+
+		res, err := u.Login(context.Background(), user.Email, "password123")
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, res.AccessToken)
+		assert.NotEmpty(t, res.RefreshToken)
+		assert.Equal(t, int64(900), res.ExpiresIn)
+		mockSessionRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failed - Wrong Password", func(t *testing.T) {
+		mockUserRepo.On("GetByEmail", mock.Anything, user.Email).Return(user, nil).Once()
+
+		_, err := u.Login(context.Background(), user.Email, "wrong")
+
+		assert.ErrorIs(t, err, domain.ErrUnauthorized)
+	})
+}
+
+func TestRefresh(t *testing.T) {
+	session := &domain.Session{ID: 7, UserID: 1, ExpiresAt: time.Now().Add(time.Hour)}
+
+	t.Run("Success Rotates Token", func(t *testing.T) {
+		u, _, mockSessionRepo := newAuthUsecase()
+		stored := &domain.RefreshToken{ID: 3, SessionID: 7, ExpiresAt: session.ExpiresAt}
+
+		mockSessionRepo.On("GetRefreshTokenByHash", mock.Anything, util.HashToken("raw-token")).Return(stored, nil).Once()
+		mockSessionRepo.On("GetSession", mock.Anything, int64(7)).Return(session, nil).Once()
+		mockSessionRepo.On("MarkRefreshTokenUsed", mock.Anything, int64(3), mock.Anything).Return(true, nil).Once()
+		mockSessionRepo.On("CreateRefreshToken", mock.Anything, mock.AnythingOfType("*domain.RefreshToken")).Return(nil).Once()
+
+		pair, err := u.Refresh(context.Background(), "raw-token")
+
+		assert.NoError(t, err)
+		assert.NotEqual(t, "raw-token", pair.RefreshToken)
+		mockSessionRepo.AssertExpectations(t)
+	})
+
+	t.Run("Reuse Revokes Whole Family", func(t *testing.T) {
+		u, _, mockSessionRepo := newAuthUsecase()
+		usedAt := time.Now().Add(-time.Minute)
+		stored := &domain.RefreshToken{ID: 3, SessionID: 7, ExpiresAt: session.ExpiresAt, UsedAt: &usedAt}
+
+		mockSessionRepo.On("GetRefreshTokenByHash", mock.Anything, util.HashToken("stolen")).Return(stored, nil).Once()
+		mockSessionRepo.On("GetSession", mock.Anything, int64(7)).Return(session, nil).Once()
+		mockSessionRepo.On("RevokeSession", mock.Anything, int64(7), mock.Anything).Return(nil).Once()
+
+		_, err := u.Refresh(context.Background(), "stolen")
+
+		assert.ErrorIs(t, err, domain.ErrUnauthorized)
+		mockSessionRepo.AssertExpectations(t)
+		mockSessionRepo.AssertNotCalled(t, "CreateRefreshToken", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Revoked Session Is Rejected", func(t *testing.T) {
+		u, _, mockSessionRepo := newAuthUsecase()
+		revokedAt := time.Now()
+		revoked := &domain.Session{ID: 8, UserID: 1, ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt}
+
+		mockSessionRepo.On("GetSession", mock.Anything, int64(8)).Return(revoked, nil).Once()
+
+		err := u.ValidateSession(context.Background(), 1, 8)
+
+		assert.ErrorIs(t, err, domain.ErrUnauthorized)
+	})
+}
+
+func TestLoginRequiresVerifiedEmail(t *testing.T) {
+	u, mockUserRepo, _, _, _ := newAccountUsecase(usecase.AuthConfig{RequireVerifiedEmail: true})
+
+	hashed, _ := util.HashPassword("password123")
+	user := &domain.User{ID: 1, Email: "test@example.com", Password: hashed}
+	mockUserRepo.On("GetByEmail", mock.Anything, user.Email).Return(user, nil).Once()
+
+	_, err := u.Login(context.Background(), user.Email, "password123")
+
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+}
+
+func TestResetPassword(t *testing.T) {
+	t.Run("Success Updates Password And Revokes Sessions", func(t *testing.T) {
+		u, mockUserRepo, mockSessionRepo, mockTokenRepo, _ := newAccountUsecase(usecase.AuthConfig{})
+		token := &domain.UserToken{ID: 9, UserID: 1, Purpose: domain.UserTokenResetPassword, ExpiresAt: time.Now().Add(time.Hour)}
+
+		mockTokenRepo.On("GetByHash", mock.Anything, domain.UserTokenResetPassword, util.HashToken("reset-token")).Return(token, nil).Once()
+		mockTokenRepo.On("MarkUsed", mock.Anything, int64(9), mock.Anything).Return(true, nil).Once()
+		mockUserRepo.On("UpdatePassword", mock.Anything, int64(1), mock.AnythingOfType("string"), mock.Anything).Return(nil).Once()
+		mockUserRepo.On("MarkEmailVerified", mock.Anything, int64(1), mock.Anything).Return(nil).Once()
+		mockSessionRepo.On("RevokeUserSessions", mock.Anything, int64(1), mock.Anything).Return(nil).Once()
+
+		err := u.ResetPassword(context.Background(), "reset-token", "new-password")
+
+		assert.NoError(t, err)
+		mockUserRepo.AssertExpectations(t)
+		mockSessionRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failed - Token Already Used", func(t *testing.T) {
+		u, mockUserRepo, _, mockTokenRepo, _ := newAccountUsecase(usecase.AuthConfig{})
+		usedAt := time.Now()
+		token := &domain.UserToken{ID: 9, UserID: 1, ExpiresAt: time.Now().Add(time.Hour), UsedAt: &usedAt}
+
+		mockTokenRepo.On("GetByHash", mock.Anything, domain.UserTokenResetPassword, util.HashToken("reset-token")).Return(token, nil).Once()
+
+		err := u.ResetPassword(context.Background(), "reset-token", "new-password")
+
+		assert.ErrorIs(t, err, domain.ErrBadParamInput)
+		mockUserRepo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failed - Token Expired", func(t *testing.T) {
+		u, _, _, mockTokenRepo, _ := newAccountUsecase(usecase.AuthConfig{})
+		token := &domain.UserToken{ID: 9, UserID: 1, ExpiresAt: time.Now().Add(-time.Minute)}
+
+		mockTokenRepo.On("GetByHash", mock.Anything, domain.UserTokenResetPassword, util.HashToken("reset-token")).Return(token, nil).Once()
+
+		err := u.ResetPassword(context.Background(), "reset-token", "new-password")
+
+		assert.ErrorIs(t, err, domain.ErrBadParamInput)
+	})
+}
+
+func TestRequestPasswordResetUnknownEmail(t *testing.T) {
+	u, mockUserRepo, _, _, mockMailer := newAccountUsecase(usecase.AuthConfig{})
+	mockUserRepo.On("GetByEmail", mock.Anything, "ghost@example.com").Return(nil, nil).Once()
+
+	err := u.RequestPasswordReset(context.Background(), "ghost@example.com")
+
+	assert.NoError(t, err)
+	mockMailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+}
+
+func TestLoginWithMFA(t *testing.T) {
+	u, mockUserRepo, mockSessionRepo, mockTokenRepo, _ := newAccountUsecase(usecase.AuthConfig{MFAChallengeTTL: 5 * time.Minute})
+
+	secret, _ := util.GenerateTOTPSecret()
+	hashed, _ := util.HashPassword("password123")
+	enabledAt := time.Now().Add(-time.Hour)
+	user := &domain.User{ID: 1, Email: "test@example.com", Password: hashed, TOTPSecret: secret, MFAEnabledAt: &enabledAt}
+
+	// Langkah 1: password benar hanya menghasilkan challenge
+	var challengeHash string
+	mockUserRepo.On("GetByEmail", mock.Anything, user.Email).Return(user, nil).Once()
+	mockTokenRepo.On("InvalidateAll", mock.Anything, int64(1), domain.UserTokenMFAChallenge, mock.Anything).Return(nil).Once()
+	mockTokenRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.UserToken")).
+		Run(func(args mock.Arguments) { challengeHash = args.Get(1).(*domain.UserToken).TokenHash }).
+		Return(nil).Once()
+
+	res, err := u.Login(context.Background(), user.Email, "password123")
+
+	assert.NoError(t, err)
+	assert.True(t, res.MFARequired)
+	assert.NotEmpty(t, res.MFAToken)
+	assert.Empty(t, res.AccessToken)
+	assert.Equal(t, util.HashToken(res.MFAToken), challengeHash)
+	mockSessionRepo.AssertNotCalled(t, "CreateSession", mock.Anything, mock.Anything)
+
+	// Langkah 2: kode TOTP ditukar dengan session
+	step := util.TOTPStep(time.Now())
+	code, _ := util.TOTPCodeAt(secret, step)
+	stored := &domain.UserToken{ID: 9, UserID: 1, Purpose: domain.UserTokenMFAChallenge, ExpiresAt: time.Now().Add(time.Minute)}
+
+	mockTokenRepo.On("GetByHash", mock.Anything, domain.UserTokenMFAChallenge, challengeHash).Return(stored, nil).Once()
+	mockTokenRepo.On("MarkUsed", mock.Anything, int64(9), mock.Anything).Return(true, nil).Once()
+	mockUserRepo.On("GetByID", mock.Anything, int64(1)).Return(user, nil).Once()
+	mockUserRepo.On("ClaimTOTPStep", mock.Anything, int64(1), step).Return(true, nil).Once()
+	mockSessionRepo.On("CreateSession", mock.Anything, mock.AnythingOfType("*domain.Session")).Return(nil).Once()
+	mockSessionRepo.On("CreateRefreshToken", mock.Anything, mock.AnythingOfType("*domain.RefreshToken")).Return(nil).Once()
+
+	res, err = u.VerifyMFA(context.Background(), res.MFAToken, code)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, res.AccessToken)
+	assert.False(t, res.MFARequired)
+	mockUserRepo.AssertExpectations(t)
+	mockSessionRepo.AssertExpectations(t)
+}
+
+func TestVerifyMFA(t *testing.T) {
+	secret, _ := util.GenerateTOTPSecret()
+	enabledAt := time.Now().Add(-time.Hour)
+	user := &domain.User{ID: 1, TOTPSecret: secret, MFAEnabledAt: &enabledAt}
+	stored := &domain.UserToken{ID: 9, UserID: 1, Purpose: domain.UserTokenMFAChallenge, ExpiresAt: time.Now().Add(time.Minute)}
+
+	t.Run("Recovery Code Is Accepted Once", func(t *testing.T) {
+		u, mockUserRepo, mockSessionRepo, mockTokenRepo, _ := newAccountUsecase(usecase.AuthConfig{})
+
+		mockTokenRepo.On("GetByHash", mock.Anything, domain.UserTokenMFAChallenge, util.HashToken("challenge")).Return(stored, nil).Once()
+		mockTokenRepo.On("MarkUsed", mock.Anything, int64(9), mock.Anything).Return(true, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, int64(1)).Return(user, nil).Once()
+		// Input dinormalisasi: huruf kecil dan tanpa tanda hubung tetap cocok
+		mockUserRepo.On("UseRecoveryCode", mock.Anything, int64(1), util.HashToken("ABCDE12345"), mock.Anything).Return(true, nil).Once()
+		mockSessionRepo.On("CreateSession", mock.Anything, mock.AnythingOfType("*domain.Session")).Return(nil).Once()
+		mockSessionRepo.On("CreateRefreshToken", mock.Anything, mock.AnythingOfType("*domain.RefreshToken")).Return(nil).Once()
+
+		res, err := u.VerifyMFA(context.Background(), "challenge", "abcde-12345")
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, res.AccessToken)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("Replayed TOTP Code Is Rejected", func(t *testing.T) {
+		u, mockUserRepo, mockSessionRepo, mockTokenRepo, _ := newAccountUsecase(usecase.AuthConfig{})
+		code, _ := util.TOTPCodeAt(secret, util.TOTPStep(time.Now()))
+
+		mockTokenRepo.On("GetByHash", mock.Anything, domain.UserTokenMFAChallenge, util.HashToken("challenge")).Return(stored, nil).Once()
+		mockTokenRepo.On("MarkUsed", mock.Anything, int64(9), mock.Anything).Return(true, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, int64(1)).Return(user, nil).Once()
+		mockUserRepo.On("ClaimTOTPStep", mock.Anything, int64(1), mock.Anything).Return(false, nil).Once()
+
+		_, err := u.VerifyMFA(context.Background(), "challenge", code)
+
+		assert.ErrorIs(t, err, domain.ErrUnauthorized)
+		mockSessionRepo.AssertNotCalled(t, "CreateSession", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Used Challenge Is Rejected", func(t *testing.T) {
+		u, _, _, mockTokenRepo, _ := newAccountUsecase(usecase.AuthConfig{})
+		usedAt := time.Now()
+		used := &domain.UserToken{ID: 9, UserID: 1, ExpiresAt: time.Now().Add(time.Minute), UsedAt: &usedAt}
+
+		mockTokenRepo.On("GetByHash", mock.Anything, domain.UserTokenMFAChallenge, util.HashToken("challenge")).Return(used, nil).Once()
+
+		_, err := u.VerifyMFA(context.Background(), "challenge", "123456")
+
+		assert.ErrorIs(t, err, domain.ErrUnauthorized)
+	})
+}
+
+func TestConfirmMFA(t *testing.T) {
+	u, mockUserRepo, _, _, _ := newAccountUsecase(usecase.AuthConfig{})
+
+	secret, _ := util.GenerateTOTPSecret()
+	user := &domain.User{ID: 1, TOTPSecret: secret}
+
+	t.Run("Wrong Code", func(t *testing.T) {
+		mockUserRepo.On("GetByID", mock.Anything, int64(1)).Return(user, nil).Once()
+
+		_, err := u.ConfirmMFA(context.Background(), 1, "000000x")
+
+		assert.ErrorIs(t, err, domain.ErrBadParamInput)
+	})
+
+	t.Run("Success Enables MFA And Stores Hashed Recovery Codes", func(t *testing.T) {
+		code, _ := util.TOTPCodeAt(secret, util.TOTPStep(time.Now()))
+		var storedHashes []string
+
+		mockUserRepo.On("GetByID", mock.Anything, int64(1)).Return(user, nil).Once()
+		mockUserRepo.On("ClaimTOTPStep", mock.Anything, int64(1), mock.Anything).Return(true, nil).Once()
+		mockUserRepo.On("ReplaceRecoveryCodes", mock.Anything, int64(1), mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) { storedHashes = args.Get(2).([]string) }).
+			Return(nil).Once()
+		mockUserRepo.On("EnableMFA", mock.Anything, int64(1), mock.Anything).Return(nil).Once()
+
+		res, err := u.ConfirmMFA(context.Background(), 1, code)
+
+		assert.NoError(t, err)
+		assert.Len(t, res.Codes, 10)
+		assert.Len(t, storedHashes, 10)
+		assert.NotContains(t, storedHashes, res.Codes[0])
+		mockUserRepo.AssertExpectations(t)
+	})
+}
+
+func TestUpdateProfileTimezone(t *testing.T) {
+	mockUserRepo := new(mocks.UserRepository)
+	u := usecase.NewUserUsecase(mockUserRepo, new(mocks.SessionRepository), new(mocks.UserTokenRepository), new(mocks.Mailer), 2*time.Second, usecase.AuthConfig{Secret: "secret_key"})
+
+	t.Run("Failed - Unknown Zone", func(t *testing.T) {
+		mockUserRepo.On("GetByID", mock.Anything, int64(1)).Return(&domain.User{ID: 1, Name: "Budi", Timezone: "UTC"}, nil).Once()
+
+		tz := "Mars/Olympus"
+		_, err := u.UpdateProfile(context.Background(), 1, domain.UserPatch{Timezone: domain.Optional[string]{Set: true, Value: &tz}})
+
+		assert.ErrorIs(t, err, domain.ErrBadParamInput)
+		mockUserRepo.AssertNotCalled(t, "UpdateProfile", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		mockUserRepo.On("GetByID", mock.Anything, int64(1)).Return(&domain.User{ID: 1, Name: "Budi", Timezone: "UTC"}, nil).Once()
+		mockUserRepo.On("UpdateProfile", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+			return u.Timezone == "Asia/Jakarta" && u.Name == "Budi"
+		})).Return(nil).Once()
+
+		tz := "Asia/Jakarta"
+		user, err := u.UpdateProfile(context.Background(), 1, domain.UserPatch{Timezone: domain.Optional[string]{Set: true, Value: &tz}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Asia/Jakarta", user.Location().String())
+	})
+}
@@ -0,0 +1,325 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+	"simple-task-manager/pkg/util"
+)
+
+const (
+	maxWebhookURL        = 2048
+	webhookDeliveryLimit = 50
+	webhookSecretPrefix  = "whsec_"
+)
+
+// CreatedWebhook berisi secret mentah yang hanya dikembalikan sekali
+type CreatedWebhook struct {
+	domain.Webhook
+	Secret string `json:"secret"`
+}
+
+type WebhookUsecase struct {
+	webhookRepo    domain.WebhookRepository
+	sender         domain.WebhookSender
+	policy         *policy
+	contextTimeout time.Duration
+}
+
+func NewWebhookUsecase(
+	webhookRepo domain.WebhookRepository,
+	workspaceRepo domain.WorkspaceRepository,
+	sender domain.WebhookSender,
+	timeout time.Duration,
+) *WebhookUsecase {
+	return &WebhookUsecase{
+		webhookRepo:    webhookRepo,
+		sender:         sender,
+		policy:         newPolicy(nil, nil, workspaceRepo), // usecase ini hanya mengecek workspace
+		contextTimeout: timeout,
+	}
+}
+
+// authorizeWebhook: webhook pribadi hanya untuk pembuatnya, webhook workspace untuk admin & owner
+func (u *WebhookUsecase) authorizeWebhook(ctx context.Context, id int64, userID int64) (*domain.Webhook, error) {
+	hook, err := u.webhookRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if hook == nil {
+		return nil, domain.NotFound("webhook %d not found", id)
+	}
+	if err := u.policy.authorizeResource(ctx, "webhook", id, hook.UserID, hook.WorkspaceID, userID, permManage); err != nil {
+		return nil, err
+	}
+	return hook, nil
+}
+
+// validateWebhook melengkapi verr dengan error URL & daftar event; events dirapikan tanpa duplikat
+func validateWebhook(hook *domain.Webhook, verr *domain.ValidationError) {
+	hook.URL = strings.TrimSpace(hook.URL)
+	parsed, err := url.Parse(hook.URL)
+	switch {
+	case hook.URL == "":
+		verr.Add("url", "is required")
+	case len(hook.URL) > maxWebhookURL:
+		verr.Add("url", "must be at most %d characters", maxWebhookURL)
+	case err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "":
+		verr.Add("url", "must be an absolute http or https URL")
+	case isInternalHost(parsed.Hostname()):
+		// Hanya penolakan dini; sender tetap memeriksa alamat hasil DNS saat mengirim
+		verr.Add("url", "must not point to a private or reserved address")
+	}
+
+	events := make([]string, 0, len(hook.Events))
+	if len(hook.Events) == 0 {
+		verr.Add("events", "at least one event is required")
+	}
+	for i, e := range hook.Events {
+		e = strings.TrimSpace(e)
+		if !slices.Contains(domain.WebhookEvents, e) {
+			verr.Add(fmt.Sprintf("events[%d]", i), "unknown event %q", e)
+			continue
+		}
+		if !slices.Contains(events, e) {
+			events = append(events, e)
+		}
+	}
+	hook.Events = events
+}
+
+func isInternalHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+	addr, err := netip.ParseAddr(host)
+	return err == nil && !util.IsPublicIP(addr)
+}
+
+// Create mendaftarkan webhook baru dan mengembalikan secret penandatangannya
+func (u *WebhookUsecase) Create(c context.Context, userID int64, in domain.WebhookInput) (*CreatedWebhook, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	if in.WorkspaceID != nil {
+		if _, err := u.policy.authorizeWorkspace(ctx, *in.WorkspaceID, userID, permManage); err != nil {
+			return nil, err
+		}
+	}
+
+	hook := &domain.Webhook{
+		UserID:      userID,
+		WorkspaceID: in.WorkspaceID,
+		URL:         in.URL,
+		Events:      in.Events,
+		Active:      true,
+	}
+	verr := &domain.ValidationError{}
+	validateWebhook(hook, verr)
+	if err := verr.OrNil(); err != nil {
+		return nil, err
+	}
+
+	raw, _, err := util.GenerateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+	hook.Secret = webhookSecretPrefix + raw
+	hook.CreatedAt = time.Now()
+	hook.UpdatedAt = hook.CreatedAt
+
+	if err := u.webhookRepo.Create(ctx, hook); err != nil {
+		return nil, err
+	}
+	return &CreatedWebhook{Webhook: *hook, Secret: hook.Secret}, nil
+}
+
+// List webhook pribadi user, atau webhook workspace untuk admin & owner-nya
+func (u *WebhookUsecase) List(c context.Context, userID int64, workspaceID *int64) ([]domain.Webhook, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	if workspaceID != nil {
+		if _, err := u.policy.authorizeWorkspace(ctx, *workspaceID, userID, permManage); err != nil {
+			return nil, err
+		}
+	}
+	return u.webhookRepo.List(ctx, userID, workspaceID)
+}
+
+func (u *WebhookUsecase) Get(c context.Context, id int64, userID int64) (*domain.Webhook, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	return u.authorizeWebhook(ctx, id, userID)
+}
+
+// Patch mengubah URL, daftar event, atau mengaktifkan/menonaktifkan webhook
+func (u *WebhookUsecase) Patch(c context.Context, id int64, userID int64, patch domain.WebhookPatch) (*domain.Webhook, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	hook, err := u.authorizeWebhook(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	verr := &domain.ValidationError{}
+	if patch.URL.Set {
+		hook.URL = ""
+		if patch.URL.Value != nil {
+			hook.URL = *patch.URL.Value
+		}
+	}
+	if patch.Events.Set {
+		hook.Events = nil
+		if patch.Events.Value != nil {
+			hook.Events = *patch.Events.Value
+		}
+	}
+	if patch.Active.Set {
+		if patch.Active.Value == nil {
+			verr.Add("active", "cannot be null")
+		} else {
+			hook.Active = *patch.Active.Value
+		}
+	}
+	validateWebhook(hook, verr)
+	if err := verr.OrNil(); err != nil {
+		return nil, err
+	}
+
+	hook.UpdatedAt = time.Now()
+	if err := u.webhookRepo.Update(ctx, hook); err != nil {
+		return nil, err
+	}
+	return hook, nil
+}
+
+func (u *WebhookUsecase) Delete(c context.Context, id int64, userID int64) error {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	if _, err := u.authorizeWebhook(ctx, id, userID); err != nil {
+		return err
+	}
+	return u.webhookRepo.Delete(ctx, id)
+}
+
+// ListDeliveries mengembalikan log pengiriman terbaru webhook
+func (u *WebhookUsecase) ListDeliveries(c context.Context, id int64, userID int64) ([]domain.WebhookDelivery, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	if _, err := u.authorizeWebhook(ctx, id, userID); err != nil {
+		return nil, err
+	}
+	return u.webhookRepo.ListDeliveries(ctx, id, webhookDeliveryLimit)
+}
+
+// Ping mengirim event "ping" langsung (tanpa antrian & retry) dan mencatat hasilnya di log
+func (u *WebhookUsecase) Ping(c context.Context, id int64, userID int64) (*domain.WebhookDelivery, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	hook, err := u.authorizeWebhook(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	payload, err := json.Marshal(domain.Event{
+		Type:        domain.WebhookEventPing,
+		ActorID:     userID,
+		WorkspaceID: hook.WorkspaceID,
+		Data:        map[string]any{"webhook_id": hook.ID},
+		OccurredAt:  now,
+	})
+	if err != nil {
+		return nil, err
+	}
+	d := &domain.WebhookDelivery{
+		WebhookID:     hook.ID,
+		EventType:     domain.WebhookEventPing,
+		Payload:       payload,
+		Status:        domain.WebhookDeliverySending,
+		Attempts:      1,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}
+	if err := u.webhookRepo.EnqueueDelivery(ctx, d); err != nil {
+		return nil, err
+	}
+
+	// pengiriman memakai context request, bukan contextTimeout milik query database
+	status, sendErr := u.sender.Send(c, *hook, *d)
+	d.UpdatedAt = time.Now()
+	if status != 0 {
+		d.ResponseStatus = &status
+	}
+	if sendErr != nil {
+		msg := sendErr.Error()
+		d.Status, d.LastError = domain.WebhookDeliveryFailed, &msg
+	} else {
+		d.Status, d.DeliveredAt = domain.WebhookDeliverySucceeded, &d.UpdatedAt
+	}
+	if err := u.webhookRepo.CompleteDelivery(ctx, d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// HandleEvent adalah subscriber event bus yang memasukkan event ke antrian setiap
+// webhook yang melangganinya. Event task pribadi hanya sampai ke webhook pribadi
+// pemiliknya (Recipients), event task workspace ke webhook workspace tersebut.
+func (u *WebhookUsecase) HandleEvent(c context.Context, e domain.Event) error {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	if e.WorkspaceID == nil && len(e.Recipients) == 0 {
+		return nil
+	}
+	hooks, err := u.webhookRepo.ListSubscribed(ctx, e.Type, e.WorkspaceID, e.Recipients)
+	if err != nil || len(hooks) == 0 {
+		return err
+	}
+
+	e.Recipients = nil // tidak ikut dikirim ke pihak luar
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	var eventID *int64
+	if e.ID != 0 {
+		eventID = &e.ID
+	}
+	now := time.Now()
+	for _, hook := range hooks {
+		d := &domain.WebhookDelivery{
+			WebhookID:     hook.ID,
+			EventType:     e.Type,
+			Payload:       payload,
+			EventID:       eventID,
+			Status:        domain.WebhookDeliveryPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		}
+		// ErrConflict: event dikirim ulang oleh relay dan sudah diantrikan sebelumnya;
+		// ErrNotFound: webhook dihapus setelah daftar di atas diambil
+		err := u.webhookRepo.EnqueueDelivery(ctx, d)
+		if err != nil && !errors.Is(err, domain.ErrConflict) && !errors.Is(err, domain.ErrNotFound) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package usecase_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+	"simple-task-manager/internal/core/usecase"
+	"simple-task-manager/internal/core/usecase/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateWebhook(t *testing.T) {
+	t.Run("Success - Returns Secret Once", func(t *testing.T) {
+		repo := new(mocks.WebhookRepository)
+		u := usecase.NewWebhookUsecase(repo, new(mocks.WorkspaceRepository), new(mocks.WebhookSender), 2*time.Second)
+
+		repo.On("Create", mock.Anything, mock.MatchedBy(func(h *domain.Webhook) bool {
+			return h.UserID == 1 && h.Active && len(h.Events) == 1 && strings.HasPrefix(h.Secret, "whsec_")
+		})).Return(nil).Once()
+
+		created, err := u.Create(context.Background(), 1, domain.WebhookInput{
+			URL:    " https://example.com/hook ",
+			Events: []string{domain.EventTaskCreated, domain.EventTaskCreated},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "https://example.com/hook", created.URL)
+		assert.Equal(t, created.Webhook.Secret, created.Secret)
+
+		// secret tidak ikut saat webhook diserialisasi di endpoint lain
+		raw, _ := json.Marshal(created.Webhook)
+		assert.NotContains(t, string(raw), "whsec_")
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Failed - Invalid URL And Event", func(t *testing.T) {
+		repo := new(mocks.WebhookRepository)
+		u := usecase.NewWebhookUsecase(repo, new(mocks.WorkspaceRepository), new(mocks.WebhookSender), 2*time.Second)
+
+		_, err := u.Create(context.Background(), 1, domain.WebhookInput{URL: "ftp://example.com", Events: []string{"task.exploded"}})
+
+		var verr *domain.ValidationError
+		assert.ErrorAs(t, err, &verr)
+		fields := []string{}
+		for _, f := range verr.Fields {
+			fields = append(fields, f.Field)
+		}
+		assert.Equal(t, []string{"url", "events[0]"}, fields)
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failed - Internal Destination", func(t *testing.T) {
+		repo := new(mocks.WebhookRepository)
+		u := usecase.NewWebhookUsecase(repo, new(mocks.WorkspaceRepository), new(mocks.WebhookSender), 2*time.Second)
+
+		for _, raw := range []string{"http://169.254.169.254/latest/meta-data", "http://localhost:6379", "https://10.0.0.5/hook", "http://[::1]/"} {
+			_, err := u.Create(context.Background(), 1, domain.WebhookInput{URL: raw, Events: []string{domain.EventTaskCreated}})
+
+			var verr *domain.ValidationError
+			if assert.ErrorAs(t, err, &verr, raw) {
+				assert.Equal(t, "url", verr.Fields[0].Field)
+			}
+		}
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failed - Workspace Member Cannot Manage", func(t *testing.T) {
+		repo := new(mocks.WebhookRepository)
+		workspaceRepo := new(mocks.WorkspaceRepository)
+		u := usecase.NewWebhookUsecase(repo, workspaceRepo, new(mocks.WebhookSender), 2*time.Second)
+
+		workspaceID := int64(5)
+		workspaceRepo.On("GetMember", mock.Anything, workspaceID, int64(1)).
+			Return(&domain.WorkspaceMember{WorkspaceID: workspaceID, UserID: 1, Role: domain.RoleMember}, nil).Once()
+
+		_, err := u.Create(context.Background(), 1, domain.WebhookInput{
+			URL: "https://example.com/hook", Events: []string{domain.EventTaskCreated}, WorkspaceID: &workspaceID,
+		})
+
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestWebhookHandleEvent(t *testing.T) {
+	t.Run("Personal Task - Enqueues For Owner's Hooks", func(t *testing.T) {
+		repo := new(mocks.WebhookRepository)
+		u := usecase.NewWebhookUsecase(repo, new(mocks.WorkspaceRepository), new(mocks.WebhookSender), 2*time.Second)
+
+		repo.On("ListSubscribed", mock.Anything, domain.EventTaskUpdated, (*int64)(nil), []int64{7}).
+			Return([]domain.Webhook{{ID: 1}, {ID: 2}}, nil).Once()
+		repo.On("EnqueueDelivery", mock.Anything, mock.MatchedBy(func(d *domain.WebhookDelivery) bool {
+			return d.EventType == domain.EventTaskUpdated && d.Status == domain.WebhookDeliveryPending &&
+				!strings.Contains(string(d.Payload), "recipients")
+		})).Return(nil).Twice()
+
+		err := u.HandleEvent(context.Background(), domain.Event{
+			Type: domain.EventTaskUpdated, TaskID: 3, ActorID: 7, Recipients: []int64{7},
+		})
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("No Audience - Nothing Queried", func(t *testing.T) {
+		repo := new(mocks.WebhookRepository)
+		u := usecase.NewWebhookUsecase(repo, new(mocks.WorkspaceRepository), new(mocks.WebhookSender), 2*time.Second)
+
+		assert.NoError(t, u.HandleEvent(context.Background(), domain.Event{Type: domain.EventTaskDeleted, TaskID: 3}))
+		repo.AssertNotCalled(t, "ListSubscribed", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestPingWebhook(t *testing.T) {
+	repo := new(mocks.WebhookRepository)
+	sender := new(mocks.WebhookSender)
+	u := usecase.NewWebhookUsecase(repo, new(mocks.WorkspaceRepository), sender, 2*time.Second)
+
+	hook := &domain.Webhook{ID: 4, UserID: 1, URL: "https://example.com/hook", Active: true}
+	repo.On("GetByID", mock.Anything, int64(4)).Return(hook, nil)
+	repo.On("EnqueueDelivery", mock.Anything, mock.Anything).Return(nil).Once()
+	sender.On("Send", mock.Anything, *hook, mock.Anything).Return(500, errors.New("webhook responded with status 500")).Once()
+	repo.On("CompleteDelivery", mock.Anything, mock.MatchedBy(func(d *domain.WebhookDelivery) bool {
+		return d.Status == domain.WebhookDeliveryFailed && *d.ResponseStatus == 500
+	})).Return(nil).Once()
+
+	t.Run("Failed - Other User Is Forbidden", func(t *testing.T) {
+		_, err := u.Ping(context.Background(), 4, 2)
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+	})
+
+	t.Run("Records Failed Attempt", func(t *testing.T) {
+		d, err := u.Ping(context.Background(), 4, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.WebhookEventPing, d.EventType)
+		repo.AssertExpectations(t)
+		sender.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,403 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+	"simple-task-manager/pkg/util"
+)
+
+// WorkspaceConfig mengatur link dan masa berlaku undangan workspace
+type WorkspaceConfig struct {
+	AppURL        string        // base URL frontend untuk link undangan
+	InvitationTTL time.Duration // masa berlaku link undangan
+}
+
+type WorkspaceUsecase struct {
+	workspaceRepo  domain.WorkspaceRepository
+	userRepo       domain.UserRepository
+	mailer         domain.Mailer
+	policy         *policy
+	contextTimeout time.Duration
+	cfg            WorkspaceConfig
+}
+
+func NewWorkspaceUsecase(
+	workspaceRepo domain.WorkspaceRepository,
+	userRepo domain.UserRepository,
+	mailer domain.Mailer,
+	timeout time.Duration,
+	cfg WorkspaceConfig,
+) *WorkspaceUsecase {
+	return &WorkspaceUsecase{
+		workspaceRepo:  workspaceRepo,
+		userRepo:       userRepo,
+		mailer:         mailer,
+		policy:         newPolicy(nil, nil, workspaceRepo), // hanya mengecek keanggotaan workspace
+		contextTimeout: timeout,
+		cfg:            cfg,
+	}
+}
+
+func validateWorkspaceName(name string) error {
+	switch {
+	case name == "":
+		return domain.NewValidationError("name", "cannot be empty")
+	case len(name) > 255:
+		return domain.NewValidationError("name", "is longer than 255 characters")
+	}
+	return nil
+}
+
+// Create workspace baru; pembuatnya otomatis menjadi owner
+func (u *WorkspaceUsecase) Create(c context.Context, userID int64, name string) (*domain.Workspace, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	name = strings.TrimSpace(name)
+	if err := validateWorkspaceName(name); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	ws := &domain.Workspace{
+		Name:      name,
+		OwnerID:   userID,
+		Role:      domain.RoleOwner,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := u.workspaceRepo.Create(ctx, ws); err != nil {
+		return nil, err
+	}
+	return ws, nil
+}
+
+// List workspace tempat user menjadi anggota
+func (u *WorkspaceUsecase) List(c context.Context, userID int64) ([]domain.Workspace, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	return u.workspaceRepo.ListByMember(ctx, userID)
+}
+
+// Get satu workspace beserta role user di dalamnya
+func (u *WorkspaceUsecase) Get(c context.Context, id int64, userID int64) (*domain.Workspace, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	member, err := u.policy.authorizeWorkspace(ctx, id, userID, permView)
+	if err != nil {
+		return nil, err
+	}
+	return u.getWorkspace(ctx, id, member.Role)
+}
+
+func (u *WorkspaceUsecase) getWorkspace(ctx context.Context, id int64, role string) (*domain.Workspace, error) {
+	ws, err := u.workspaceRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if ws == nil {
+		return nil, domain.NotFound("workspace %d not found", id)
+	}
+	ws.Role = role
+	return ws, nil
+}
+
+// Rename workspace (admin ke atas)
+func (u *WorkspaceUsecase) Rename(c context.Context, id int64, userID int64, name string) (*domain.Workspace, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	member, err := u.policy.authorizeWorkspace(ctx, id, userID, permManage)
+	if err != nil {
+		return nil, err
+	}
+
+	name = strings.TrimSpace(name)
+	if err := validateWorkspaceName(name); err != nil {
+		return nil, err
+	}
+
+	ws, err := u.getWorkspace(ctx, id, member.Role)
+	if err != nil {
+		return nil, err
+	}
+	ws.Name = name
+	ws.UpdatedAt = time.Now()
+	if err := u.workspaceRepo.Update(ctx, ws); err != nil {
+		return nil, err
+	}
+	return ws, nil
+}
+
+// Delete workspace beserta semua task dan project di dalamnya; hanya owner
+func (u *WorkspaceUsecase) Delete(c context.Context, id int64, userID int64) error {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	member, err := u.policy.authorizeWorkspace(ctx, id, userID, permView)
+	if err != nil {
+		return err
+	}
+	if member.Role != domain.RoleOwner {
+		return domain.Forbidden("only the owner can delete workspace %d", id)
+	}
+
+	return u.workspaceRepo.Delete(ctx, id)
+}
+
+// --- MEMBERS ---
+
+// ListMembers bisa dilihat semua anggota
+func (u *WorkspaceUsecase) ListMembers(c context.Context, id int64, userID int64) ([]domain.WorkspaceMember, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	if _, err := u.policy.authorizeWorkspace(ctx, id, userID, permView); err != nil {
+		return nil, err
+	}
+	return u.workspaceRepo.ListMembers(ctx, id)
+}
+
+// canManageRole: owner mengatur siapa pun; admin hanya member dan viewer
+func canManageRole(actorRole string, role string) bool {
+	switch actorRole {
+	case domain.RoleOwner:
+		return role != domain.RoleOwner
+	case domain.RoleAdmin:
+		return role == domain.RoleMember || role == domain.RoleViewer
+	}
+	return false
+}
+
+// UpdateMemberRole mengganti role anggota. Role owner tidak bisa diberikan atau dicabut lewat sini.
+func (u *WorkspaceUsecase) UpdateMemberRole(c context.Context, id int64, userID int64, targetID int64, role string) (*domain.WorkspaceMember, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	actor, err := u.policy.authorizeWorkspace(ctx, id, userID, permManage)
+	if err != nil {
+		return nil, err
+	}
+	if !ValidInviteRole(role) {
+		return nil, domain.NewValidationError("role", "must be one of admin, member, viewer")
+	}
+
+	target, err := u.workspaceRepo.GetMember(ctx, id, targetID)
+	if err != nil {
+		return nil, err
+	}
+	if target == nil {
+		return nil, domain.NotFound("user %d is not a member of workspace %d", targetID, id)
+	}
+	if !canManageRole(actor.Role, target.Role) || !canManageRole(actor.Role, role) {
+		return nil, domain.Forbidden("your role %q cannot change a %s into a %s", actor.Role, target.Role, role)
+	}
+
+	if err := u.workspaceRepo.UpdateMemberRole(ctx, id, targetID, role); err != nil {
+		return nil, err
+	}
+	target.Role = role
+	return target, nil
+}
+
+// RemoveMember mengeluarkan anggota. Setiap anggota boleh keluar sendiri kecuali owner.
+func (u *WorkspaceUsecase) RemoveMember(c context.Context, id int64, userID int64, targetID int64) error {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	if userID == targetID {
+		member, err := u.policy.authorizeWorkspace(ctx, id, userID, permView)
+		if err != nil {
+			return err
+		}
+		if member.Role == domain.RoleOwner {
+			return domain.Conflict("the owner cannot leave workspace %d; delete it instead", id)
+		}
+		return u.workspaceRepo.RemoveMember(ctx, id, userID)
+	}
+
+	actor, err := u.policy.authorizeWorkspace(ctx, id, userID, permManage)
+	if err != nil {
+		return err
+	}
+	target, err := u.workspaceRepo.GetMember(ctx, id, targetID)
+	if err != nil {
+		return err
+	}
+	if target == nil {
+		return domain.NotFound("user %d is not a member of workspace %d", targetID, id)
+	}
+	if !canManageRole(actor.Role, target.Role) {
+		return domain.Forbidden("your role %q cannot remove a %s", actor.Role, target.Role)
+	}
+
+	return u.workspaceRepo.RemoveMember(ctx, id, targetID)
+}
+
+// --- INVITATIONS ---
+
+// ValidInviteRole bernilai true untuk role yang boleh diberikan lewat undangan atau perubahan role
+func ValidInviteRole(role string) bool {
+	return domain.ValidRole(role) && role != domain.RoleOwner
+}
+
+// Invite mengirim undangan lewat email. Admin hanya boleh mengundang member dan viewer.
+func (u *WorkspaceUsecase) Invite(c context.Context, id int64, userID int64, email string, role string) (*domain.WorkspaceInvitation, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	actor, err := u.policy.authorizeWorkspace(ctx, id, userID, permManage)
+	if err != nil {
+		return nil, err
+	}
+
+	email = strings.ToLower(strings.TrimSpace(email))
+	if role == "" {
+		role = domain.RoleMember
+	}
+	verr := &domain.ValidationError{}
+	if !isValidEmail(email) {
+		verr.Add("email", "is not a valid email address")
+	}
+	if !ValidInviteRole(role) {
+		verr.Add("role", "must be one of admin, member, viewer")
+	}
+	if err := verr.OrNil(); err != nil {
+		return nil, err
+	}
+	if !canManageRole(actor.Role, role) {
+		return nil, domain.Forbidden("your role %q cannot invite a %s", actor.Role, role)
+	}
+
+	ws, err := u.getWorkspace(ctx, id, actor.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, hash, err := util.GenerateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	inv := &domain.WorkspaceInvitation{
+		WorkspaceID: id,
+		Email:       email,
+		Role:        role,
+		TokenHash:   hash,
+		InvitedBy:   userID,
+		ExpiresAt:   now.Add(u.cfg.InvitationTTL),
+		CreatedAt:   now,
+	}
+	if err := u.workspaceRepo.CreateInvitation(ctx, inv); err != nil {
+		return nil, err
+	}
+
+	// Undangan tetap tersimpan walau email gagal; admin bisa mencabut dan mengundang ulang
+	link := strings.TrimRight(u.cfg.AppURL, "/") + "/invitations/accept?token=" + url.QueryEscape(raw)
+	err = u.mailer.Send(ctx, domain.Mail{
+		To:      email,
+		Subject: fmt.Sprintf("You've been invited to %s", ws.Name),
+		Body: fmt.Sprintf("Hi,\n\nYou have been invited to join the workspace %q as %s. Open the link below to accept:\n\n%s\n\nThe invitation expires in %s.\n",
+			ws.Name, role, link, u.cfg.InvitationTTL),
+	})
+	if err != nil {
+		log.Printf("failed to send invitation %d to %s: %v", inv.ID, email, err)
+	}
+	return inv, nil
+}
+
+// ListInvitations menampilkan undangan yang masih menunggu (admin ke atas)
+func (u *WorkspaceUsecase) ListInvitations(c context.Context, id int64, userID int64) ([]domain.WorkspaceInvitation, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	if _, err := u.policy.authorizeWorkspace(ctx, id, userID, permManage); err != nil {
+		return nil, err
+	}
+	return u.workspaceRepo.ListInvitations(ctx, id)
+}
+
+// RevokeInvitation membatalkan undangan yang belum diterima
+func (u *WorkspaceUsecase) RevokeInvitation(c context.Context, id int64, userID int64, invitationID int64) error {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	if _, err := u.policy.authorizeWorkspace(ctx, id, userID, permManage); err != nil {
+		return err
+	}
+
+	inv, err := u.workspaceRepo.GetInvitation(ctx, invitationID)
+	if err != nil {
+		return err
+	}
+	if inv == nil || inv.WorkspaceID != id {
+		return domain.NotFound("invitation %d not found", invitationID)
+	}
+	if inv.AcceptedAt != nil {
+		return domain.Conflict("invitation %d has already been accepted", invitationID)
+	}
+
+	return u.workspaceRepo.RevokeInvitation(ctx, invitationID, time.Now())
+}
+
+// AcceptInvitation menambahkan user yang sedang login sebagai anggota.
+// Email akun harus sama dengan email yang diundang.
+func (u *WorkspaceUsecase) AcceptInvitation(c context.Context, userID int64, rawToken string) (*domain.Workspace, error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	invalid := domain.NewValidationError("token", "is invalid or has expired")
+
+	inv, err := u.workspaceRepo.GetInvitationByHash(ctx, util.HashToken(strings.TrimSpace(rawToken)))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if inv == nil || !inv.Pending(now) {
+		return nil, invalid
+	}
+
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || !strings.EqualFold(user.Email, inv.Email) {
+		return nil, domain.Forbidden("this invitation was sent to a different email address")
+	}
+
+	existing, err := u.workspaceRepo.GetMember(ctx, inv.WorkspaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, domain.Conflict("you are already a member of workspace %d", inv.WorkspaceID)
+	}
+
+	member := &domain.WorkspaceMember{
+		WorkspaceID: inv.WorkspaceID,
+		UserID:      userID,
+		Role:        inv.Role,
+		JoinedAt:    now,
+	}
+	ok, err := u.workspaceRepo.AcceptInvitation(ctx, inv.ID, member, now)
+	if err != nil {
+		if errors.Is(err, domain.ErrConflict) {
+			return nil, domain.Conflict("you are already a member of workspace %d", inv.WorkspaceID)
+		}
+		return nil, err
+	}
+	if !ok {
+		return nil, invalid
+	}
+
+	return u.getWorkspace(ctx, inv.WorkspaceID, inv.Role)
+}
@@ -0,0 +1,156 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+	"simple-task-manager/internal/core/usecase"
+	"simple-task-manager/internal/core/usecase/mocks"
+	"simple-task-manager/pkg/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func member(wsID, userID int64, role string) *domain.WorkspaceMember {
+	return &domain.WorkspaceMember{WorkspaceID: wsID, UserID: userID, Role: role}
+}
+
+func TestWorkspaceTaskPolicy(t *testing.T) {
+	wsID := int64(4)
+	mockTaskRepo := new(mocks.TaskRepository)
+	mockWorkspaceRepo := new(mocks.WorkspaceRepository)
+	u := usecase.NewTaskUsecase(mockTaskRepo, new(mocks.StatusRepository), new(mocks.ProjectRepository), mockWorkspaceRepo, new(mocks.UserRepository), new(mocks.TaskReminderRepository), 2*time.Second)
+
+	t.Run("Viewer Cannot Edit", func(t *testing.T) {
+		mockTaskRepo.On("GetByID", mock.Anything, int64(10)).Return(&domain.Task{ID: 10, UserID: 1, WorkspaceID: &wsID}, nil).Once()
+		mockWorkspaceRepo.On("GetMember", mock.Anything, wsID, int64(2)).Return(member(wsID, 2, domain.RoleViewer), nil).Once()
+
+		title := "Baru"
+		_, err := u.Patch(context.Background(), 10, 2, domain.TaskPatch{Title: domain.Optional[string]{Set: true, Value: &title}})
+
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+		mockTaskRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Member Can Delete Own Task", func(t *testing.T) {
+		mockTaskRepo.On("GetByID", mock.Anything, int64(11)).Return(&domain.Task{ID: 11, UserID: 2, WorkspaceID: &wsID}, nil).Once()
+		mockWorkspaceRepo.On("GetMember", mock.Anything, wsID, int64(2)).Return(member(wsID, 2, domain.RoleMember), nil).Once()
+		mockTaskRepo.On("Delete", mock.Anything, int64(11), mock.Anything).Return(nil).Once()
+
+		err := u.Delete(context.Background(), 11, 2)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Member Cannot Delete Others' Task", func(t *testing.T) {
+		mockTaskRepo.On("GetByID", mock.Anything, int64(12)).Return(&domain.Task{ID: 12, UserID: 1, WorkspaceID: &wsID}, nil).Once()
+		mockWorkspaceRepo.On("GetMember", mock.Anything, wsID, int64(2)).Return(member(wsID, 2, domain.RoleMember), nil).Once()
+
+		err := u.Delete(context.Background(), 12, 2)
+
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+		mockTaskRepo.AssertNotCalled(t, "Delete", mock.Anything, int64(12), mock.Anything)
+	})
+
+	t.Run("Non Member Cannot See Workspace Tasks", func(t *testing.T) {
+		mockWorkspaceRepo.On("GetMember", mock.Anything, wsID, int64(3)).Return(nil, nil).Once()
+
+		_, err := u.Fetch(context.Background(), domain.TaskFilter{UserID: 3, WorkspaceID: &wsID})
+
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		mockTaskRepo.AssertNotCalled(t, "Fetch", mock.Anything, mock.Anything)
+	})
+}
+
+func TestWorkspaceMembers(t *testing.T) {
+	wsID := int64(4)
+	mockWorkspaceRepo := new(mocks.WorkspaceRepository)
+	u := usecase.NewWorkspaceUsecase(mockWorkspaceRepo, new(mocks.UserRepository), new(mocks.Mailer), 2*time.Second, usecase.WorkspaceConfig{})
+
+	t.Run("Admin Cannot Promote To Admin", func(t *testing.T) {
+		mockWorkspaceRepo.On("GetMember", mock.Anything, wsID, int64(2)).Return(member(wsID, 2, domain.RoleAdmin), nil).Once()
+		mockWorkspaceRepo.On("GetMember", mock.Anything, wsID, int64(3)).Return(member(wsID, 3, domain.RoleMember), nil).Once()
+
+		_, err := u.UpdateMemberRole(context.Background(), wsID, 2, 3, domain.RoleAdmin)
+
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+		mockWorkspaceRepo.AssertNotCalled(t, "UpdateMemberRole", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Owner Cannot Leave", func(t *testing.T) {
+		mockWorkspaceRepo.On("GetMember", mock.Anything, wsID, int64(1)).Return(member(wsID, 1, domain.RoleOwner), nil).Once()
+
+		err := u.RemoveMember(context.Background(), wsID, 1, 1)
+
+		assert.ErrorIs(t, err, domain.ErrConflict)
+	})
+
+	t.Run("Viewer Can Leave", func(t *testing.T) {
+		mockWorkspaceRepo.On("GetMember", mock.Anything, wsID, int64(5)).Return(member(wsID, 5, domain.RoleViewer), nil).Once()
+		mockWorkspaceRepo.On("RemoveMember", mock.Anything, wsID, int64(5)).Return(nil).Once()
+
+		err := u.RemoveMember(context.Background(), wsID, 5, 5)
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestAcceptInvitation(t *testing.T) {
+	wsID := int64(4)
+	raw := "invitation-token"
+	pending := func() *domain.WorkspaceInvitation {
+		return &domain.WorkspaceInvitation{
+			ID: 9, WorkspaceID: wsID, Email: "budi@example.com", Role: domain.RoleMember,
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+	}
+
+	t.Run("Failed - Different Email", func(t *testing.T) {
+		mockWorkspaceRepo := new(mocks.WorkspaceRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		u := usecase.NewWorkspaceUsecase(mockWorkspaceRepo, mockUserRepo, new(mocks.Mailer), 2*time.Second, usecase.WorkspaceConfig{})
+
+		mockWorkspaceRepo.On("GetInvitationByHash", mock.Anything, util.HashToken(raw)).Return(pending(), nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, int64(7)).Return(&domain.User{ID: 7, Email: "eve@example.com"}, nil).Once()
+
+		_, err := u.AcceptInvitation(context.Background(), 7, raw)
+
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+		mockWorkspaceRepo.AssertNotCalled(t, "AcceptInvitation", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success - Email Is Case Insensitive", func(t *testing.T) {
+		mockWorkspaceRepo := new(mocks.WorkspaceRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		u := usecase.NewWorkspaceUsecase(mockWorkspaceRepo, mockUserRepo, new(mocks.Mailer), 2*time.Second, usecase.WorkspaceConfig{})
+
+		mockWorkspaceRepo.On("GetInvitationByHash", mock.Anything, util.HashToken(raw)).Return(pending(), nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, int64(7)).Return(&domain.User{ID: 7, Email: "Budi@Example.com"}, nil).Once()
+		mockWorkspaceRepo.On("GetMember", mock.Anything, wsID, int64(7)).Return(nil, nil).Once()
+		mockWorkspaceRepo.On("AcceptInvitation", mock.Anything, int64(9), mock.AnythingOfType("*domain.WorkspaceMember"), mock.Anything).Return(true, nil).Once()
+		mockWorkspaceRepo.On("GetByID", mock.Anything, wsID).Return(&domain.Workspace{ID: wsID, Name: "Tim"}, nil).Once()
+
+		ws, err := u.AcceptInvitation(context.Background(), 7, raw)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.RoleMember, ws.Role)
+		mockWorkspaceRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failed - Already Used", func(t *testing.T) {
+		mockWorkspaceRepo := new(mocks.WorkspaceRepository)
+		u := usecase.NewWorkspaceUsecase(mockWorkspaceRepo, new(mocks.UserRepository), new(mocks.Mailer), 2*time.Second, usecase.WorkspaceConfig{})
+
+		used := pending()
+		accepted := time.Now()
+		used.AcceptedAt = &accepted
+		mockWorkspaceRepo.On("GetInvitationByHash", mock.Anything, util.HashToken(raw)).Return(used, nil).Once()
+
+		_, err := u.AcceptInvitation(context.Background(), 7, raw)
+
+		assert.ErrorIs(t, err, domain.ErrBadParamInput)
+	})
+}
@@ -0,0 +1,90 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"simple-task-manager/internal/core/domain"
+	"simple-task-manager/internal/core/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AccessTokenHandler struct {
+	AccessTokenUseCase *usecase.AccessTokenUsecase
+}
+
+// CreateAccessToken godoc
+// @Summary      Create Personal Access Token
+// @Description  Membuat API key untuk script/integrasi. Token mentah hanya dikembalikan sekali; simpan segera. Scope: tasks:read, tasks:write, statuses:read, statuses:write, workspaces:read, workspaces:write (write mencakup read).
+// @Tags         tokens
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body usecase.CreateAccessTokenInput true "Token Data"
+// @Success      201  {object}  usecase.CreatedAccessToken
+// @Failure      400  {object}  map[string]interface{}
+// @Router       /auth/tokens [post]
+func (h *AccessTokenHandler) Create(c *gin.Context) {
+	var req usecase.CreateAccessTokenInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("body", "%s", err.Error()))
+		return
+	}
+
+	userID := c.MustGet("user_id").(int64)
+
+	token, err := h.AccessTokenUseCase.Create(c.Request.Context(), userID, req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, token)
+}
+
+// ListAccessTokens godoc
+// @Summary      List Personal Access Tokens
+// @Description  Menampilkan semua token milik user, termasuk yang sudah dicabut atau kedaluwarsa
+// @Tags         tokens
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}  domain.PersonalAccessToken
+// @Router       /auth/tokens [get]
+func (h *AccessTokenHandler) List(c *gin.Context) {
+	userID := c.MustGet("user_id").(int64)
+
+	tokens, err := h.AccessTokenUseCase.List(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+// RevokeAccessToken godoc
+// @Summary      Revoke Personal Access Token
+// @Description  Mencabut token; request berikutnya dengan token ini akan ditolak
+// @Tags         tokens
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Token ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /auth/tokens/{id} [delete]
+func (h *AccessTokenHandler) Revoke(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(domain.NewValidationError("id", "must be an integer"))
+		return
+	}
+
+	userID := c.MustGet("user_id").(int64)
+
+	if err := h.AccessTokenUseCase.Revoke(c.Request.Context(), userID, id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Access token revoked"})
+}
@@ -4,6 +4,8 @@ import (
     "fmt"
     "net/http"
     "strconv"
+    "strings"
+    "time"
 
     "simple-task-manager/internal/core/domain"
     "simple-task-manager/internal/core/usecase"
@@ -23,10 +25,11 @@ func (h *UserHandler) Register(c *gin.Context) {
         Name     string `json:"name"`
         Email    string `json:"email"`
         Password string `json:"password"`
+        Timezone string `json:"timezone"`
     }
 
     if err := c.ShouldBindJSON(&req); err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+        c.Error(domain.NewValidationError("body", "%s", err.Error()))
         return
     }
 
@@ -37,11 +40,12 @@ func (h *UserHandler) Register(c *gin.Context) {
         Name:     req.Name,
         Email:    req.Email,
         Password: req.Password,
+        Timezone: req.Timezone,
     }
 
     if err := h.UserUseCase.Register(c.Request.Context(), &user); err != nil {
         fmt.Printf("\n>>> DEBUG ERROR REGISTER: %v\n\n", err)
-        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        c.Error(err)
         return
     }
 
@@ -53,12 +57,12 @@ func (h *UserHandler) Register(c *gin.Context) {
 
 // Login godoc
 // @Summary      Login User
-// @Description  Masuk dengan email dan password untuk mendapatkan Token JWT
+// @Description  Masuk dengan email dan password untuk mendapatkan Token JWT. Jika 2FA aktif, response berisi mfa_required dan mfa_token yang harus ditukar lewat /auth/mfa/verify.
 // @Tags         auth
 // @Accept       json
 // @Produce      json
 // @Param        request body object{email=string,password=string} true "Login Credentials"
-// @Success      200  {object}  map[string]interface{}
+// @Success      200  {object}  usecase.LoginResponse
 // @Failure      400  {object}  map[string]interface{}
 // @Failure      401  {object}  map[string]interface{}
 // @Router       /login [post]
@@ -69,20 +73,369 @@ func (h *UserHandler) Login(c *gin.Context) {
     }
 
     if err := c.ShouldBindJSON(&req); err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+        c.Error(domain.NewValidationError("body", "%s", err.Error()))
         return
     }
 
     res, err := h.UserUseCase.Login(c.Request.Context(), req.Email, req.Password)
     if err != nil {
         fmt.Printf("\n>>> DEBUG ERROR LOGIN: %v\n\n", err)
-        c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
+        c.Error(err)
         return
     }
 
     c.JSON(http.StatusOK, res)
 }
 
+// Refresh godoc
+// @Summary      Refresh Access Token
+// @Description  Menukar refresh token dengan pasangan access & refresh token baru. Refresh token lama langsung tidak berlaku; memakainya lagi akan mencabut seluruh session.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body object{refresh_token=string} true "Refresh Token"
+// @Success      200  {object}  usecase.TokenPair
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /auth/refresh [post]
+func (h *UserHandler) Refresh(c *gin.Context) {
+    var req struct {
+        RefreshToken string `json:"refresh_token" binding:"required"`
+    }
+
+    if err := c.ShouldBindJSON(&req); err != nil {
+        c.Error(domain.NewValidationError("refresh_token", "is required"))
+        return
+    }
+
+    pair, err := h.UserUseCase.Refresh(c.Request.Context(), req.RefreshToken)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+
+    c.JSON(http.StatusOK, pair)
+}
+
+// Logout godoc
+// @Summary      Logout
+// @Description  Mencabut session saat ini, atau semua session user jika all=true
+// @Tags         auth
+// @Accept       json
+// @Security     BearerAuth
+// @Param        request body object{all=bool} false "Logout dari semua perangkat"
+// @Success      200  {object}  map[string]interface{}
+// @Router       /auth/logout [post]
+func (h *UserHandler) Logout(c *gin.Context) {
+    var req struct {
+        All bool `json:"all"`
+    }
+    // Body opsional
+    _ = c.ShouldBindJSON(&req)
+
+    userID := c.MustGet("user_id").(int64)
+    sessionID := c.MustGet("session_id").(int64)
+
+    if err := h.UserUseCase.Logout(c.Request.Context(), userID, sessionID, req.All); err != nil {
+        c.Error(err)
+        return
+    }
+
+    c.JSON(http.StatusOK, gin.H{"message": "Logged out"})
+}
+
+// VerifyEmail godoc
+// @Summary      Verify Email
+// @Description  Menandai email terverifikasi memakai token dari email verifikasi (sekali pakai)
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body object{token=string} true "Verification Token"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Router       /auth/verify-email [post]
+func (h *UserHandler) VerifyEmail(c *gin.Context) {
+    var req struct {
+        Token string `json:"token" binding:"required"`
+    }
+    if err := c.ShouldBindJSON(&req); err != nil {
+        c.Error(domain.NewValidationError("token", "is required"))
+        return
+    }
+
+    if err := h.UserUseCase.VerifyEmail(c.Request.Context(), req.Token); err != nil {
+        c.Error(err)
+        return
+    }
+
+    c.JSON(http.StatusOK, gin.H{"message": "Email verified"})
+}
+
+// ResendVerification godoc
+// @Summary      Resend Verification Email
+// @Description  Mengirim ulang link verifikasi. Response selalu sama agar tidak membocorkan email yang terdaftar.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body object{email=string} true "Email"
+// @Success      202  {object}  map[string]interface{}
+// @Router       /auth/verify-email/resend [post]
+func (h *UserHandler) ResendVerification(c *gin.Context) {
+    var req struct {
+        Email string `json:"email" binding:"required"`
+    }
+    if err := c.ShouldBindJSON(&req); err != nil {
+        c.Error(domain.NewValidationError("email", "is required"))
+        return
+    }
+
+    if err := h.UserUseCase.ResendVerification(c.Request.Context(), req.Email); err != nil {
+        c.Error(err)
+        return
+    }
+
+    c.JSON(http.StatusAccepted, gin.H{"message": "If the account exists and is unverified, a verification email has been sent"})
+}
+
+// ForgotPassword godoc
+// @Summary      Request Password Reset
+// @Description  Mengirim link reset password. Response selalu sama agar tidak membocorkan email yang terdaftar.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body object{email=string} true "Email"
+// @Success      202  {object}  map[string]interface{}
+// @Router       /auth/password/forgot [post]
+func (h *UserHandler) ForgotPassword(c *gin.Context) {
+    var req struct {
+        Email string `json:"email" binding:"required"`
+    }
+    if err := c.ShouldBindJSON(&req); err != nil {
+        c.Error(domain.NewValidationError("email", "is required"))
+        return
+    }
+
+    if err := h.UserUseCase.RequestPasswordReset(c.Request.Context(), req.Email); err != nil {
+        c.Error(err)
+        return
+    }
+
+    c.JSON(http.StatusAccepted, gin.H{"message": "If the account exists, a password reset email has been sent"})
+}
+
+// ResetPassword godoc
+// @Summary      Reset Password
+// @Description  Mengganti password memakai token dari email (sekali pakai). Semua session aktif akan dicabut.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body object{token=string,password=string} true "Reset Data"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Router       /auth/password/reset [post]
+func (h *UserHandler) ResetPassword(c *gin.Context) {
+    var req struct {
+        Token    string `json:"token" binding:"required"`
+        Password string `json:"password" binding:"required"`
+    }
+    if err := c.ShouldBindJSON(&req); err != nil {
+        c.Error(domain.NewValidationError("body", "token and password are required"))
+        return
+    }
+
+    if err := h.UserUseCase.ResetPassword(c.Request.Context(), req.Token, req.Password); err != nil {
+        c.Error(err)
+        return
+    }
+
+    c.JSON(http.StatusOK, gin.H{"message": "Password has been reset, please log in again"})
+}
+
+// VerifyMFA godoc
+// @Summary      Complete Two-Factor Login
+// @Description  Menukar mfa_token dari /login dan kode TOTP (atau recovery code) dengan access & refresh token. Challenge hanya bisa dicoba sekali.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body object{mfa_token=string,code=string} true "MFA Challenge"
+// @Success      200  {object}  usecase.LoginResponse
+// @Failure      401  {object}  map[string]interface{}
+// @Router       /auth/mfa/verify [post]
+func (h *UserHandler) VerifyMFA(c *gin.Context) {
+    var req struct {
+        MFAToken string `json:"mfa_token" binding:"required"`
+        Code     string `json:"code" binding:"required"`
+    }
+    if err := c.ShouldBindJSON(&req); err != nil {
+        c.Error(domain.NewValidationError("body", "mfa_token and code are required"))
+        return
+    }
+
+    res, err := h.UserUseCase.VerifyMFA(c.Request.Context(), req.MFAToken, req.Code)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+
+    c.JSON(http.StatusOK, res)
+}
+
+// SetupMFA godoc
+// @Summary      Start Two-Factor Enrollment
+// @Description  Membuat secret TOTP baru dan URI otpauth:// untuk QR code. 2FA baru aktif setelah dikonfirmasi.
+// @Tags         auth
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  usecase.MFASetup
+// @Failure      409  {object}  map[string]interface{}
+// @Router       /auth/mfa/setup [post]
+func (h *UserHandler) SetupMFA(c *gin.Context) {
+    userID := c.MustGet("user_id").(int64)
+
+    setup, err := h.UserUseCase.SetupMFA(c.Request.Context(), userID)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+
+    c.JSON(http.StatusOK, setup)
+}
+
+// ConfirmMFA godoc
+// @Summary      Confirm Two-Factor Enrollment
+// @Description  Mengaktifkan 2FA dengan kode TOTP pertama dan mengembalikan recovery code (hanya ditampilkan sekali)
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body object{code=string} true "TOTP Code"
+// @Success      200  {object}  usecase.RecoveryCodes
+// @Failure      400  {object}  map[string]interface{}
+// @Router       /auth/mfa/confirm [post]
+func (h *UserHandler) ConfirmMFA(c *gin.Context) {
+    var req struct {
+        Code string `json:"code" binding:"required"`
+    }
+    if err := c.ShouldBindJSON(&req); err != nil {
+        c.Error(domain.NewValidationError("code", "is required"))
+        return
+    }
+
+    userID := c.MustGet("user_id").(int64)
+
+    codes, err := h.UserUseCase.ConfirmMFA(c.Request.Context(), userID, req.Code)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+
+    c.JSON(http.StatusOK, codes)
+}
+
+// DisableMFA godoc
+// @Summary      Disable Two-Factor Authentication
+// @Description  Mematikan 2FA dan menghapus semua recovery code. Butuh password dan kode TOTP/recovery code.
+// @Tags         auth
+// @Accept       json
+// @Security     BearerAuth
+// @Param        request body object{password=string,code=string} true "Confirmation"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      400  {object}  map[string]interface{}
+// @Router       /auth/mfa/disable [post]
+func (h *UserHandler) DisableMFA(c *gin.Context) {
+    var req struct {
+        Password string `json:"password" binding:"required"`
+        Code     string `json:"code" binding:"required"`
+    }
+    if err := c.ShouldBindJSON(&req); err != nil {
+        c.Error(domain.NewValidationError("body", "password and code are required"))
+        return
+    }
+
+    userID := c.MustGet("user_id").(int64)
+
+    if err := h.UserUseCase.DisableMFA(c.Request.Context(), userID, req.Password, req.Code); err != nil {
+        c.Error(err)
+        return
+    }
+
+    c.JSON(http.StatusOK, gin.H{"message": "Two-factor authentication disabled"})
+}
+
+// RegenerateRecoveryCodes godoc
+// @Summary      Regenerate Recovery Codes
+// @Description  Mengganti semua recovery code; kode lama langsung tidak berlaku
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body object{code=string} true "TOTP Code"
+// @Success      200  {object}  usecase.RecoveryCodes
+// @Failure      400  {object}  map[string]interface{}
+// @Router       /auth/mfa/recovery-codes [post]
+func (h *UserHandler) RegenerateRecoveryCodes(c *gin.Context) {
+    var req struct {
+        Code string `json:"code" binding:"required"`
+    }
+    if err := c.ShouldBindJSON(&req); err != nil {
+        c.Error(domain.NewValidationError("code", "is required"))
+        return
+    }
+
+    userID := c.MustGet("user_id").(int64)
+
+    codes, err := h.UserUseCase.RegenerateRecoveryCodes(c.Request.Context(), userID, req.Code)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+
+    c.JSON(http.StatusOK, codes)
+}
+
+// GetMe godoc
+// @Summary      Get Profile
+// @Tags         auth
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  domain.User
+// @Router       /me [get]
+func (h *UserHandler) GetMe(c *gin.Context) {
+    user, err := h.UserUseCase.GetProfile(c.Request.Context(), c.MustGet("user_id").(int64))
+    if err != nil {
+        c.Error(err)
+        return
+    }
+
+    c.JSON(http.StatusOK, user)
+}
+
+// UpdateMe godoc
+// @Summary      Update Profile
+// @Description  Mengubah nama dan/atau zona waktu (nama IANA, mis. "Asia/Jakarta"). Zona waktu dipakai untuk tenggat all-day dan bucket "hari ini".
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body  domain.UserPatch  true  "Field yang diubah"
+// @Success      200  {object}  domain.User
+// @Failure      400  {object}  map[string]interface{}
+// @Router       /me [patch]
+func (h *UserHandler) UpdateMe(c *gin.Context) {
+    var patch domain.UserPatch
+    if err := c.ShouldBindJSON(&patch); err != nil {
+        c.Error(domain.NewValidationError("body", "%s", err.Error()))
+        return
+    }
+
+    user, err := h.UserUseCase.UpdateProfile(c.Request.Context(), c.MustGet("user_id").(int64), patch)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+
+    c.JSON(http.StatusOK, user)
+}
+
 //
 // --- Task Handler ---
 //
@@ -105,13 +458,13 @@ type TaskHandler struct {
 func (h *TaskHandler) Create(c *gin.Context) {
     userID, exists := c.Get("user_id")
     if !exists {
-        c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+        c.Error(domain.Unauthorized("User not found in context"))
         return
     }
 
     var task domain.Task
     if err := c.ShouldBindJSON(&task); err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+        c.Error(domain.NewValidationError("body", "%s", err.Error()))
         return
     }
 
@@ -119,7 +472,7 @@ func (h *TaskHandler) Create(c *gin.Context) {
 
     if err := h.TaskUseCase.Create(c.Request.Context(), &task); err != nil {
         fmt.Printf("\n>>> DEBUG ERROR CREATE TASK: %v\n\n", err)
-        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        c.Error(err)
         return
     }
 
@@ -127,28 +480,185 @@ func (h *TaskHandler) Create(c *gin.Context) {
 }
 
 // FetchTasks godoc
-// @Summary      Get All Tasks
-// @Description  Mengambil semua tugas milik user yang sedang login
+// @Summary      Get Tasks
+// @Description  Mengambil tugas pribadi user dan tugas di workspace tempat ia menjadi anggota, dengan filter, sorting, dan cursor pagination
 // @Tags         tasks
 // @Produce      json
 // @Security     BearerAuth
-// @Success      200  {array}   domain.Task
+// @Param        status         query  string  false  "Filter status, pisahkan dengan koma"
+// @Param        priority       query  string  false  "Filter priority, pisahkan dengan koma"
+// @Param        label          query  string  false  "Filter label (cocok salah satu), pisahkan dengan koma"
+// @Param        project_id     query  string  false  "ID project, atau 'none' untuk task tanpa project"
+// @Param        workspace_id   query  string  false  "ID workspace, atau 'none' untuk task pribadi saja"
+// @Param        assignee       query  string  false  "ID user, atau 'me' untuk task yang di-assign ke user yang login"
+// @Param        created_from   query  string  false  "RFC3339, inklusif"
+// @Param        created_to     query  string  false  "RFC3339, eksklusif"
+// @Param        updated_from   query  string  false  "RFC3339, inklusif"
+// @Param        updated_to     query  string  false  "RFC3339, eksklusif"
+// @Param        reminder_from  query  string  false  "RFC3339, inklusif"
+// @Param        reminder_to    query  string  false  "RFC3339, eksklusif"
+// @Param        due_from       query  string  false  "RFC3339, inklusif"
+// @Param        due_to         query  string  false  "RFC3339, eksklusif"
+// @Param        incomplete     query  bool    false  "Hanya task yang belum selesai"
+// @Param        sort           query  string  false  "created_at, updated_at, reminder_time, priority, title, due_at; awali dengan '-' untuk descending"
+// @Param        limit          query  int     false  "Jumlah task per halaman (default 50, max 200)"
+// @Param        cursor         query  string  false  "next_cursor dari halaman sebelumnya"
+// @Success      200  {object}  domain.TaskPage
+// @Failure      400  {object}  map[string]interface{}
 // @Router       /tasks/ [get]
 func (h *TaskHandler) Fetch(c *gin.Context) {
     userID, exists := c.Get("user_id")
     if !exists {
-        c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+        c.Error(domain.Unauthorized("User not found in context"))
+        return
+    }
+
+    filter, err := parseTaskFilter(c)
+    if err != nil {
+        c.Error(err)
         return
     }
+    filter.UserID = userID.(int64)
 
-    tasks, err := h.TaskUseCase.Fetch(c.Request.Context(), userID.(int64))
+    page, err := h.TaskUseCase.Fetch(c.Request.Context(), filter)
     if err != nil {
         fmt.Printf("\n>>> DEBUG ERROR FETCH: %v\n\n", err)
-        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        c.Error(err)
+        return
+    }
+
+    c.JSON(http.StatusOK, page)
+}
+
+// DueTasks godoc
+// @Summary      Due Tasks
+// @Description  Task yang belum selesai dikelompokkan ke overdue, today dan upcoming menurut zona waktu user
+// @Tags         tasks
+// @Produce      json
+// @Security     BearerAuth
+// @Param        days  query  int  false  "Jangkauan upcoming dalam hari setelah hari ini (default 7, max 90)"
+// @Success      200  {object}  domain.TaskDueBuckets
+// @Failure      400  {object}  map[string]interface{}
+// @Router       /tasks/due [get]
+func (h *TaskHandler) Due(c *gin.Context) {
+    days := 0
+    if raw := c.Query("days"); raw != "" {
+        n, err := strconv.Atoi(raw)
+        if err != nil {
+            c.Error(domain.NewValidationError("days", "must be an integer"))
+            return
+        }
+        days = n
+    }
+
+    buckets, err := h.TaskUseCase.Due(c.Request.Context(), c.MustGet("user_id").(int64), days)
+    if err != nil {
+        c.Error(err)
         return
     }
 
-    c.JSON(http.StatusOK, tasks)
+    c.JSON(http.StatusOK, buckets)
+}
+
+// parseTaskFilter membaca query string GET /tasks menjadi domain.TaskFilter
+func parseTaskFilter(c *gin.Context) (domain.TaskFilter, error) {
+    filter := domain.TaskFilter{
+        Statuses:   splitQueryList(c, "status"),
+        Priorities: splitQueryList(c, "priority"),
+        Labels:     splitQueryList(c, "label"),
+        Cursor:     c.Query("cursor"),
+        Incomplete: c.Query("incomplete") == "true",
+    }
+
+    times := []struct {
+        key string
+        dst **time.Time
+    }{
+        {"created_from", &filter.CreatedFrom},
+        {"created_to", &filter.CreatedTo},
+        {"updated_from", &filter.UpdatedFrom},
+        {"updated_to", &filter.UpdatedTo},
+        {"reminder_from", &filter.ReminderFrom},
+        {"reminder_to", &filter.ReminderTo},
+        {"due_from", &filter.DueFrom},
+        {"due_to", &filter.DueTo},
+    }
+    for _, tp := range times {
+        raw := c.Query(tp.key)
+        if raw == "" {
+            continue
+        }
+        parsed, err := time.Parse(time.RFC3339, raw)
+        if err != nil {
+            return filter, domain.NewValidationError(tp.key, "expected RFC3339 timestamp")
+        }
+        *tp.dst = &parsed
+    }
+
+    switch raw := c.Query("project_id"); raw {
+    case "":
+    case "none":
+        filter.WithoutProject = true
+    default:
+        projectID, err := strconv.ParseInt(raw, 10, 64)
+        if err != nil {
+            return filter, domain.NewValidationError("project_id", "must be an integer or \"none\"")
+        }
+        filter.ProjectID = &projectID
+    }
+
+    switch raw := c.Query("workspace_id"); raw {
+    case "":
+    case "none":
+        filter.PersonalOnly = true
+    default:
+        workspaceID, err := strconv.ParseInt(raw, 10, 64)
+        if err != nil {
+            return filter, domain.NewValidationError("workspace_id", "must be an integer or \"none\"")
+        }
+        filter.WorkspaceID = &workspaceID
+    }
+
+    switch raw := c.Query("assignee"); raw {
+    case "":
+    case "me":
+        me := c.GetInt64("user_id")
+        filter.AssigneeID = &me
+    default:
+        assigneeID, err := strconv.ParseInt(raw, 10, 64)
+        if err != nil {
+            return filter, domain.NewValidationError("assignee", "must be an integer or \"me\"")
+        }
+        filter.AssigneeID = &assigneeID
+    }
+
+    if sort := c.Query("sort"); sort != "" {
+        filter.SortDesc = strings.HasPrefix(sort, "-")
+        filter.SortBy = strings.TrimPrefix(sort, "-")
+    }
+
+    if raw := c.Query("limit"); raw != "" {
+        limit, err := strconv.Atoi(raw)
+        if err != nil || limit < 1 {
+            return filter, domain.NewValidationError("limit", "must be a positive integer")
+        }
+        filter.Limit = limit
+    }
+
+    return filter, nil
+}
+
+// splitQueryList mendukung ?status=a,b maupun ?status=a&status=b
+func splitQueryList(c *gin.Context, key string) []string {
+    var out []string
+    for _, v := range c.QueryArray(key) {
+        for _, part := range strings.Split(v, ",") {
+            if part = strings.TrimSpace(part); part != "" {
+                out = append(out, part)
+            }
+        }
+    }
+    return out
 }
 
 // Update status task
@@ -156,7 +666,7 @@ func (h *TaskHandler) UpdateStatus(c *gin.Context) {
     idParam := c.Param("id")
     id, err := strconv.ParseInt(idParam, 10, 64)
     if err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+        c.Error(domain.NewValidationError("id", "must be an integer"))
         return
     }
 
@@ -166,25 +676,72 @@ func (h *TaskHandler) UpdateStatus(c *gin.Context) {
         Status string `json:"status"`
     }
     if err := c.ShouldBindJSON(&req); err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+        c.Error(domain.NewValidationError("body", "%s", err.Error()))
         return
     }
 
     if err := h.TaskUseCase.UpdateStatus(c.Request.Context(), id, userID, req.Status); err != nil {
         fmt.Printf("\n>>> DEBUG ERROR UPDATE: %v\n\n", err)
-        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        c.Error(err)
         return
     }
 
     c.JSON(http.StatusOK, gin.H{"message": "Task updated"})
 }
 
+// PatchTask godoc
+// @Summary      Edit Task
+// @Description  Mengubah sebagian field task; field yang tidak dikirim tidak diubah, null mengosongkan field opsional.
+// @Description  Untuk task recurring, scope=future mengubah template beserta kemunculan ini & sesudahnya.
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  int               true  "Task ID"
+// @Param        request  body  domain.TaskPatch  true  "Field yang diubah"
+// @Param        scope    query string            false "this (default) atau future"
+// @Success      200  {object}  domain.Task
+// @Failure      400  {object}  map[string]interface{}
+// @Router       /tasks/{id} [patch]
+func (h *TaskHandler) Patch(c *gin.Context) {
+    idParam := c.Param("id")
+    id, err := strconv.ParseInt(idParam, 10, 64)
+    if err != nil {
+        c.Error(domain.NewValidationError("id", "must be an integer"))
+        return
+    }
+
+    userID := c.MustGet("user_id").(int64)
+
+    var patch domain.TaskPatch
+    if err := c.ShouldBindJSON(&patch); err != nil {
+        c.Error(domain.NewValidationError("body", "%s", err.Error()))
+        return
+    }
+
+    var task *domain.Task
+    switch c.DefaultQuery("scope", domain.EditScopeThis) {
+    case domain.EditScopeThis:
+        task, err = h.TaskUseCase.Patch(c.Request.Context(), id, userID, patch)
+    case domain.EditScopeFuture:
+        task, err = h.TaskUseCase.PatchFuture(c.Request.Context(), id, userID, patch)
+    default:
+        err = domain.NewValidationError("scope", "must be one of this, future")
+    }
+    if err != nil {
+        c.Error(err)
+        return
+    }
+
+    c.JSON(http.StatusOK, task)
+}
+
 // Delete task
 func (h *TaskHandler) Delete(c *gin.Context) {
     idParam := c.Param("id")
     id, err := strconv.ParseInt(idParam, 10, 64)
     if err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid task ID"})
+        c.Error(domain.NewValidationError("id", "must be an integer"))
         return
     }
 
@@ -192,38 +749,122 @@ func (h *TaskHandler) Delete(c *gin.Context) {
 
     if err := h.TaskUseCase.Delete(c.Request.Context(), id, userID); err != nil {
         fmt.Printf("\n>>> DEBUG ERROR DELETE: %v\n\n", err)
-        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        c.Error(err)
         return
     }
 
     c.JSON(http.StatusOK, gin.H{"message": "Task deleted"})
 }
 
+// --- ASSIGNEE HANDLERS ---
+
+// AssignTask godoc
+// @Summary      Assign Task
+// @Description  Menambahkan assignee. Assignee harus bisa melihat task (anggota workspace, atau pembuatnya untuk task pribadi).
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  int                    true  "Task ID"
+// @Param        request  body  object{user_id=int}    true  "User yang di-assign"
+// @Success      200  {object}  domain.Task
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]interface{}
+// @Router       /tasks/{id}/assignees [post]
+func (h *TaskHandler) Assign(c *gin.Context) {
+    taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+    if err != nil {
+        c.Error(domain.NewValidationError("id", "must be an integer"))
+        return
+    }
+
+    var req struct {
+        UserID int64 `json:"user_id" binding:"required"`
+    }
+    if err := c.ShouldBindJSON(&req); err != nil {
+        c.Error(domain.NewValidationError("user_id", "is required"))
+        return
+    }
+
+    task, err := h.TaskUseCase.Assign(c.Request.Context(), taskID, c.MustGet("user_id").(int64), req.UserID)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+
+    c.JSON(http.StatusOK, task)
+}
+
+// UnassignTask godoc
+// @Summary      Unassign Task
+// @Description  Melepas assignee; assignee boleh melepas dirinya sendiri
+// @Tags         tasks
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  int  true  "Task ID"
+// @Param        user_id  path  int  true  "User yang dilepas"
+// @Success      200  {object}  domain.Task
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /tasks/{id}/assignees/{user_id} [delete]
+func (h *TaskHandler) Unassign(c *gin.Context) {
+    taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+    if err != nil {
+        c.Error(domain.NewValidationError("id", "must be an integer"))
+        return
+    }
+    assigneeID, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
+    if err != nil {
+        c.Error(domain.NewValidationError("user_id", "must be an integer"))
+        return
+    }
+
+    task, err := h.TaskUseCase.Unassign(c.Request.Context(), taskID, c.MustGet("user_id").(int64), assigneeID)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+
+    c.JSON(http.StatusOK, task)
+}
+
 // --- SUBTASK HANDLERS ---
 
 func (h *TaskHandler) AddSubtask(c *gin.Context) {
     idParam := c.Param("id") // Task ID
-    taskID, _ := strconv.ParseInt(idParam, 10, 64)
+    taskID, err := strconv.ParseInt(idParam, 10, 64)
+    if err != nil {
+        c.Error(domain.NewValidationError("id", "must be an integer"))
+        return
+    }
+
+    userID := c.MustGet("user_id").(int64)
 
     var req struct{ Title string `json:"title"` }
     if err := c.ShouldBindJSON(&req); err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+        c.Error(domain.NewValidationError("body", "%s", err.Error()))
         return
     }
 
-    if err := h.TaskUseCase.AddSubtask(c.Request.Context(), taskID, req.Title); err != nil {
-        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+    sub, err := h.TaskUseCase.AddSubtask(c.Request.Context(), taskID, userID, req.Title)
+    if err != nil {
+        c.Error(err)
         return
     }
-    c.JSON(http.StatusOK, gin.H{"message": "Subtask added"})
+    c.JSON(http.StatusOK, gin.H{"message": "Subtask added", "subtask": sub})
 }
 
 func (h *TaskHandler) ToggleSubtask(c *gin.Context) {
     idParam := c.Param("sub_id")
-    subID, _ := strconv.ParseInt(idParam, 10, 64)
+    subID, err := strconv.ParseInt(idParam, 10, 64)
+    if err != nil {
+        c.Error(domain.NewValidationError("sub_id", "must be an integer"))
+        return
+    }
 
-    if err := h.TaskUseCase.ToggleSubtask(c.Request.Context(), subID); err != nil {
-        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+    userID := c.MustGet("user_id").(int64)
+
+    if err := h.TaskUseCase.ToggleSubtask(c.Request.Context(), subID, userID); err != nil {
+        c.Error(err)
         return
     }
     c.JSON(http.StatusOK, gin.H{"message": "Toggled"})
@@ -231,10 +872,16 @@ func (h *TaskHandler) ToggleSubtask(c *gin.Context) {
 
 func (h *TaskHandler) DeleteSubtask(c *gin.Context) {
     idParam := c.Param("sub_id")
-    subID, _ := strconv.ParseInt(idParam, 10, 64)
+    subID, err := strconv.ParseInt(idParam, 10, 64)
+    if err != nil {
+        c.Error(domain.NewValidationError("sub_id", "must be an integer"))
+        return
+    }
+
+    userID := c.MustGet("user_id").(int64)
 
-    if err := h.TaskUseCase.DeleteSubtask(c.Request.Context(), subID); err != nil {
-        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+    if err := h.TaskUseCase.DeleteSubtask(c.Request.Context(), subID, userID); err != nil {
+        c.Error(err)
         return
     }
     c.JSON(http.StatusOK, gin.H{"message": "Deleted"})
@@ -0,0 +1,121 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"simple-task-manager/internal/core/domain"
+	"simple-task-manager/internal/core/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type JobHandler struct {
+	JobUseCase *usecase.JobUsecase
+}
+
+// ListJobs godoc
+// @Summary      List Background Jobs
+// @Description  Khusus admin (ADMIN_EMAILS). Terbaru lebih dulu; pakai next_cursor untuk halaman berikutnya. Body email (email.send) disensor.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        type    query  string  false  "Tipe job, mis. email.send"
+// @Param        status  query  string  false  "pending, running, done atau dead"
+// @Param        limit   query  int     false  "Maksimal 200 (default 50)"
+// @Param        cursor  query  string  false  "next_cursor dari halaman sebelumnya"
+// @Success      200  {object}  domain.JobPage
+// @Failure      403  {object}  map[string]interface{}
+// @Router       /admin/jobs [get]
+func (h *JobHandler) List(c *gin.Context) {
+	filter := domain.JobFilter{
+		Type:   c.Query("type"),
+		Status: c.Query("status"),
+		Cursor: c.Query("cursor"),
+	}
+	if raw := c.Query("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil {
+			c.Error(domain.NewValidationError("limit", "must be an integer"))
+			return
+		}
+		filter.Limit = limit
+	}
+
+	page, err := h.JobUseCase.List(c.Request.Context(), c.MustGet("user_id").(int64), filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// JobStats godoc
+// @Summary      Background Job Counts
+// @Description  Jumlah job per tipe & status. Khusus admin.
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}  domain.JobCount
+// @Router       /admin/jobs/stats [get]
+func (h *JobHandler) Stats(c *gin.Context) {
+	counts, err := h.JobUseCase.Stats(c.Request.Context(), c.MustGet("user_id").(int64))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, counts)
+}
+
+// GetJob godoc
+// @Summary      Get Background Job
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Job ID"
+// @Success      200  {object}  domain.Job
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /admin/jobs/{id} [get]
+func (h *JobHandler) Get(c *gin.Context) {
+	id, err := int64Param(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	job, err := h.JobUseCase.Get(c.Request.Context(), c.MustGet("user_id").(int64), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+// RetryJob godoc
+// @Summary      Retry Dead Job
+// @Description  Mengantrikan ulang job dari dead letter dengan jatah percobaan penuh
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Job ID"
+// @Success      200  {object}  domain.Job
+// @Failure      409  {object}  map[string]interface{}
+// @Router       /admin/jobs/{id}/retry [post]
+func (h *JobHandler) Retry(c *gin.Context) {
+	id, err := int64Param(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	job, err := h.JobUseCase.Retry(c.Request.Context(), c.MustGet("user_id").(int64), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
@@ -0,0 +1,108 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"simple-task-manager/internal/core/domain"
+	"simple-task-manager/internal/core/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationHandler struct {
+	NotificationUseCase *usecase.NotificationUsecase
+}
+
+// ListNotifications godoc
+// @Summary      List Notifications
+// @Description  Inbox notifikasi in-app, terbaru lebih dulu, dengan cursor pagination
+// @Tags         notifications
+// @Produce      json
+// @Security     BearerAuth
+// @Param        unread  query  bool    false  "true untuk hanya yang belum dibaca"
+// @Param        limit   query  int     false  "Jumlah per halaman (default 20, max 100)"
+// @Param        cursor  query  string  false  "next_cursor dari halaman sebelumnya"
+// @Success      200  {object}  domain.NotificationPage
+// @Failure      400  {object}  map[string]interface{}
+// @Router       /notifications [get]
+func (h *NotificationHandler) List(c *gin.Context) {
+	filter := domain.NotificationFilter{
+		UserID:     c.MustGet("user_id").(int64),
+		UnreadOnly: c.Query("unread") == "true",
+		Cursor:     c.Query("cursor"),
+	}
+	if raw := c.Query("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil {
+			c.Error(domain.NewValidationError("limit", "must be an integer"))
+			return
+		}
+		filter.Limit = limit
+	}
+
+	page, err := h.NotificationUseCase.List(c.Request.Context(), filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// UnreadNotificationCount godoc
+// @Summary      Unread Count
+// @Tags         notifications
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  object{unread=int}
+// @Router       /notifications/unread-count [get]
+func (h *NotificationHandler) UnreadCount(c *gin.Context) {
+	count, err := h.NotificationUseCase.UnreadCount(c.Request.Context(), c.MustGet("user_id").(int64))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"unread": count})
+}
+
+// MarkNotificationRead godoc
+// @Summary      Mark Notification Read
+// @Tags         notifications
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Notification ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /notifications/{id}/read [post]
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	id, err := int64Param(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.NotificationUseCase.MarkRead(c.Request.Context(), id, c.MustGet("user_id").(int64)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Notification marked as read"})
+}
+
+// MarkAllNotificationsRead godoc
+// @Summary      Mark All Notifications Read
+// @Tags         notifications
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  object{updated=int}
+// @Router       /notifications/read-all [post]
+func (h *NotificationHandler) MarkAllRead(c *gin.Context) {
+	updated, err := h.NotificationUseCase.MarkAllRead(c.Request.Context(), c.MustGet("user_id").(int64))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"updated": updated})
+}
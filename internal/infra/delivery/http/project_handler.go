@@ -0,0 +1,168 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"simple-task-manager/internal/core/domain"
+	"simple-task-manager/internal/core/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProjectHandler struct {
+	ProjectUseCase *usecase.ProjectUsecase
+}
+
+func projectIDParam(c *gin.Context) (int64, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return 0, domain.NewValidationError("id", "must be an integer")
+	}
+	return id, nil
+}
+
+// CreateProject godoc
+// @Summary      Create Project
+// @Description  Membuat project (task list) baru. default_priority & default_status dipakai untuk task baru di project ini.
+// @Tags         projects
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body object{name=string,description=string,default_priority=string,default_status=string,workspace_id=int} true "Project Data"
+// @Success      201  {object}  domain.Project
+// @Failure      400  {object}  map[string]interface{}
+// @Router       /projects [post]
+func (h *ProjectHandler) Create(c *gin.Context) {
+	var req struct {
+		Name            string `json:"name"`
+		Description     string `json:"description"`
+		DefaultPriority string `json:"default_priority"`
+		DefaultStatus   string `json:"default_status"`
+		WorkspaceID     *int64 `json:"workspace_id"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("body", "%s", err.Error()))
+		return
+	}
+
+	project := domain.Project{
+		UserID:          c.MustGet("user_id").(int64),
+		WorkspaceID:     req.WorkspaceID,
+		Name:            req.Name,
+		Description:     req.Description,
+		DefaultPriority: req.DefaultPriority,
+		DefaultStatus:   req.DefaultStatus,
+	}
+
+	if err := h.ProjectUseCase.Create(c.Request.Context(), &project); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, project)
+}
+
+// ListProjects godoc
+// @Summary      List Projects
+// @Description  Mengambil project pribadi user dan project di workspace tempat ia menjadi anggota; project yang diarsipkan hanya ikut jika archived=true
+// @Tags         projects
+// @Produce      json
+// @Security     BearerAuth
+// @Param        archived  query  bool  false  "Sertakan project yang diarsipkan"
+// @Success      200  {array}  domain.Project
+// @Router       /projects [get]
+func (h *ProjectHandler) List(c *gin.Context) {
+	userID := c.MustGet("user_id").(int64)
+	includeArchived := c.Query("archived") == "true"
+
+	projects, err := h.ProjectUseCase.List(c.Request.Context(), userID, includeArchived)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, projects)
+}
+
+// GetProject godoc
+// @Summary      Get Project
+// @Tags         projects
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Project ID"
+// @Success      200  {object}  domain.Project
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /projects/{id} [get]
+func (h *ProjectHandler) Get(c *gin.Context) {
+	id, err := projectIDParam(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	project, err := h.ProjectUseCase.Get(c.Request.Context(), id, c.MustGet("user_id").(int64))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, project)
+}
+
+// PatchProject godoc
+// @Summary      Update Project
+// @Description  Perubahan parsial; kirim archived=true untuk mengarsipkan atau false untuk membuka arsip
+// @Tags         projects
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  int                  true  "Project ID"
+// @Param        request  body  domain.ProjectPatch  true  "Field yang diubah"
+// @Success      200  {object}  domain.Project
+// @Failure      400  {object}  map[string]interface{}
+// @Router       /projects/{id} [patch]
+func (h *ProjectHandler) Patch(c *gin.Context) {
+	id, err := projectIDParam(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var patch domain.ProjectPatch
+	if err := c.ShouldBindJSON(&patch); err != nil {
+		c.Error(domain.NewValidationError("body", "%s", err.Error()))
+		return
+	}
+
+	project, err := h.ProjectUseCase.Patch(c.Request.Context(), id, c.MustGet("user_id").(int64), patch)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, project)
+}
+
+// DeleteProject godoc
+// @Summary      Delete Project
+// @Description  Menghapus project; task di dalamnya tetap ada tanpa project
+// @Tags         projects
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Project ID"
+// @Success      200  {object}  map[string]interface{}
+// @Router       /projects/{id} [delete]
+func (h *ProjectHandler) Delete(c *gin.Context) {
+	id, err := projectIDParam(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.ProjectUseCase.Delete(c.Request.Context(), id, c.MustGet("user_id").(int64)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Project deleted"})
+}
@@ -0,0 +1,102 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"simple-task-manager/internal/infra/stream"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StreamHandler struct {
+	Hub       *stream.Hub
+	Heartbeat time.Duration // komentar ping supaya proxy tidak menutup koneksi idle
+}
+
+// writeSSE menulis satu event dalam format text/event-stream
+func writeSSE(w io.Writer, id string, event string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if id != "" {
+		if _, err := fmt.Fprintf(w, "id: %s\n", id); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload)
+	return err
+}
+
+// writeMessage mengirim event tanpa daftar penerimanya
+func writeMessage(w io.Writer, msg stream.Message) error {
+	e := msg.Event
+	e.Recipients = nil
+	return writeSSE(w, msg.ID, e.Type, e)
+}
+
+// StreamEvents godoc
+// @Summary      Real-time Event Stream
+// @Description  Server-Sent Events berisi perubahan task, subtask dan notifikasi yang terlihat oleh user. Kirim header Last-Event-ID (atau query last_event_id) untuk melanjutkan setelah koneksi putus; event "reset" berarti riwayat tidak tersedia dan client harus refetch.
+// @Tags         stream
+// @Produce      text/event-stream
+// @Security     BearerAuth
+// @Param        Last-Event-ID  header  string  false  "ID event terakhir yang diterima"
+// @Param        last_event_id  query   string  false  "Alternatif header Last-Event-ID"
+// @Success      200  {string}  string  "text/event-stream"
+// @Router       /stream [get]
+func (h *StreamHandler) Stream(c *gin.Context) {
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+
+	sub, backlog, reset := h.Hub.Subscribe(c.MustGet("user_id").(int64), lastEventID)
+	defer h.Hub.Unsubscribe(sub)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	w := c.Writer
+	fmt.Fprint(w, "retry: 3000\n\n")
+	if reset {
+		writeSSE(w, "", "reset", gin.H{"reason": "event history is unavailable, refetch state"})
+	}
+	for _, msg := range backlog {
+		if err := writeMessage(w, msg); err != nil {
+			return
+		}
+	}
+	w.Flush()
+
+	heartbeat := time.NewTicker(h.Heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case msg, ok := <-sub.C:
+			if !ok {
+				// diputus hub karena tertinggal; client reconnect dengan Last-Event-ID
+				return
+			}
+			if err := writeMessage(w, msg); err != nil {
+				return
+			}
+			w.Flush()
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+			w.Flush()
+		}
+	}
+}
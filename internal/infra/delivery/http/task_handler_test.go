@@ -0,0 +1,114 @@
+package http_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+	"simple-task-manager/internal/core/usecase"
+	"simple-task-manager/internal/core/usecase/mocks"
+	handler "simple-task-manager/internal/infra/delivery/http"
+	"simple-task-manager/internal/infra/delivery/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// newSubtaskRouter menyiapkan route subtask dengan user_id yang sudah "login"
+func newSubtaskRouter(taskRepo *mocks.TaskRepository, userID int64) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	u := usecase.NewTaskUsecase(taskRepo, new(mocks.StatusRepository), new(mocks.ProjectRepository), new(mocks.WorkspaceRepository), new(mocks.UserRepository), new(mocks.TaskReminderRepository), 2*time.Second)
+	h := &handler.TaskHandler{TaskUseCase: u}
+
+	r := gin.New()
+	r.Use(middleware.ErrorHandler())
+	r.Use(func(c *gin.Context) {
+		c.Set("user_id", userID)
+		c.Next()
+	})
+	r.POST("/tasks/:id/subtasks", h.AddSubtask)
+	r.PUT("/subtasks/:sub_id", h.ToggleSubtask)
+	r.DELETE("/subtasks/:sub_id", h.DeleteSubtask)
+	return r
+}
+
+func TestSubtaskOwnership(t *testing.T) {
+	const owner, intruder = int64(1), int64(2)
+
+	t.Run("Owner Can Toggle", func(t *testing.T) {
+		repo := new(mocks.TaskRepository)
+		repo.On("GetSubtaskTaskID", mock.Anything, int64(5)).Return(int64(7), nil).Once()
+		repo.On("GetByID", mock.Anything, int64(7)).Return(&domain.Task{ID: 7, UserID: owner}, nil).Once()
+		repo.On("ToggleSubtask", mock.Anything, int64(5), mock.Anything).Return(nil).Once()
+
+		w := httptest.NewRecorder()
+		newSubtaskRouter(repo, owner).ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/subtasks/5", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Other User Cannot Toggle", func(t *testing.T) {
+		repo := new(mocks.TaskRepository)
+		repo.On("GetSubtaskTaskID", mock.Anything, int64(5)).Return(int64(7), nil).Once()
+		repo.On("GetByID", mock.Anything, int64(7)).Return(&domain.Task{ID: 7, UserID: owner}, nil).Once()
+
+		w := httptest.NewRecorder()
+		newSubtaskRouter(repo, intruder).ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/subtasks/5", nil))
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		repo.AssertNotCalled(t, "ToggleSubtask", mock.Anything, int64(5))
+	})
+
+	t.Run("Other User Cannot Delete", func(t *testing.T) {
+		repo := new(mocks.TaskRepository)
+		repo.On("GetSubtaskTaskID", mock.Anything, int64(5)).Return(int64(7), nil).Once()
+		repo.On("GetByID", mock.Anything, int64(7)).Return(&domain.Task{ID: 7, UserID: owner}, nil).Once()
+
+		w := httptest.NewRecorder()
+		newSubtaskRouter(repo, intruder).ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/subtasks/5", nil))
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		repo.AssertNotCalled(t, "DeleteSubtask", mock.Anything, int64(5), mock.Anything)
+	})
+
+	t.Run("Missing Subtask Returns 404", func(t *testing.T) {
+		repo := new(mocks.TaskRepository)
+		repo.On("GetSubtaskTaskID", mock.Anything, int64(99)).Return(int64(0), domain.ErrNotFound).Once()
+
+		w := httptest.NewRecorder()
+		newSubtaskRouter(repo, intruder).ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/subtasks/99", nil))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Other User Cannot Add Subtask", func(t *testing.T) {
+		repo := new(mocks.TaskRepository)
+		repo.On("GetByID", mock.Anything, int64(7)).Return(&domain.Task{ID: 7, UserID: owner}, nil).Once()
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/tasks/7/subtasks", strings.NewReader(`{"title":"hack"}`))
+		req.Header.Set("Content-Type", "application/json")
+		newSubtaskRouter(repo, intruder).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		repo.AssertNotCalled(t, "CreateSubtask", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Add Subtask To Missing Task Returns 404", func(t *testing.T) {
+		repo := new(mocks.TaskRepository)
+		repo.On("GetByID", mock.Anything, int64(8)).Return((*domain.Task)(nil), nil).Once()
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/tasks/8/subtasks", strings.NewReader(`{"title":"x"}`))
+		req.Header.Set("Content-Type", "application/json")
+		newSubtaskRouter(repo, intruder).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,52 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PreviewRecurrence godoc
+// @Summary      Preview Recurrence Rule
+// @Description  Memvalidasi RRULE (FREQ, INTERVAL, BYDAY, BYMONTHDAY, COUNT, UNTIL, atau alias daily/weekly/monthly/yearly/weekdays) dan menampilkan kemunculan berikutnya di zona waktu user
+// @Tags         tasks
+// @Produce      json
+// @Security     BearerAuth
+// @Param        rule   query  string  true   "RRULE, mis. FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE"
+// @Param        start  query  string  false  "RFC3339; kemunculan pertama pada atau setelah waktu ini (default sekarang)"
+// @Param        count  query  int     false  "Jumlah kemunculan (default 5, max 50)"
+// @Success      200  {object}  usecase.RecurrencePreview
+// @Failure      400  {object}  map[string]interface{}
+// @Router       /tasks/recurrence/preview [get]
+func (h *TaskHandler) PreviewRecurrence(c *gin.Context) {
+	var start *time.Time
+	if raw := c.Query("start"); raw != "" {
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			c.Error(domain.NewValidationError("start", "must be an RFC3339 timestamp"))
+			return
+		}
+		start = &t
+	}
+	count := 0
+	if raw := c.Query("count"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			c.Error(domain.NewValidationError("count", "must be an integer"))
+			return
+		}
+		count = n
+	}
+
+	preview, err := h.TaskUseCase.PreviewRecurrence(c.Request.Context(), c.MustGet("user_id").(int64), c.Query("rule"), start, count)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, preview)
+}
@@ -0,0 +1,138 @@
+package http
+
+import (
+	"net/http"
+
+	"simple-task-manager/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListTaskReminders godoc
+// @Summary      List Task Reminders
+// @Tags         tasks
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Task ID"
+// @Success      200  {array}   domain.TaskReminder
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /tasks/{id}/reminders [get]
+func (h *TaskHandler) ListReminders(c *gin.Context) {
+	taskID, err := int64Param(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	reminders, err := h.TaskUseCase.ListReminders(c.Request.Context(), taskID, c.MustGet("user_id").(int64))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reminders)
+}
+
+// AddTaskReminder godoc
+// @Summary      Add Task Reminder
+// @Description  Isi remind_at untuk reminder absolut, atau offset_minutes (menit sebelum tenggat) untuk reminder relatif yang ikut bergeser saat due_at berubah
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  int                       true  "Task ID"
+// @Param        request  body  domain.TaskReminderInput  true  "Reminder"
+// @Success      201  {object}  domain.TaskReminder
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      409  {object}  map[string]interface{}
+// @Router       /tasks/{id}/reminders [post]
+func (h *TaskHandler) AddReminder(c *gin.Context) {
+	taskID, err := int64Param(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req domain.TaskReminderInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("body", "%s", err.Error()))
+		return
+	}
+
+	reminder, err := h.TaskUseCase.AddReminder(c.Request.Context(), taskID, c.MustGet("user_id").(int64), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, reminder)
+}
+
+// UpdateTaskReminder godoc
+// @Summary      Replace Task Reminder
+// @Description  Mengganti definisi reminder; bisa berpindah antara absolut dan relatif
+// @Tags         tasks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id           path  int                       true  "Task ID"
+// @Param        reminder_id  path  int                       true  "Reminder ID"
+// @Param        request      body  domain.TaskReminderInput  true  "Reminder"
+// @Success      200  {object}  domain.TaskReminder
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /tasks/{id}/reminders/{reminder_id} [put]
+func (h *TaskHandler) UpdateReminder(c *gin.Context) {
+	taskID, err := int64Param(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	reminderID, err := int64Param(c, "reminder_id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req domain.TaskReminderInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("body", "%s", err.Error()))
+		return
+	}
+
+	reminder, err := h.TaskUseCase.UpdateReminder(c.Request.Context(), taskID, reminderID, c.MustGet("user_id").(int64), req)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reminder)
+}
+
+// DeleteTaskReminder godoc
+// @Summary      Delete Task Reminder
+// @Tags         tasks
+// @Security     BearerAuth
+// @Param        id           path  int  true  "Task ID"
+// @Param        reminder_id  path  int  true  "Reminder ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /tasks/{id}/reminders/{reminder_id} [delete]
+func (h *TaskHandler) DeleteReminder(c *gin.Context) {
+	taskID, err := int64Param(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	reminderID, err := int64Param(c, "reminder_id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.TaskUseCase.DeleteReminder(c.Request.Context(), taskID, reminderID, c.MustGet("user_id").(int64)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Reminder deleted"})
+}
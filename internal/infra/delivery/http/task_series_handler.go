@@ -0,0 +1,47 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"simple-task-manager/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Series godoc
+// @Summary      Recurring Series
+// @Description  Menampilkan template recurring, instance yang sudah dibuat, dan kemunculan berikutnya. id boleh template maupun instance.
+// @Tags         tasks
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id        path   int  true   "Task ID"
+// @Param        upcoming  query  int  false  "Jumlah kemunculan berikutnya (default 5, max 50)"
+// @Success      200  {object}  domain.TaskSeries
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /tasks/{id}/series [get]
+func (h *TaskHandler) Series(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.Error(domain.NewValidationError("id", "must be an integer"))
+		return
+	}
+	upcoming := 0
+	if raw := c.Query("upcoming"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			c.Error(domain.NewValidationError("upcoming", "must be an integer"))
+			return
+		}
+		upcoming = n
+	}
+
+	series, err := h.TaskUseCase.Series(c.Request.Context(), id, c.MustGet("user_id").(int64), upcoming)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, series)
+}
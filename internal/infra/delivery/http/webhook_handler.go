@@ -0,0 +1,203 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"simple-task-manager/internal/core/domain"
+	"simple-task-manager/internal/core/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookHandler struct {
+	WebhookUseCase *usecase.WebhookUsecase
+}
+
+// CreateWebhook godoc
+// @Summary      Create Webhook
+// @Description  Mendaftarkan URL yang menerima event secara POST. Isi workspace_id untuk webhook workspace (admin/owner). Secret penandatangan HMAC hanya ditampilkan sekali.
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body  domain.WebhookInput  true  "Webhook Data"
+// @Success      201  {object}  usecase.CreatedWebhook
+// @Failure      400  {object}  map[string]interface{}
+// @Router       /webhooks [post]
+func (h *WebhookHandler) Create(c *gin.Context) {
+	var in domain.WebhookInput
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.Error(domain.NewValidationError("body", "%s", err.Error()))
+		return
+	}
+
+	created, err := h.WebhookUseCase.Create(c.Request.Context(), c.MustGet("user_id").(int64), in)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+// ListWebhooks godoc
+// @Summary      List Webhooks
+// @Description  Webhook pribadi user, atau webhook sebuah workspace jika workspace_id diisi
+// @Tags         webhooks
+// @Produce      json
+// @Security     BearerAuth
+// @Param        workspace_id  query  int  false  "Workspace ID"
+// @Success      200  {array}  domain.Webhook
+// @Router       /webhooks [get]
+func (h *WebhookHandler) List(c *gin.Context) {
+	var workspaceID *int64
+	if raw := c.Query("workspace_id"); raw != "" {
+		id, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			c.Error(domain.NewValidationError("workspace_id", "must be an integer"))
+			return
+		}
+		workspaceID = &id
+	}
+
+	hooks, err := h.WebhookUseCase.List(c.Request.Context(), c.MustGet("user_id").(int64), workspaceID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, hooks)
+}
+
+// GetWebhook godoc
+// @Summary      Get Webhook
+// @Tags         webhooks
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Webhook ID"
+// @Success      200  {object}  domain.Webhook
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /webhooks/{id} [get]
+func (h *WebhookHandler) Get(c *gin.Context) {
+	id, err := int64Param(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	hook, err := h.WebhookUseCase.Get(c.Request.Context(), id, c.MustGet("user_id").(int64))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, hook)
+}
+
+// PatchWebhook godoc
+// @Summary      Update Webhook
+// @Description  Perubahan parsial; kirim active=false untuk menghentikan pengiriman sementara
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  int                  true  "Webhook ID"
+// @Param        request  body  domain.WebhookPatch  true  "Field yang diubah"
+// @Success      200  {object}  domain.Webhook
+// @Failure      400  {object}  map[string]interface{}
+// @Router       /webhooks/{id} [patch]
+func (h *WebhookHandler) Patch(c *gin.Context) {
+	id, err := int64Param(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var patch domain.WebhookPatch
+	if err := c.ShouldBindJSON(&patch); err != nil {
+		c.Error(domain.NewValidationError("body", "%s", err.Error()))
+		return
+	}
+
+	hook, err := h.WebhookUseCase.Patch(c.Request.Context(), id, c.MustGet("user_id").(int64), patch)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, hook)
+}
+
+// DeleteWebhook godoc
+// @Summary      Delete Webhook
+// @Description  Menghapus webhook beserta log pengirimannya
+// @Tags         webhooks
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Webhook ID"
+// @Success      200  {object}  map[string]interface{}
+// @Router       /webhooks/{id} [delete]
+func (h *WebhookHandler) Delete(c *gin.Context) {
+	id, err := int64Param(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.WebhookUseCase.Delete(c.Request.Context(), id, c.MustGet("user_id").(int64)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Webhook deleted successfully"})
+}
+
+// ListWebhookDeliveries godoc
+// @Summary      List Webhook Deliveries
+// @Description  Log pengiriman terbaru (maks 50): status, jumlah percobaan, response HTTP dan error terakhir
+// @Tags         webhooks
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Webhook ID"
+// @Success      200  {array}  domain.WebhookDelivery
+// @Router       /webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	id, err := int64Param(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	deliveries, err := h.WebhookUseCase.ListDeliveries(c.Request.Context(), id, c.MustGet("user_id").(int64))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, deliveries)
+}
+
+// PingWebhook godoc
+// @Summary      Ping Webhook
+// @Description  Mengirim event "ping" bertanda tangan secara langsung (tanpa retry) dan mengembalikan hasilnya
+// @Tags         webhooks
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Webhook ID"
+// @Success      200  {object}  domain.WebhookDelivery
+// @Router       /webhooks/{id}/ping [post]
+func (h *WebhookHandler) Ping(c *gin.Context) {
+	id, err := int64Param(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	delivery, err := h.WebhookUseCase.Ping(c.Request.Context(), id, c.MustGet("user_id").(int64))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, delivery)
+}
@@ -0,0 +1,80 @@
+package http
+
+import (
+	"net/http"
+
+	"simple-task-manager/internal/core/domain"
+	"simple-task-manager/internal/core/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WorkflowHandler struct {
+	WorkflowUseCase *usecase.WorkflowUsecase
+}
+
+// GetWorkflow godoc
+// @Summary      Get Status Workflow
+// @Description  Mengambil daftar status (urut sesuai kolom kanban) dan transisi yang diizinkan
+// @Tags         statuses
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  domain.Workflow
+// @Router       /statuses [get]
+func (h *WorkflowHandler) Get(c *gin.Context) {
+	userID := c.MustGet("user_id").(int64)
+
+	wf, err := h.WorkflowUseCase.Get(c.Request.Context(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, wf)
+}
+
+// SaveWorkflow godoc
+// @Summary      Replace Status Workflow
+// @Description  Mengganti status custom dan transisinya; urutan status menentukan posisi kolom kanban
+// @Tags         statuses
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body domain.Workflow true "Workflow"
+// @Success      200  {object}  domain.Workflow
+// @Failure      400  {object}  map[string]interface{}
+// @Router       /statuses [put]
+func (h *WorkflowHandler) Save(c *gin.Context) {
+	userID := c.MustGet("user_id").(int64)
+
+	var wf domain.Workflow
+	if err := c.ShouldBindJSON(&wf); err != nil {
+		c.Error(domain.NewValidationError("body", "%s", err.Error()))
+		return
+	}
+
+	if err := h.WorkflowUseCase.Save(c.Request.Context(), userID, &wf); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, wf)
+}
+
+// ResetWorkflow godoc
+// @Summary      Reset Status Workflow
+// @Description  Menghapus workflow custom sehingga user kembali memakai pending / in_progress / done
+// @Tags         statuses
+// @Security     BearerAuth
+// @Success      200  {object}  map[string]interface{}
+// @Router       /statuses [delete]
+func (h *WorkflowHandler) Reset(c *gin.Context) {
+	userID := c.MustGet("user_id").(int64)
+
+	if err := h.WorkflowUseCase.Reset(c.Request.Context(), userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Workflow reset to default"})
+}
@@ -0,0 +1,372 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"simple-task-manager/internal/core/domain"
+	"simple-task-manager/internal/core/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WorkspaceHandler struct {
+	WorkspaceUseCase *usecase.WorkspaceUsecase
+}
+
+// int64Param membaca path param numerik; nama param dipakai sebagai field error
+func int64Param(c *gin.Context, name string) (int64, error) {
+	id, err := strconv.ParseInt(c.Param(name), 10, 64)
+	if err != nil {
+		return 0, domain.NewValidationError(name, "must be an integer")
+	}
+	return id, nil
+}
+
+// CreateWorkspace godoc
+// @Summary      Create Workspace
+// @Description  Membuat workspace tim; pembuatnya menjadi owner
+// @Tags         workspaces
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body object{name=string} true "Workspace Data"
+// @Success      201  {object}  domain.Workspace
+// @Failure      400  {object}  map[string]interface{}
+// @Router       /workspaces [post]
+func (h *WorkspaceHandler) Create(c *gin.Context) {
+	var req struct {
+		Name string `json:"name"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("body", "%s", err.Error()))
+		return
+	}
+
+	ws, err := h.WorkspaceUseCase.Create(c.Request.Context(), c.MustGet("user_id").(int64), req.Name)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, ws)
+}
+
+// ListWorkspaces godoc
+// @Summary      List Workspaces
+// @Description  Mengambil workspace tempat user menjadi anggota, beserta role-nya
+// @Tags         workspaces
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}  domain.Workspace
+// @Router       /workspaces [get]
+func (h *WorkspaceHandler) List(c *gin.Context) {
+	workspaces, err := h.WorkspaceUseCase.List(c.Request.Context(), c.MustGet("user_id").(int64))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, workspaces)
+}
+
+// GetWorkspace godoc
+// @Summary      Get Workspace
+// @Tags         workspaces
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Workspace ID"
+// @Success      200  {object}  domain.Workspace
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /workspaces/{id} [get]
+func (h *WorkspaceHandler) Get(c *gin.Context) {
+	id, err := int64Param(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	ws, err := h.WorkspaceUseCase.Get(c.Request.Context(), id, c.MustGet("user_id").(int64))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ws)
+}
+
+// RenameWorkspace godoc
+// @Summary      Rename Workspace
+// @Description  Mengganti nama workspace (admin atau owner)
+// @Tags         workspaces
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  int                     true  "Workspace ID"
+// @Param        request  body  object{name=string}     true  "Nama baru"
+// @Success      200  {object}  domain.Workspace
+// @Failure      403  {object}  map[string]interface{}
+// @Router       /workspaces/{id} [patch]
+func (h *WorkspaceHandler) Rename(c *gin.Context) {
+	id, err := int64Param(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req struct {
+		Name string `json:"name"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("body", "%s", err.Error()))
+		return
+	}
+
+	ws, err := h.WorkspaceUseCase.Rename(c.Request.Context(), id, c.MustGet("user_id").(int64), req.Name)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ws)
+}
+
+// DeleteWorkspace godoc
+// @Summary      Delete Workspace
+// @Description  Menghapus workspace beserta semua task dan project di dalamnya (hanya owner)
+// @Tags         workspaces
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Workspace ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]interface{}
+// @Router       /workspaces/{id} [delete]
+func (h *WorkspaceHandler) Delete(c *gin.Context) {
+	id, err := int64Param(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.WorkspaceUseCase.Delete(c.Request.Context(), id, c.MustGet("user_id").(int64)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Workspace deleted"})
+}
+
+// ListWorkspaceMembers godoc
+// @Summary      List Workspace Members
+// @Tags         workspaces
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Workspace ID"
+// @Success      200  {array}  domain.WorkspaceMember
+// @Router       /workspaces/{id}/members [get]
+func (h *WorkspaceHandler) ListMembers(c *gin.Context) {
+	id, err := int64Param(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	members, err := h.WorkspaceUseCase.ListMembers(c.Request.Context(), id, c.MustGet("user_id").(int64))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, members)
+}
+
+// UpdateWorkspaceMember godoc
+// @Summary      Change Member Role
+// @Description  Owner bisa mengatur admin, member, viewer; admin hanya member dan viewer
+// @Tags         workspaces
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  int                  true  "Workspace ID"
+// @Param        user_id  path  int                  true  "User ID"
+// @Param        request  body  object{role=string}  true  "Role baru: admin, member, viewer"
+// @Success      200  {object}  domain.WorkspaceMember
+// @Failure      403  {object}  map[string]interface{}
+// @Router       /workspaces/{id}/members/{user_id} [patch]
+func (h *WorkspaceHandler) UpdateMember(c *gin.Context) {
+	id, err := int64Param(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	targetID, err := int64Param(c, "user_id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req struct {
+		Role string `json:"role"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("body", "%s", err.Error()))
+		return
+	}
+
+	member, err := h.WorkspaceUseCase.UpdateMemberRole(c.Request.Context(), id, c.MustGet("user_id").(int64), targetID, req.Role)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, member)
+}
+
+// RemoveWorkspaceMember godoc
+// @Summary      Remove Member
+// @Description  Mengeluarkan anggota; gunakan user_id sendiri untuk keluar dari workspace
+// @Tags         workspaces
+// @Security     BearerAuth
+// @Param        id       path  int  true  "Workspace ID"
+// @Param        user_id  path  int  true  "User ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]interface{}
+// @Router       /workspaces/{id}/members/{user_id} [delete]
+func (h *WorkspaceHandler) RemoveMember(c *gin.Context) {
+	id, err := int64Param(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	targetID, err := int64Param(c, "user_id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.WorkspaceUseCase.RemoveMember(c.Request.Context(), id, c.MustGet("user_id").(int64), targetID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Member removed"})
+}
+
+// InviteWorkspaceMember godoc
+// @Summary      Invite Member
+// @Description  Mengirim undangan lewat email. Role default member.
+// @Tags         workspaces
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path  int                               true  "Workspace ID"
+// @Param        request  body  object{email=string,role=string}  true  "Email & role"
+// @Success      201  {object}  domain.WorkspaceInvitation
+// @Failure      400  {object}  map[string]interface{}
+// @Router       /workspaces/{id}/invitations [post]
+func (h *WorkspaceHandler) Invite(c *gin.Context) {
+	id, err := int64Param(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req struct {
+		Email string `json:"email"`
+		Role  string `json:"role"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("body", "%s", err.Error()))
+		return
+	}
+
+	inv, err := h.WorkspaceUseCase.Invite(c.Request.Context(), id, c.MustGet("user_id").(int64), req.Email, req.Role)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, inv)
+}
+
+// ListWorkspaceInvitations godoc
+// @Summary      List Pending Invitations
+// @Tags         workspaces
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Workspace ID"
+// @Success      200  {array}  domain.WorkspaceInvitation
+// @Router       /workspaces/{id}/invitations [get]
+func (h *WorkspaceHandler) ListInvitations(c *gin.Context) {
+	id, err := int64Param(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	invitations, err := h.WorkspaceUseCase.ListInvitations(c.Request.Context(), id, c.MustGet("user_id").(int64))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, invitations)
+}
+
+// RevokeWorkspaceInvitation godoc
+// @Summary      Revoke Invitation
+// @Tags         workspaces
+// @Security     BearerAuth
+// @Param        id             path  int  true  "Workspace ID"
+// @Param        invitation_id  path  int  true  "Invitation ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /workspaces/{id}/invitations/{invitation_id} [delete]
+func (h *WorkspaceHandler) RevokeInvitation(c *gin.Context) {
+	id, err := int64Param(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	invitationID, err := int64Param(c, "invitation_id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.WorkspaceUseCase.RevokeInvitation(c.Request.Context(), id, c.MustGet("user_id").(int64), invitationID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Invitation revoked"})
+}
+
+// AcceptWorkspaceInvitation godoc
+// @Summary      Accept Invitation
+// @Description  Bergabung ke workspace dengan token dari email undangan. Email akun harus sama dengan email yang diundang.
+// @Tags         workspaces
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body object{token=string} true "Token undangan"
+// @Success      200  {object}  domain.Workspace
+// @Failure      400  {object}  map[string]interface{}
+// @Failure      403  {object}  map[string]interface{}
+// @Router       /invitations/accept [post]
+func (h *WorkspaceHandler) AcceptInvitation(c *gin.Context) {
+	var req struct {
+		Token string `json:"token" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(domain.NewValidationError("token", "is required"))
+		return
+	}
+
+	ws, err := h.WorkspaceUseCase.AcceptInvitation(c.Request.Context(), c.MustGet("user_id").(int64), req.Token)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ws)
+}
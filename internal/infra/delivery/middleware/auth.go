@@ -1,31 +1,67 @@
 package middleware
 
 import (
+	"context"
 	"fmt"
-	"net/http"
 	"strings"
 
+	"simple-task-manager/internal/core/domain"
+
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 )
 
-func AuthMiddleware(secretKey string) gin.HandlerFunc {
+// SessionValidator memastikan session di balik access token belum dicabut
+type SessionValidator interface {
+	ValidateSession(ctx context.Context, userID int64, sessionID int64) error
+}
+
+// AccessTokenAuthenticator memvalidasi personal access token (API key) dari header Authorization
+type AccessTokenAuthenticator interface {
+	Authenticate(ctx context.Context, raw string) (*domain.PersonalAccessToken, error)
+}
+
+// Key context yang diisi AuthMiddleware. token_scopes hanya ada jika request
+// memakai personal access token; request dengan session JWT punya akses penuh.
+const (
+	ContextUserID      = "user_id"
+	ContextSessionID   = "session_id"
+	ContextTokenScopes = "token_scopes"
+)
+
+// AuthMiddleware menerima JWT dari login (terikat session) maupun personal access token.
+// Scope personal access token ditegakkan per grup route dengan RequireScope.
+func AuthMiddleware(secretKey string, sessions SessionValidator, tokens AccessTokenAuthenticator) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header is required"})
+			c.Error(domain.Unauthorized("Authorization header is required"))
 			c.Abort()
 			return
 		}
 
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization header format"})
+			c.Error(domain.Unauthorized("Invalid authorization header format"))
 			c.Abort()
 			return
 		}
 
 		tokenString := parts[1]
+		if strings.HasPrefix(tokenString, domain.AccessTokenPrefix) {
+			pat, err := tokens.Authenticate(c.Request.Context(), tokenString)
+			if err != nil {
+				c.Error(err)
+				c.Abort()
+				return
+			}
+
+			c.Set(ContextUserID, pat.UserID)
+			c.Set(ContextTokenScopes, pat.Scopes)
+			c.Next()
+			return
+		}
+
 		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
@@ -34,20 +70,35 @@ func AuthMiddleware(secretKey string) gin.HandlerFunc {
 		})
 
 		if err != nil || !token.Valid {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+			c.Error(domain.Unauthorized("Invalid or expired token"))
 			c.Abort()
 			return
 		}
 
 		claims, ok := token.Claims.(jwt.MapClaims)
 		if !ok {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
+			c.Error(domain.Unauthorized("Invalid token claims"))
 			c.Abort()
 			return
 		}
-		
-		userID := int64(claims["user_id"].(float64))
-		c.Set("user_id", userID)
+
+		rawUserID, ok := claims["user_id"].(float64)
+		rawSessionID, hasSession := claims["sid"].(float64)
+		if !ok || !hasSession {
+			c.Error(domain.Unauthorized("Invalid token claims"))
+			c.Abort()
+			return
+		}
+		userID, sessionID := int64(rawUserID), int64(rawSessionID)
+
+		if err := sessions.ValidateSession(c.Request.Context(), userID, sessionID); err != nil {
+			c.Error(err)
+			c.Abort()
+			return
+		}
+
+		c.Set(ContextUserID, userID)
+		c.Set(ContextSessionID, sessionID)
 
 		c.Next()
 	}
@@ -0,0 +1,81 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"simple-task-manager/internal/core/domain"
+	"simple-task-manager/internal/infra/delivery/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeSessions struct{}
+
+func (fakeSessions) ValidateSession(ctx context.Context, userID int64, sessionID int64) error {
+	return nil
+}
+
+type fakeTokens map[string]*domain.PersonalAccessToken
+
+func (f fakeTokens) Authenticate(ctx context.Context, raw string) (*domain.PersonalAccessToken, error) {
+	if t, ok := f[raw]; ok {
+		return t, nil
+	}
+	return nil, domain.Unauthorized("invalid, expired or revoked access token")
+}
+
+func newScopedRouter(tokens fakeTokens) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middleware.ErrorHandler())
+
+	auth := middleware.AuthMiddleware("secret", fakeSessions{}, tokens)
+	ok := func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{"user_id": c.MustGet("user_id")}) }
+
+	tasks := r.Group("/tasks", auth, middleware.RequireScope(domain.ScopeTasksRead, domain.ScopeTasksWrite))
+	tasks.GET("", ok)
+	tasks.POST("", ok)
+
+	r.POST("/auth/tokens", auth, middleware.RequireSession(), ok)
+	return r
+}
+
+func TestAccessTokenScopes(t *testing.T) {
+	readOnly := domain.AccessTokenPrefix + "read"
+	writer := domain.AccessTokenPrefix + "write"
+	r := newScopedRouter(fakeTokens{
+		readOnly: {UserID: 1, Scopes: []string{domain.ScopeTasksRead}},
+		writer:   {UserID: 1, Scopes: []string{domain.ScopeTasksWrite}},
+	})
+
+	cases := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		status int
+	}{
+		{"Read Scope Can List", http.MethodGet, "/tasks", readOnly, http.StatusOK},
+		{"Read Scope Cannot Create", http.MethodPost, "/tasks", readOnly, http.StatusForbidden},
+		{"Write Scope Implies Read", http.MethodGet, "/tasks", writer, http.StatusOK},
+		{"Write Scope Can Create", http.MethodPost, "/tasks", writer, http.StatusOK},
+		{"Unknown Token", http.MethodGet, "/tasks", domain.AccessTokenPrefix + "nope", http.StatusUnauthorized},
+		{"Token Cannot Mint Tokens", http.MethodPost, "/auth/tokens", writer, http.StatusForbidden},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.path, nil)
+			req.Header.Set("Authorization", "Bearer "+tc.token)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.status, w.Code)
+		})
+	}
+}
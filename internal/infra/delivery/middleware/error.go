@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"errors"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+
+	"simple-task-manager/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Problem adalah body response error sesuai RFC 7807 (application/problem+json)
+type Problem struct {
+	Type     string              `json:"type"`
+	Title    string              `json:"title"`
+	Status   int                 `json:"status"`
+	Detail   string              `json:"detail,omitempty"`
+	Instance string              `json:"instance,omitempty"`
+	Errors   []domain.FieldError `json:"errors,omitempty"`
+}
+
+// ErrorHandler mengubah error terakhir yang dicatat handler lewat c.Error(err)
+// menjadi response application/problem+json. Handler cukup memanggil
+// c.Error(err) lalu return; status code ditentukan dari kategori error domain.
+// Harus dipasang sebelum middleware/handler lain supaya bisa menangkap error mereka.
+func ErrorHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		err := c.Errors.Last().Err
+		problem := problemFor(err)
+		problem.Instance = c.Request.URL.Path
+
+		if problem.Status == http.StatusInternalServerError {
+			log.Printf("[ERROR] %s %s: %v", c.Request.Method, c.Request.URL.Path, err)
+		}
+
+		var rateErr *domain.RateLimitedError
+		if errors.As(err, &rateErr) {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(rateErr.RetryAfter.Seconds()))))
+		}
+
+		c.Header("Content-Type", "application/problem+json")
+		c.JSON(problem.Status, problem)
+	}
+}
+
+// problemFor memetakan kategori error domain ke status code dan tipe problem
+func problemFor(err error) Problem {
+	var validationErr *domain.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		return newProblem(http.StatusBadRequest, "validation-error", err.Error(), validationErr.Fields)
+	case errors.Is(err, domain.ErrBadParamInput):
+		return newProblem(http.StatusBadRequest, "bad-request", err.Error(), nil)
+	case errors.Is(err, domain.ErrUnauthorized):
+		return newProblem(http.StatusUnauthorized, "unauthorized", err.Error(), nil)
+	case errors.Is(err, domain.ErrForbidden):
+		return newProblem(http.StatusForbidden, "forbidden", err.Error(), nil)
+	case errors.Is(err, domain.ErrNotFound):
+		return newProblem(http.StatusNotFound, "not-found", err.Error(), nil)
+	case errors.Is(err, domain.ErrConflict):
+		return newProblem(http.StatusConflict, "conflict", err.Error(), nil)
+	case errors.Is(err, domain.ErrRateLimited):
+		return newProblem(http.StatusTooManyRequests, "rate-limited", err.Error(), nil)
+	default:
+		// Detail error internal tidak dikirim ke client
+		return newProblem(http.StatusInternalServerError, "internal-error", "", nil)
+	}
+}
+
+func newProblem(status int, slug string, detail string, fields []domain.FieldError) Problem {
+	return Problem{
+		Type:   "/problems/" + slug,
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Errors: fields,
+	}
+}
@@ -0,0 +1,85 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+	"simple-task-manager/internal/infra/delivery/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func serveError(err error) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middleware.ErrorHandler())
+	r.GET("/boom", func(c *gin.Context) {
+		c.Error(err)
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/boom", nil))
+	return w
+}
+
+func TestErrorHandler(t *testing.T) {
+	cases := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"Not Found", domain.NotFound("task 1 not found"), http.StatusNotFound},
+		{"Wrapped Not Found", fmt.Errorf("loading: %w", domain.ErrNotFound), http.StatusNotFound},
+		{"Forbidden", domain.Forbidden("you don't own task 1"), http.StatusForbidden},
+		{"Conflict", domain.Conflict("email already exists"), http.StatusConflict},
+		{"Unauthorized", domain.Unauthorized("invalid email or password"), http.StatusUnauthorized},
+		{"Validation", domain.NewValidationError("title", "cannot be empty"), http.StatusBadRequest},
+		{"Rate Limited", &domain.RateLimitedError{RetryAfter: 30 * time.Second}, http.StatusTooManyRequests},
+		{"Unknown", errors.New("pq: connection refused"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := serveError(tc.err)
+
+			assert.Equal(t, tc.status, w.Code)
+			assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+
+			var problem middleware.Problem
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+			assert.Equal(t, tc.status, problem.Status)
+			assert.Equal(t, "/boom", problem.Instance)
+		})
+	}
+
+	t.Run("Validation Lists Fields", func(t *testing.T) {
+		verr := domain.NewValidationError("title", "cannot be empty")
+		verr.Add("priority", "must be one of low, medium, high")
+
+		var problem middleware.Problem
+		_ = json.Unmarshal(serveError(verr).Body.Bytes(), &problem)
+
+		assert.Len(t, problem.Errors, 2)
+		assert.Equal(t, "priority", problem.Errors[1].Field)
+	})
+
+	t.Run("Internal Error Detail Is Hidden", func(t *testing.T) {
+		var problem middleware.Problem
+		_ = json.Unmarshal(serveError(errors.New("pq: secret dsn")).Body.Bytes(), &problem)
+
+		assert.Empty(t, problem.Detail)
+	})
+
+	t.Run("Rate Limited Sets Retry-After", func(t *testing.T) {
+		w := serveError(&domain.RateLimitedError{RetryAfter: 1500 * time.Millisecond})
+
+		assert.Equal(t, "2", w.Header().Get("Retry-After"))
+	})
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"net/http"
+
+	"simple-task-manager/internal/core/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireScope membatasi personal access token pada sebuah grup route: request
+// baca (GET/HEAD) butuh scope read, selain itu butuh scope write. Request dengan
+// session JWT tidak punya token_scopes dan selalu lolos.
+func RequireScope(read string, write string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		raw, ok := c.Get(ContextTokenScopes)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		scope := write
+		if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+			scope = read
+		}
+
+		if !domain.HasScope(raw.([]string), scope) {
+			c.Error(domain.Forbidden("access token is missing the %q scope", scope))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireSession menolak personal access token untuk route pengelolaan akun
+// (logout, 2FA, pembuatan token baru) yang hanya boleh dilakukan dari login interaktif.
+func RequireSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := c.Get(ContextSessionID); !ok {
+			c.Error(domain.Forbidden("this endpoint requires an interactive login session"))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
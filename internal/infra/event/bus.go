@@ -0,0 +1,77 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+
+	"simple-task-manager/internal/core/domain"
+)
+
+// Bus adalah dispatcher event in-process. Handler dipanggil berurutan di
+// goroutine pemanggil Publish dan satu handler yang gagal tidak menghalangi
+// handler lain. Error handler biasa hanya di-log; error handler durable
+// dikembalikan oleh Publish supaya relay outbox mengirim ulang event-nya.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]subscriber
+	all      []subscriber
+}
+
+type subscriber struct {
+	handle  domain.EventHandler
+	durable bool
+}
+
+func NewBus() *Bus {
+	return &Bus{handlers: make(map[string][]subscriber)}
+}
+
+// Subscribe mendaftarkan handler best-effort untuk satu jenis event
+func (b *Bus) Subscribe(eventType string, h domain.EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[eventType] = append(b.handlers[eventType], subscriber{handle: h})
+}
+
+// SubscribeDurable mendaftarkan handler yang menyimpan hasilnya (notifikasi, antrian
+// webhook). Kegagalannya membuat Publish gagal sehingga event dikirim ulang, jadi
+// handler wajib idempoten terhadap Event.ID.
+func (b *Bus) SubscribeDurable(eventType string, h domain.EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[eventType] = append(b.handlers[eventType], subscriber{handle: h, durable: true})
+}
+
+// SubscribeAll mendaftarkan handler best-effort untuk semua jenis event (mis. stream real-time)
+func (b *Bus) SubscribeAll(h domain.EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.all = append(b.all, subscriber{handle: h})
+}
+
+// Publish memanggil semua handler dan mengembalikan gabungan error handler durable
+func (b *Bus) Publish(ctx context.Context, e domain.Event) error {
+	b.mu.RLock()
+	subs := make([]subscriber, 0, len(b.handlers[e.Type])+len(b.all))
+	subs = append(append(subs, b.handlers[e.Type]...), b.all...)
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, s := range subs {
+		err := s.handle(ctx, e)
+		switch {
+		case err == nil:
+		case s.durable:
+			errs = append(errs, err)
+		default:
+			log.Printf("event handler for %s on task %d failed: %v", e.Type, e.TaskID, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("publishing %s (event %d): %w", e.Type, e.ID, err)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package event_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"simple-task-manager/internal/core/domain"
+	"simple-task-manager/internal/infra/event"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBusPublish(t *testing.T) {
+	bus := event.NewBus()
+
+	var got []string
+	bus.Subscribe(domain.EventTaskAssigned, func(ctx context.Context, e domain.Event) error {
+		got = append(got, "first")
+		return errors.New("boom")
+	})
+	bus.Subscribe(domain.EventTaskAssigned, func(ctx context.Context, e domain.Event) error {
+		got = append(got, "second")
+		return nil
+	})
+	bus.Subscribe(domain.EventTaskUnassigned, func(ctx context.Context, e domain.Event) error {
+		got = append(got, "other")
+		return nil
+	})
+
+	err := bus.Publish(context.Background(), domain.Event{Type: domain.EventTaskAssigned, TaskID: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"first", "second"}, got)
+}
+
+func TestBusSubscribeAll(t *testing.T) {
+	bus := event.NewBus()
+
+	var got []string
+	bus.Subscribe(domain.EventTaskCreated, func(ctx context.Context, e domain.Event) error {
+		got = append(got, "typed")
+		return nil
+	})
+	bus.SubscribeAll(func(ctx context.Context, e domain.Event) error {
+		got = append(got, "all:"+e.Type)
+		return nil
+	})
+
+	_ = bus.Publish(context.Background(), domain.Event{Type: domain.EventTaskCreated, TaskID: 1})
+	_ = bus.Publish(context.Background(), domain.Event{Type: domain.EventTaskDeleted, TaskID: 1})
+
+	assert.Equal(t, []string{"typed", "all:task.created", "all:task.deleted"}, got)
+}
+
+func TestBusDurableSubscriber(t *testing.T) {
+	bus := event.NewBus()
+
+	var got []string
+	bus.SubscribeDurable(domain.EventTaskAssigned, func(ctx context.Context, e domain.Event) error {
+		got = append(got, "durable")
+		return errors.New("insert failed")
+	})
+	bus.SubscribeAll(func(ctx context.Context, e domain.Event) error {
+		got = append(got, "stream")
+		return errors.New("client gone")
+	})
+
+	err := bus.Publish(context.Background(), domain.Event{ID: 7, Type: domain.EventTaskAssigned, TaskID: 1})
+
+	// hanya error subscriber durable yang dikembalikan; subscriber lain tetap dipanggil
+	assert.ErrorContains(t, err, "insert failed")
+	assert.NotContains(t, err.Error(), "client gone")
+	assert.Equal(t, []string{"durable", "stream"}, got)
+}
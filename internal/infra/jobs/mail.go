@@ -0,0 +1,36 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+)
+
+// TypeSendEmail mengirim satu domain.Mail lewat mailer yang didaftarkan di RegisterMailer
+const TypeSendEmail = domain.JobSendEmail
+
+// defaultMailRetention: payload email berisi token sekali pakai, jadi job yang selesai
+// (termasuk dead letter) hanya disimpan cukup lama untuk diperiksa & di-retry admin
+const defaultMailRetention = 24 * time.Hour
+
+// RegisterMailer mendaftarkan handler email.send yang mengirim lewat mailer, lalu mengembalikan
+// domain.Mailer yang hanya mengantrikan email. Request tidak lagi gagal karena SMTP sedang
+// bermasalah; pengiriman dicoba ulang oleh antrian.
+func RegisterMailer(q *Queue, mailer domain.Mailer, cfg HandlerConfig) domain.Mailer {
+	if cfg.Retention <= 0 {
+		cfg.Retention = defaultMailRetention
+	}
+	Handle(q, TypeSendEmail, mailer.Send, cfg)
+	return &queuedMailer{queue: q}
+}
+
+type queuedMailer struct {
+	queue *Queue
+}
+
+// Send: email transaksional (verifikasi, reset password, undangan) didahulukan dari job lain
+func (m *queuedMailer) Send(ctx context.Context, msg domain.Mail) error {
+	_, err := m.queue.Enqueue(ctx, TypeSendEmail, msg, EnqueueOptions{Priority: 10})
+	return err
+}
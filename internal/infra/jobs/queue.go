@@ -0,0 +1,331 @@
+// Package jobs adalah antrian job background di atas tabel jobs Postgres. Setiap tipe job
+// punya handler, batas konkurensi dan kebijakan retry sendiri; job yang gagal dicoba ulang
+// dengan backoff eksponensial dan berhenti di status dead (dead letter) bila percobaan habis.
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sync"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+)
+
+// Handler memproses satu job; error membuat job dicoba ulang sesuai HandlerConfig
+type Handler func(ctx context.Context, job domain.Job) error
+
+// HandlerConfig mengatur eksekusi satu tipe job
+type HandlerConfig struct {
+	Concurrency int           // job tipe ini yang berjalan bersamaan per worker
+	Timeout     time.Duration // batas waktu satu percobaan
+	MaxAttempts int           // bawaan untuk job baru; bisa ditimpa EnqueueOptions
+	BaseBackoff time.Duration // jeda retry pertama; berlipat dua setiap percobaan
+	MaxBackoff  time.Duration
+	Retention   time.Duration // job done/dead tipe ini dihapus setelah selama ini; 0 berarti disimpan
+}
+
+// Config mengatur worker antrian
+type Config struct {
+	PollInterval  time.Duration
+	Lease         time.Duration // job running lebih lama dari ini dianggap workernya mati dan diantrikan ulang
+	PurgeInterval time.Duration // jeda antar penghapusan job selesai (lihat HandlerConfig.Retention)
+}
+
+// EnqueueOptions bersifat opsional; nilai nol memakai bawaan
+type EnqueueOptions struct {
+	Priority    int       // lebih besar diambil lebih dulu
+	RunAt       time.Time // kosong berarti sekarang
+	MaxAttempts int
+}
+
+type registration struct {
+	handler Handler
+	cfg     HandlerConfig
+	running int // dijaga Queue.mu
+}
+
+// Queue mendaftarkan handler dan menjalankan worker. Beberapa replica aman berjalan
+// bersamaan karena klaim memakai SKIP LOCKED; eksekusi bersifat at-least-once.
+type Queue struct {
+	repo      domain.JobRepository
+	cfg       Config
+	mu        sync.Mutex
+	handlers  map[string]*registration
+	inflight  sync.WaitGroup
+	now       func() time.Time
+	lastPurge time.Time
+}
+
+func NewQueue(repo domain.JobRepository, cfg Config) *Queue {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = time.Second
+	}
+	if cfg.Lease <= 0 {
+		cfg.Lease = 15 * time.Minute
+	}
+	if cfg.PurgeInterval <= 0 {
+		cfg.PurgeInterval = time.Hour
+	}
+	return &Queue{repo: repo, cfg: cfg, handlers: map[string]*registration{}, now: time.Now}
+}
+
+// Register mendaftarkan handler untuk jobType; dipanggil saat startup, sebelum Run
+func (q *Queue) Register(jobType string, h Handler, cfg HandlerConfig) {
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 1
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = time.Minute
+	}
+	if cfg.Timeout >= q.cfg.Lease {
+		panic(fmt.Sprintf("jobs: timeout of %q must be shorter than the queue lease (%s)", jobType, q.cfg.Lease))
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 5
+	}
+	if cfg.BaseBackoff <= 0 {
+		cfg.BaseBackoff = 30 * time.Second
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = time.Hour
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if _, ok := q.handlers[jobType]; ok {
+		panic(fmt.Sprintf("jobs: handler for %q registered twice", jobType))
+	}
+	q.handlers[jobType] = &registration{handler: h, cfg: cfg}
+}
+
+// Handle mendaftarkan handler bertipe: payload JSON di-decode ke T sebelum fn dipanggil.
+// Payload yang tidak bisa di-decode langsung masuk dead letter tanpa retry.
+func Handle[T any](q *Queue, jobType string, fn func(ctx context.Context, payload T) error, cfg HandlerConfig) {
+	q.Register(jobType, func(ctx context.Context, job domain.Job) error {
+		var payload T
+		if err := json.Unmarshal(job.Payload, &payload); err != nil {
+			return Permanent(fmt.Errorf("decoding payload: %w", err))
+		}
+		return fn(ctx, payload)
+	}, cfg)
+}
+
+// Enqueue menambahkan job; jobType harus sudah didaftarkan
+func (q *Queue) Enqueue(ctx context.Context, jobType string, payload any, opts EnqueueOptions) (*domain.Job, error) {
+	q.mu.Lock()
+	reg, ok := q.handlers[jobType]
+	q.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("jobs: no handler registered for %q", jobType)
+	}
+
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("jobs: encoding payload of %q: %w", jobType, err)
+	}
+	now := q.now()
+	job := &domain.Job{
+		Type:        jobType,
+		Payload:     raw,
+		Priority:    opts.Priority,
+		Status:      domain.JobPending,
+		MaxAttempts: opts.MaxAttempts,
+		RunAt:       opts.RunAt,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if job.MaxAttempts <= 0 {
+		job.MaxAttempts = reg.cfg.MaxAttempts
+	}
+	if job.RunAt.IsZero() {
+		job.RunAt = now
+	}
+	if err := q.repo.Enqueue(ctx, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// Run memproses antrian sampai ctx dibatalkan, lalu menunggu job yang sedang berjalan selesai
+func (q *Queue) Run(ctx context.Context) {
+	ticker := time.NewTicker(q.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := q.Poll(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("[JOBS ERROR] Polling job queue: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			q.Wait()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll menjalankan satu putaran: antrikan ulang klaim basi, hapus job selesai yang melewati
+// retensi tipenya, lalu klaim job jatuh tempo sebanyak slot konkurensi yang masih kosong per
+// tipe. Job berjalan di goroutine sendiri; Poll mengembalikan jumlah job yang dimulai.
+func (q *Queue) Poll(ctx context.Context) (int, error) {
+	if n, err := q.repo.RequeueStale(ctx, q.now().Add(-q.cfg.Lease)); err != nil {
+		return 0, err
+	} else if n > 0 {
+		log.Printf("[JOBS] %d job(s) interrupted while running, requeued", n)
+	}
+
+	q.mu.Lock()
+	types := make([]string, 0, len(q.handlers))
+	for jobType := range q.handlers {
+		types = append(types, jobType)
+	}
+	q.mu.Unlock()
+	slices.Sort(types)
+
+	var errs []error
+	if err := q.purge(ctx, types); err != nil {
+		errs = append(errs, err)
+	}
+	started := 0
+	for _, jobType := range types {
+		n, err := q.dispatch(ctx, jobType)
+		started += n
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", jobType, err))
+		}
+	}
+	return started, errors.Join(errs...)
+}
+
+// purge menghapus job selesai per tipe yang punya Retention, paling sering sekali per PurgeInterval
+func (q *Queue) purge(ctx context.Context, types []string) error {
+	now := q.now()
+	if now.Sub(q.lastPurge) < q.cfg.PurgeInterval {
+		return nil
+	}
+	var errs []error
+	for _, jobType := range types {
+		q.mu.Lock()
+		retention := q.handlers[jobType].cfg.Retention
+		q.mu.Unlock()
+		if retention <= 0 {
+			continue
+		}
+		n, err := q.repo.PurgeFinished(ctx, jobType, now.Add(-retention))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("purging %s: %w", jobType, err))
+			continue
+		}
+		if n > 0 {
+			log.Printf("[JOBS] Purged %d finished %s job(s)", n, jobType)
+		}
+	}
+	if len(errs) == 0 {
+		q.lastPurge = now
+	}
+	return errors.Join(errs...)
+}
+
+// Wait menunggu semua job yang sedang berjalan selesai
+func (q *Queue) Wait() {
+	q.inflight.Wait()
+}
+
+func (q *Queue) dispatch(ctx context.Context, jobType string) (int, error) {
+	q.mu.Lock()
+	reg := q.handlers[jobType]
+	free := reg.cfg.Concurrency - reg.running
+	reg.running += max(free, 0) // slot dipesan dulu supaya Poll yang tumpang tindih tidak melebihi batas
+	q.mu.Unlock()
+	if free <= 0 {
+		return 0, nil
+	}
+
+	batch, err := q.repo.Claim(ctx, jobType, q.now(), free)
+	q.release(reg, free-len(batch))
+	if err != nil {
+		return 0, err
+	}
+	for _, job := range batch {
+		q.inflight.Add(1)
+		go func(job domain.Job) {
+			defer q.inflight.Done()
+			defer q.release(reg, 1)
+			// Job yang sudah diklaim diselesaikan walau worker sedang berhenti
+			q.execute(context.WithoutCancel(ctx), reg, job)
+		}(job)
+	}
+	return len(batch), nil
+}
+
+func (q *Queue) release(reg *registration, n int) {
+	q.mu.Lock()
+	reg.running -= n
+	q.mu.Unlock()
+}
+
+func (q *Queue) execute(ctx context.Context, reg *registration, job domain.Job) {
+	runCtx, cancel := context.WithTimeout(ctx, reg.cfg.Timeout)
+	err := safeCall(runCtx, reg.handler, job)
+	cancel()
+
+	now := q.now()
+	job.UpdatedAt = now
+	switch {
+	case err == nil:
+		job.Status, job.FinishedAt, job.LastError = domain.JobDone, &now, nil
+	case IsPermanent(err) || job.Attempts >= job.MaxAttempts:
+		msg := err.Error()
+		job.Status, job.FinishedAt, job.LastError = domain.JobDead, &now, &msg
+		log.Printf("[JOBS ERROR] Job %d (%s) moved to dead letter after %d attempt(s): %v", job.ID, job.Type, job.Attempts, err)
+	default:
+		msg := err.Error()
+		job.Status, job.LastError = domain.JobPending, &msg
+		job.RunAt = now.Add(backoff(reg.cfg, job.Attempts))
+	}
+
+	if ok, err := q.repo.Finish(ctx, &job); err != nil {
+		log.Printf("[JOBS ERROR] Saving result of job %d: %v", job.ID, err)
+	} else if !ok {
+		log.Printf("[JOBS ERROR] Lease of job %d (%s) expired while running; result discarded", job.ID, job.Type)
+	}
+}
+
+// safeCall menjalankan handler; panic diperlakukan sebagai error biasa supaya worker tetap hidup
+func safeCall(ctx context.Context, h Handler, job domain.Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return h(ctx, job)
+}
+
+// backoff: BaseBackoff * 2^(attempts-1), dibatasi MaxBackoff
+func backoff(cfg HandlerConfig, attempts int) time.Duration {
+	wait := cfg.BaseBackoff
+	for i := 1; i < attempts && wait < cfg.MaxBackoff; i++ {
+		wait *= 2
+	}
+	return min(wait, cfg.MaxBackoff)
+}
+
+type permanentError struct{ err error }
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent menandai error yang tidak akan berhasil walau dicoba ulang (mis. payload rusak);
+// job langsung masuk dead letter
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+func IsPermanent(err error) bool {
+	var perm *permanentError
+	return errors.As(err, &perm)
+}
@@ -0,0 +1,266 @@
+package jobs_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+	"simple-task-manager/internal/core/usecase/mocks"
+	"simple-task-manager/internal/infra/jobs"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type greeting struct {
+	Name string `json:"name"`
+}
+
+const typeGreet = "test.greet"
+
+var retryCfg = jobs.HandlerConfig{MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: 3 * time.Minute}
+
+// runOnce menjalankan satu putaran Poll dan menunggu job yang dimulainya selesai
+func runOnce(t *testing.T, q *jobs.Queue) int {
+	started, err := q.Poll(context.Background())
+	assert.NoError(t, err)
+	q.Wait()
+	return started
+}
+
+func TestQueueExecute(t *testing.T) {
+	expectClaim := func(repo *mocks.JobRepository, limit int, batch ...domain.Job) {
+		repo.On("RequeueStale", mock.Anything, mock.Anything).Return(int64(0), nil).Once()
+		repo.On("Claim", mock.Anything, typeGreet, mock.Anything, limit).Return(batch, nil).Once()
+	}
+
+	t.Run("Success - Typed Payload Decoded And Marked Done", func(t *testing.T) {
+		repo := new(mocks.JobRepository)
+		q := jobs.NewQueue(repo, jobs.Config{})
+		var got greeting
+		jobs.Handle(q, typeGreet, func(ctx context.Context, g greeting) error {
+			got = g
+			return nil
+		}, retryCfg)
+
+		expectClaim(repo, 1, domain.Job{ID: 1, Type: typeGreet, Payload: []byte(`{"name":"Ana"}`), Attempts: 1, MaxAttempts: 3})
+		repo.On("Finish", mock.Anything, mock.MatchedBy(func(j *domain.Job) bool {
+			return j.ID == 1 && j.Status == domain.JobDone && j.FinishedAt != nil && j.LastError == nil
+		})).Return(true, nil).Once()
+
+		assert.Equal(t, 1, runOnce(t, q))
+		assert.Equal(t, "Ana", got.Name)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Failed - Retried With Exponential Backoff", func(t *testing.T) {
+		repo := new(mocks.JobRepository)
+		q := jobs.NewQueue(repo, jobs.Config{})
+		jobs.Handle(q, typeGreet, func(ctx context.Context, g greeting) error { return errors.New("smtp unavailable") }, retryCfg)
+
+		// percobaan ke-2: 1m * 2 = 2m
+		expectClaim(repo, 1, domain.Job{ID: 2, Type: typeGreet, Payload: []byte(`{}`), Attempts: 2, MaxAttempts: 3})
+		repo.On("Finish", mock.Anything, mock.MatchedBy(func(j *domain.Job) bool {
+			wait := time.Until(j.RunAt)
+			return j.Status == domain.JobPending && *j.LastError == "smtp unavailable" && j.FinishedAt == nil &&
+				wait > 110*time.Second && wait <= 2*time.Minute
+		})).Return(true, nil).Once()
+
+		runOnce(t, q)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Failed - Dead Letter After Last Attempt", func(t *testing.T) {
+		repo := new(mocks.JobRepository)
+		q := jobs.NewQueue(repo, jobs.Config{})
+		jobs.Handle(q, typeGreet, func(ctx context.Context, g greeting) error { panic("boom") }, retryCfg)
+
+		expectClaim(repo, 1, domain.Job{ID: 3, Type: typeGreet, Payload: []byte(`{}`), Attempts: 3, MaxAttempts: 3})
+		repo.On("Finish", mock.Anything, mock.MatchedBy(func(j *domain.Job) bool {
+			return j.Status == domain.JobDead && j.FinishedAt != nil && *j.LastError == "panic: boom"
+		})).Return(true, nil).Once()
+
+		runOnce(t, q)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Failed - Undecodable Payload Skips Retries", func(t *testing.T) {
+		repo := new(mocks.JobRepository)
+		q := jobs.NewQueue(repo, jobs.Config{})
+		called := false
+		jobs.Handle(q, typeGreet, func(ctx context.Context, g greeting) error { called = true; return nil }, retryCfg)
+
+		expectClaim(repo, 1, domain.Job{ID: 4, Type: typeGreet, Payload: []byte(`{"name":42}`), Attempts: 1, MaxAttempts: 3})
+		repo.On("Finish", mock.Anything, mock.MatchedBy(func(j *domain.Job) bool {
+			return j.Status == domain.JobDead
+		})).Return(true, nil).Once()
+
+		runOnce(t, q)
+		assert.False(t, called)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestQueueLostLease(t *testing.T) {
+	repo := new(mocks.JobRepository)
+	q := jobs.NewQueue(repo, jobs.Config{})
+	jobs.Handle(q, typeGreet, func(ctx context.Context, g greeting) error { return nil }, retryCfg)
+	claimedAt := time.Now().Add(-20 * time.Minute)
+
+	repo.On("RequeueStale", mock.Anything, mock.Anything).Return(int64(0), nil).Once()
+	repo.On("Claim", mock.Anything, typeGreet, mock.Anything, 1).Return([]domain.Job{
+		{ID: 5, Type: typeGreet, Payload: []byte(`{}`), Status: domain.JobRunning, Attempts: 1, MaxAttempts: 3, LockedAt: &claimedAt},
+	}, nil).Once()
+	// Klaim dikirim kembali sebagai token; worker lain sudah mengklaim ulang job ini
+	repo.On("Finish", mock.Anything, mock.MatchedBy(func(j *domain.Job) bool {
+		return j.ID == 5 && j.LockedAt != nil && j.LockedAt.Equal(claimedAt)
+	})).Return(false, nil).Once()
+
+	runOnce(t, q)
+	repo.AssertExpectations(t)
+}
+
+func TestQueueConcurrencyLimit(t *testing.T) {
+	repo := new(mocks.JobRepository)
+	q := jobs.NewQueue(repo, jobs.Config{})
+	release := make(chan struct{})
+	jobs.Handle(q, typeGreet, func(ctx context.Context, g greeting) error {
+		<-release
+		return nil
+	}, jobs.HandlerConfig{Concurrency: 3})
+
+	repo.On("RequeueStale", mock.Anything, mock.Anything).Return(int64(0), nil)
+	repo.On("Finish", mock.Anything, mock.Anything).Return(true, nil)
+
+	// Putaran 1: 3 slot kosong, hanya 2 job tersedia
+	repo.On("Claim", mock.Anything, typeGreet, mock.Anything, 3).Return([]domain.Job{
+		{ID: 1, Type: typeGreet, Payload: []byte(`{}`), Attempts: 1, MaxAttempts: 5},
+		{ID: 2, Type: typeGreet, Payload: []byte(`{}`), Attempts: 1, MaxAttempts: 5},
+	}, nil).Once()
+	started, err := q.Poll(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, started)
+
+	// Putaran 2: dua job masih berjalan, tinggal 1 slot
+	repo.On("Claim", mock.Anything, typeGreet, mock.Anything, 1).Return([]domain.Job{
+		{ID: 3, Type: typeGreet, Payload: []byte(`{}`), Attempts: 1, MaxAttempts: 5},
+	}, nil).Once()
+	started, err = q.Poll(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, started)
+
+	// Putaran 3: semua slot terpakai, tidak ada klaim
+	started, err = q.Poll(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, started)
+
+	close(release)
+	q.Wait()
+	repo.AssertNumberOfCalls(t, "Claim", 2)
+	repo.AssertNumberOfCalls(t, "Finish", 3)
+}
+
+func TestQueuePurge(t *testing.T) {
+	repo := new(mocks.JobRepository)
+	q := jobs.NewQueue(repo, jobs.Config{PurgeInterval: time.Hour})
+	jobs.Handle(q, typeGreet, func(ctx context.Context, g greeting) error { return nil }, jobs.HandlerConfig{})
+	jobs.Handle(q, "test.secret", func(ctx context.Context, g greeting) error { return nil }, jobs.HandlerConfig{Retention: 2 * time.Hour})
+
+	repo.On("RequeueStale", mock.Anything, mock.Anything).Return(int64(0), nil)
+	repo.On("Claim", mock.Anything, mock.Anything, mock.Anything, 1).Return([]domain.Job{}, nil)
+	repo.On("PurgeFinished", mock.Anything, "test.secret", mock.MatchedBy(func(before time.Time) bool {
+		return time.Since(before) >= 2*time.Hour && time.Since(before) < 2*time.Hour+time.Minute
+	})).Return(int64(0), errors.New("db down")).Once()
+
+	// Gagal: dicoba lagi di putaran berikutnya, klaim tetap berjalan
+	_, err := q.Poll(context.Background())
+	assert.Error(t, err)
+	repo.AssertNumberOfCalls(t, "Claim", 2)
+
+	repo.On("PurgeFinished", mock.Anything, "test.secret", mock.Anything).Return(int64(3), nil).Once()
+	_, err = q.Poll(context.Background())
+	assert.NoError(t, err)
+
+	// Berhasil: tidak dihapus lagi sebelum PurgeInterval lewat
+	_, err = q.Poll(context.Background())
+	assert.NoError(t, err)
+
+	repo.AssertNumberOfCalls(t, "PurgeFinished", 2)
+	repo.AssertNotCalled(t, "PurgeFinished", mock.Anything, typeGreet, mock.Anything)
+}
+
+func TestQueueEnqueue(t *testing.T) {
+	t.Run("Success - Defaults From Handler Config", func(t *testing.T) {
+		repo := new(mocks.JobRepository)
+		q := jobs.NewQueue(repo, jobs.Config{})
+		jobs.Handle(q, typeGreet, func(ctx context.Context, g greeting) error { return nil }, retryCfg)
+
+		repo.On("Enqueue", mock.Anything, mock.MatchedBy(func(j *domain.Job) bool {
+			return j.Type == typeGreet && string(j.Payload) == `{"name":"Budi"}` && j.Status == domain.JobPending &&
+				j.MaxAttempts == 3 && j.Priority == 5 && !j.RunAt.IsZero()
+		})).Return(nil).Once()
+
+		_, err := q.Enqueue(context.Background(), typeGreet, greeting{Name: "Budi"}, jobs.EnqueueOptions{Priority: 5})
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Success - Scheduled Run At Is Kept", func(t *testing.T) {
+		repo := new(mocks.JobRepository)
+		q := jobs.NewQueue(repo, jobs.Config{})
+		jobs.Handle(q, typeGreet, func(ctx context.Context, g greeting) error { return nil }, retryCfg)
+		runAt := time.Date(2030, 1, 1, 8, 0, 0, 0, time.UTC)
+
+		repo.On("Enqueue", mock.Anything, mock.MatchedBy(func(j *domain.Job) bool {
+			return j.RunAt.Equal(runAt) && j.MaxAttempts == 1
+		})).Return(nil).Once()
+
+		_, err := q.Enqueue(context.Background(), typeGreet, greeting{}, jobs.EnqueueOptions{RunAt: runAt, MaxAttempts: 1})
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Failed - Unknown Type", func(t *testing.T) {
+		repo := new(mocks.JobRepository)
+		q := jobs.NewQueue(repo, jobs.Config{})
+
+		_, err := q.Enqueue(context.Background(), "nope", nil, jobs.EnqueueOptions{})
+
+		assert.Error(t, err)
+		repo.AssertNotCalled(t, "Enqueue", mock.Anything, mock.Anything)
+	})
+}
+
+func TestQueuedMailer(t *testing.T) {
+	repo := new(mocks.JobRepository)
+	mailer := new(mocks.Mailer)
+	q := jobs.NewQueue(repo, jobs.Config{})
+	queued := jobs.RegisterMailer(q, mailer, jobs.HandlerConfig{})
+	msg := domain.Mail{To: "ana@example.com", Subject: "Hi", Body: "Hello"}
+
+	// Send hanya mengantrikan
+	var enqueued *domain.Job
+	repo.On("Enqueue", mock.Anything, mock.AnythingOfType("*domain.Job")).Run(func(args mock.Arguments) {
+		enqueued = args.Get(1).(*domain.Job)
+	}).Return(nil).Once()
+	assert.NoError(t, queued.Send(context.Background(), msg))
+	mailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+
+	// Worker mengirimnya lewat mailer asli; email yang selesai lebih dari sehari dihapus
+	enqueued.ID, enqueued.Attempts = 9, 1
+	repo.On("RequeueStale", mock.Anything, mock.Anything).Return(int64(0), nil).Once()
+	repo.On("PurgeFinished", mock.Anything, jobs.TypeSendEmail, mock.MatchedBy(func(before time.Time) bool {
+		return time.Since(before) > 23*time.Hour && time.Since(before) <= 24*time.Hour+time.Minute
+	})).Return(int64(2), nil).Once()
+	repo.On("Claim", mock.Anything, jobs.TypeSendEmail, mock.Anything, 1).Return([]domain.Job{*enqueued}, nil).Once()
+	repo.On("Finish", mock.Anything, mock.MatchedBy(func(j *domain.Job) bool { return j.Status == domain.JobDone })).Return(true, nil).Once()
+	mailer.On("Send", mock.Anything, msg).Return(nil).Once()
+
+	runOnce(t, q)
+	mailer.AssertExpectations(t)
+	repo.AssertExpectations(t)
+}
@@ -0,0 +1,55 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+)
+
+// FileMailer adalah pengganti SMTP untuk development lokal: setiap email
+// ditulis sebagai file .eml di Dir dan ringkasannya dicetak ke log.
+// Jika Dir kosong, email hanya dicetak ke log.
+type FileMailer struct {
+	Dir  string
+	From string
+}
+
+func NewFileMailer(dir, from string) *FileMailer {
+	return &FileMailer{Dir: dir, From: from}
+}
+
+func (m *FileMailer) Send(ctx context.Context, msg domain.Mail) error {
+	if m.Dir == "" {
+		log.Printf("[MAIL] to=%s subject=%q\n%s", msg.To, msg.Subject, msg.Body)
+		return nil
+	}
+
+	if err := os.MkdirAll(m.Dir, 0o755); err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s-%s.eml", time.Now().Format("20060102T150405.000000000"), sanitize(msg.To))
+	path := filepath.Join(m.Dir, name)
+	if err := os.WriteFile(path, buildMessage(m.From, msg), 0o644); err != nil {
+		return err
+	}
+
+	log.Printf("[MAIL] to=%s subject=%q written to %s", msg.To, msg.Subject, path)
+	return nil
+}
+
+// sanitize membuat alamat email aman dipakai sebagai nama file
+func sanitize(s string) string {
+	out := []rune(s)
+	for i, r := range out {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '-') {
+			out[i] = '_'
+		}
+	}
+	return string(out)
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresAccessTokenRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAccessTokenRepository(db *pgxpool.Pool) domain.AccessTokenRepository {
+	return &PostgresAccessTokenRepository{db: db}
+}
+
+const accessTokenColumns = `id, user_id, name, scopes, token_hash, hint, expires_at, last_used_at, revoked_at, created_at`
+
+func scanAccessToken(row pgx.Row) (*domain.PersonalAccessToken, error) {
+	var t domain.PersonalAccessToken
+	err := row.Scan(
+		&t.ID, &t.UserID, &t.Name, &t.Scopes, &t.TokenHash, &t.Hint,
+		&t.ExpiresAt, &t.LastUsedAt, &t.RevokedAt, &t.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *PostgresAccessTokenRepository) Create(ctx context.Context, t *domain.PersonalAccessToken) error {
+	query := `
+		INSERT INTO personal_access_tokens (user_id, name, scopes, token_hash, hint, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`
+	return r.db.QueryRow(ctx, query,
+		t.UserID, t.Name, t.Scopes, t.TokenHash, t.Hint, t.ExpiresAt, t.CreatedAt,
+	).Scan(&t.ID)
+}
+
+func (r *PostgresAccessTokenRepository) ListByUser(ctx context.Context, userID int64) ([]domain.PersonalAccessToken, error) {
+	query := `SELECT ` + accessTokenColumns + ` FROM personal_access_tokens WHERE user_id = $1 ORDER BY created_at DESC, id DESC`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tokens := []domain.PersonalAccessToken{}
+	for rows.Next() {
+		t, err := scanAccessToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, *t)
+	}
+	return tokens, rows.Err()
+}
+
+func (r *PostgresAccessTokenRepository) GetByHash(ctx context.Context, hash string) (*domain.PersonalAccessToken, error) {
+	query := `SELECT ` + accessTokenColumns + ` FROM personal_access_tokens WHERE token_hash = $1`
+
+	t, err := scanAccessToken(r.db.QueryRow(ctx, query, hash))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return t, nil
+}
+
+func (r *PostgresAccessTokenRepository) Revoke(ctx context.Context, id int64, userID int64, at time.Time) error {
+	query := `UPDATE personal_access_tokens SET revoked_at = COALESCE(revoked_at, $1) WHERE id = $2 AND user_id = $3`
+
+	cmdTag, err := r.db.Exec(ctx, query, at, id, userID)
+	if err != nil {
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *PostgresAccessTokenRepository) TouchLastUsed(ctx context.Context, id int64, at time.Time) error {
+	_, err := r.db.Exec(ctx, `UPDATE personal_access_tokens SET last_used_at = $1 WHERE id = $2`, at, id)
+	return err
+}
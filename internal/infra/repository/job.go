@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresJobRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewJobRepository(db *pgxpool.Pool) domain.JobRepository {
+	return &PostgresJobRepository{db: db}
+}
+
+const jobColumns = `id, type, payload, priority, status, attempts, max_attempts, run_at,
+	last_error, locked_at, finished_at, created_at, updated_at`
+
+func scanJob(row pgx.Row) (*domain.Job, error) {
+	var j domain.Job
+	err := row.Scan(
+		&j.ID, &j.Type, &j.Payload, &j.Priority, &j.Status, &j.Attempts, &j.MaxAttempts, &j.RunAt,
+		&j.LastError, &j.LockedAt, &j.FinishedAt, &j.CreatedAt, &j.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &j, nil
+}
+
+func (r *PostgresJobRepository) queryJobs(ctx context.Context, query string, args ...interface{}) ([]domain.Job, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobs := []domain.Job{}
+	for rows.Next() {
+		j, err := scanJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, *j)
+	}
+	return jobs, rows.Err()
+}
+
+func (r *PostgresJobRepository) Enqueue(ctx context.Context, job *domain.Job) error {
+	return r.db.QueryRow(ctx, `
+		INSERT INTO jobs (type, payload, priority, status, max_attempts, run_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $7)
+		RETURNING id
+	`, job.Type, job.Payload, job.Priority, job.Status, job.MaxAttempts, job.RunAt, job.CreatedAt).Scan(&job.ID)
+}
+
+func (r *PostgresJobRepository) Claim(ctx context.Context, jobType string, now time.Time, limit int) ([]domain.Job, error) {
+	return r.queryJobs(ctx, `
+		UPDATE jobs j
+		SET status = 'running', attempts = j.attempts + 1, locked_at = $2, updated_at = $2
+		WHERE j.id IN (
+			SELECT id FROM jobs
+			WHERE type = $1 AND status = 'pending' AND run_at <= $2
+			ORDER BY priority DESC, run_at, id
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+jobColumns, jobType, now, limit)
+}
+
+// Finish hanya menyimpan hasil klaim yang masih berlaku: locked_at dari Claim berfungsi sebagai
+// token klaim, jadi worker yang lease-nya sudah habis tidak menimpa percobaan yang lebih baru
+func (r *PostgresJobRepository) Finish(ctx context.Context, job *domain.Job) (bool, error) {
+	cmdTag, err := r.db.Exec(ctx, `
+		UPDATE jobs
+		SET status = $2, run_at = $3, last_error = $4, finished_at = $5, locked_at = NULL, updated_at = $6
+		WHERE id = $1 AND status = 'running' AND locked_at = $7
+	`, job.ID, job.Status, job.RunAt, job.LastError, job.FinishedAt, job.UpdatedAt, job.LockedAt)
+	if err != nil {
+		return false, err
+	}
+	return cmdTag.RowsAffected() == 1, nil
+}
+
+// RequeueStale: percobaan yang terputus ikut dihitung, jadi job yang selalu membuat worker
+// mati tetap berakhir di dead letter alih-alih berputar selamanya
+func (r *PostgresJobRepository) RequeueStale(ctx context.Context, before time.Time) (int64, error) {
+	cmdTag, err := r.db.Exec(ctx, `
+		UPDATE jobs
+		SET status = CASE WHEN attempts >= max_attempts THEN 'dead' ELSE 'pending' END,
+		    finished_at = CASE WHEN attempts >= max_attempts THEN now() END,
+		    last_error = 'worker stopped while running the job',
+		    run_at = now(), locked_at = NULL, updated_at = now()
+		WHERE status = 'running' AND locked_at < $1
+	`, before)
+	if err != nil {
+		return 0, err
+	}
+	return cmdTag.RowsAffected(), nil
+}
+
+func (r *PostgresJobRepository) GetByID(ctx context.Context, id int64) (*domain.Job, error) {
+	j, err := scanJob(r.db.QueryRow(ctx, `SELECT `+jobColumns+` FROM jobs WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return j, nil
+}
+
+// List memakai keyset pagination di atas id; cursor adalah id terakhir halaman sebelumnya
+func (r *PostgresJobRepository) List(ctx context.Context, filter domain.JobFilter) (*domain.JobPage, error) {
+	var before *int64
+	if filter.Cursor != "" {
+		id, err := strconv.ParseInt(filter.Cursor, 10, 64)
+		if err != nil {
+			return nil, domain.NewValidationError("cursor", "is invalid")
+		}
+		before = &id
+	}
+
+	// ambil satu baris ekstra untuk mengetahui apakah masih ada halaman berikutnya
+	jobs, err := r.queryJobs(ctx, `
+		SELECT `+jobColumns+`
+		FROM jobs
+		WHERE ($1::text = '' OR type = $1)
+		  AND ($2::text = '' OR status = $2)
+		  AND ($3::bigint IS NULL OR id < $3)
+		ORDER BY id DESC
+		LIMIT $4
+	`, filter.Type, filter.Status, before, filter.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &domain.JobPage{Data: jobs}
+	if len(page.Data) > filter.Limit {
+		page.Data = page.Data[:filter.Limit]
+		page.NextCursor = strconv.FormatInt(page.Data[len(page.Data)-1].ID, 10)
+	}
+	return page, nil
+}
+
+func (r *PostgresJobRepository) Stats(ctx context.Context) ([]domain.JobCount, error) {
+	rows, err := r.db.Query(ctx, `SELECT type, status, count(*) FROM jobs GROUP BY type, status ORDER BY type, status`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := []domain.JobCount{}
+	for rows.Next() {
+		var c domain.JobCount
+		if err := rows.Scan(&c.Type, &c.Status, &c.Count); err != nil {
+			return nil, err
+		}
+		counts = append(counts, c)
+	}
+	return counts, rows.Err()
+}
+
+func (r *PostgresJobRepository) Retry(ctx context.Context, id int64, at time.Time) (bool, error) {
+	cmdTag, err := r.db.Exec(ctx, `
+		UPDATE jobs SET status = 'pending', attempts = 0, run_at = $2, finished_at = NULL, updated_at = $2
+		WHERE id = $1 AND status = 'dead'
+	`, id, at)
+	if err != nil {
+		return false, err
+	}
+	return cmdTag.RowsAffected() > 0, nil
+}
+
+func (r *PostgresJobRepository) PurgeFinished(ctx context.Context, jobType string, before time.Time) (int64, error) {
+	cmdTag, err := r.db.Exec(ctx, `
+		DELETE FROM jobs
+		WHERE type = $1 AND status IN ('done', 'dead') AND finished_at < $2
+	`, jobType, before)
+	if err != nil {
+		return 0, err
+	}
+	return cmdTag.RowsAffected(), nil
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// isForeignKeyViolation: baris yang dirujuk (mis. task atau webhook) sudah dihapus
+func isForeignKeyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23503"
+}
+
+type PostgresNotificationRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewNotificationRepository(db *pgxpool.Pool) domain.NotificationRepository {
+	return &PostgresNotificationRepository{db: db}
+}
+
+func (r *PostgresNotificationRepository) Create(ctx context.Context, n *domain.Notification) error {
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO notifications (user_id, type, task_id, actor_id, title, body, created_at, event_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (user_id, event_id) DO NOTHING
+		RETURNING id
+	`, n.UserID, n.Type, n.TaskID, n.ActorID, n.Title, n.Body, n.CreatedAt, n.EventID).Scan(&n.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrConflict
+	}
+	if isForeignKeyViolation(err) {
+		return domain.NotFound("task or user of the notification no longer exists")
+	}
+	return err
+}
+
+// Fetch memakai keyset pagination di atas id; cursor adalah id terakhir halaman sebelumnya
+func (r *PostgresNotificationRepository) Fetch(ctx context.Context, filter domain.NotificationFilter) (*domain.NotificationPage, error) {
+	var before *int64
+	if filter.Cursor != "" {
+		id, err := strconv.ParseInt(filter.Cursor, 10, 64)
+		if err != nil {
+			return nil, domain.NewValidationError("cursor", "is invalid")
+		}
+		before = &id
+	}
+
+	// ambil satu baris ekstra untuk mengetahui apakah masih ada halaman berikutnya
+	rows, err := r.db.Query(ctx, `
+		SELECT id, user_id, type, task_id, actor_id, title, body, read_at, created_at
+		FROM notifications
+		WHERE user_id = $1
+		  AND ($2::boolean = false OR read_at IS NULL)
+		  AND ($3::bigint IS NULL OR id < $3)
+		ORDER BY id DESC
+		LIMIT $4
+	`, filter.UserID, filter.UnreadOnly, before, filter.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	page := &domain.NotificationPage{Data: []domain.Notification{}}
+	for rows.Next() {
+		var n domain.Notification
+		err := rows.Scan(&n.ID, &n.UserID, &n.Type, &n.TaskID, &n.ActorID, &n.Title, &n.Body, &n.ReadAt, &n.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		page.Data = append(page.Data, n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(page.Data) > filter.Limit {
+		page.Data = page.Data[:filter.Limit]
+		page.NextCursor = strconv.FormatInt(page.Data[len(page.Data)-1].ID, 10)
+	}
+	return page, nil
+}
+
+func (r *PostgresNotificationRepository) MarkRead(ctx context.Context, id int64, userID int64, at time.Time) (bool, error) {
+	cmdTag, err := r.db.Exec(ctx, `
+		UPDATE notifications SET read_at = COALESCE(read_at, $3) WHERE id = $1 AND user_id = $2
+	`, id, userID, at)
+	if err != nil {
+		return false, err
+	}
+	return cmdTag.RowsAffected() > 0, nil
+}
+
+func (r *PostgresNotificationRepository) MarkAllRead(ctx context.Context, userID int64, at time.Time) (int64, error) {
+	cmdTag, err := r.db.Exec(ctx, `
+		UPDATE notifications SET read_at = $2 WHERE user_id = $1 AND read_at IS NULL
+	`, userID, at)
+	if err != nil {
+		return 0, err
+	}
+	return cmdTag.RowsAffected(), nil
+}
+
+func (r *PostgresNotificationRepository) CountUnread(ctx context.Context, userID int64) (int64, error) {
+	var count int64
+	err := r.db.QueryRow(ctx, `SELECT count(*) FROM notifications WHERE user_id = $1 AND read_at IS NULL`, userID).Scan(&count)
+	return count, err
+}
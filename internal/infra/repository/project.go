@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"simple-task-manager/internal/core/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresProjectRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewProjectRepository(db *pgxpool.Pool) domain.ProjectRepository {
+	return &PostgresProjectRepository{db: db}
+}
+
+const projectColumns = `id, user_id, workspace_id, name, COALESCE(description, ''), COALESCE(default_priority, ''), COALESCE(default_status, ''), archived_at, created_at, updated_at`
+
+func scanProject(row pgx.Row) (*domain.Project, error) {
+	var p domain.Project
+	err := row.Scan(
+		&p.ID, &p.UserID, &p.WorkspaceID, &p.Name, &p.Description, &p.DefaultPriority, &p.DefaultStatus,
+		&p.ArchivedAt, &p.CreatedAt, &p.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// nullIfEmpty menyimpan string kosong sebagai NULL (berarti "tidak ada default")
+func nullIfEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func (r *PostgresProjectRepository) Create(ctx context.Context, p *domain.Project) error {
+	query := `
+		INSERT INTO projects (user_id, workspace_id, name, description, default_priority, default_status, archived_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id
+	`
+	return r.db.QueryRow(ctx, query,
+		p.UserID, p.WorkspaceID, p.Name, p.Description, nullIfEmpty(p.DefaultPriority), nullIfEmpty(p.DefaultStatus),
+		p.ArchivedAt, p.CreatedAt, p.UpdatedAt,
+	).Scan(&p.ID)
+}
+
+func (r *PostgresProjectRepository) GetByID(ctx context.Context, id int64) (*domain.Project, error) {
+	query := `SELECT ` + projectColumns + ` FROM projects WHERE id = $1`
+
+	p, err := scanProject(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return p, nil
+}
+
+// ListVisible mengembalikan project pribadi user dan project di workspace tempat ia menjadi anggota
+func (r *PostgresProjectRepository) ListVisible(ctx context.Context, userID int64, includeArchived bool) ([]domain.Project, error) {
+	query := `
+		SELECT ` + projectColumns + `
+		FROM projects
+		WHERE ` + fmt.Sprintf(visibleToUser, "projects") + ` AND ($2 OR archived_at IS NULL)
+		ORDER BY archived_at NULLS FIRST, name, id
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, includeArchived)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	projects := []domain.Project{}
+	for rows.Next() {
+		p, err := scanProject(rows)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, *p)
+	}
+	return projects, rows.Err()
+}
+
+func (r *PostgresProjectRepository) Update(ctx context.Context, p *domain.Project) error {
+	query := `
+		UPDATE projects
+		SET name = $1, description = $2, default_priority = $3, default_status = $4, archived_at = $5, updated_at = $6
+		WHERE id = $7
+	`
+
+	cmdTag, err := r.db.Exec(ctx, query,
+		p.Name, p.Description, nullIfEmpty(p.DefaultPriority), nullIfEmpty(p.DefaultStatus),
+		p.ArchivedAt, p.UpdatedAt, p.ID,
+	)
+	if err != nil {
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *PostgresProjectRepository) Delete(ctx context.Context, id int64) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM projects WHERE id = $1`, id)
+	return err
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresRecurrenceRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewRecurrenceRepository(db *pgxpool.Pool) domain.RecurrenceRepository {
+	return &PostgresRecurrenceRepository{db: db}
+}
+
+// ProcessDue memproses task satu per satu, masing-masing dalam transaksinya sendiri, supaya
+// kunci baris hanya ditahan selama satu task dan kegagalan satu task tidak membatalkan yang lain
+func (r *PostgresRecurrenceRepository) ProcessDue(ctx context.Context, now time.Time, limit int, fn func(domain.RecurrenceClaim) domain.RecurrenceAdvance) (int, int, error) {
+	var processed, created int
+	var errs []error
+	tried := []int64{} // task yang gagal tidak diklaim ulang di putaran yang sama
+	for processed+len(errs) < limit {
+		taskID, n, err := r.processOne(ctx, now, tried, fn)
+		if taskID == 0 && err == nil {
+			break // tidak ada lagi task jatuh tempo yang bebas
+		}
+		tried = append(tried, taskID)
+		if err != nil {
+			if taskID == 0 {
+				return processed, created, errors.Join(append(errs, err)...)
+			}
+			errs = append(errs, fmt.Errorf("task %d: %w", taskID, err))
+			continue
+		}
+		processed++
+		created += n
+	}
+	return processed, created, errors.Join(errs...)
+}
+
+// processOne mengklaim satu task jatuh tempo dengan FOR UPDATE SKIP LOCKED. Kunci baris
+// ditahan sampai commit, jadi scheduler lain melewati task ini; setelah commit, next_run-nya
+// sudah maju sehingga tidak lagi cocok dengan kondisi jatuh tempo.
+func (r *PostgresRecurrenceRepository) processOne(ctx context.Context, now time.Time, tried []int64, fn func(domain.RecurrenceClaim) domain.RecurrenceAdvance) (int64, int, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	// Status awal instance mengikuti workflow pemilik (status todo pertama menurut posisi);
+	// tanpa workflow custom, workflow default berawal di pending
+	var t domain.Task
+	var owner domain.User
+	var initial string
+	err = tx.QueryRow(ctx, `
+		SELECT t.id, t.user_id, t.workspace_id, t.project_id, t.title, COALESCE(t.description, ''), t.priority, t.labels,
+			t.reminder_time, t.due_at, t.due_all_day, t.recurrence_pattern,
+			COALESCE(t.recurrence_start, t.next_run), t.next_run, t.missed_run_policy, u.timezone,
+			COALESCE((
+				SELECT s.key FROM task_statuses s
+				WHERE s.user_id = t.user_id AND s.category = 'todo'
+				ORDER BY s.position, s.id
+				LIMIT 1
+			), 'pending')
+		FROM tasks t
+		JOIN users u ON u.id = t.user_id
+		WHERE t.recurrence_pattern <> ''
+		AND t.recurrence_mode = 'schedule'
+		AND t.next_run <= $1
+		AND NOT (t.id = ANY($2))
+		ORDER BY t.next_run, t.id
+		LIMIT 1
+		FOR UPDATE OF t SKIP LOCKED
+	`, now, tried).Scan(&t.ID, &t.UserID, &t.WorkspaceID, &t.ProjectID, &t.Title, &t.Description, &t.Priority, &t.Labels,
+		&t.ReminderTime, &t.DueAt, &t.DueAllDay, &t.RecurrencePattern,
+		&t.RecurrenceStart, &t.NextRun, &t.MissedRunPolicy, &owner.Timezone, &initial)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, 0, nil
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+
+	advance := fn(domain.RecurrenceClaim{Task: t, Location: owner.Location(), InitialStatus: initial})
+
+	created := 0
+	for _, inst := range advance.Instances {
+		ok, err := insertInstance(ctx, tx, &inst.Task, inst.ReminderBase, inst.Event)
+		if err != nil {
+			return t.ID, 0, err
+		}
+		if ok {
+			created++
+		}
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE tasks SET next_run = $1 WHERE id = $2`, advance.NextRun, t.ID); err != nil {
+		return t.ID, 0, err
+	}
+	return t.ID, created, tx.Commit(ctx)
+}
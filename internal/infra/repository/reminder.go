@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresReminderRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewReminderRepository(db *pgxpool.Pool) domain.ReminderRepository {
+	return &PostgresReminderRepository{db: db}
+}
+
+// ScheduleDue mengambil reminder dari tasks.reminder_time dan task_reminders;
+// keduanya bisa jatuh di waktu yang sama dan cukup dikirim sekali.
+// Mengandalkan UNIQUE (task_id, remind_at, user_id, channel): replica yang
+// kalah balapan cukup mendapat ON CONFLICT DO NOTHING
+func (r *PostgresReminderRepository) ScheduleDue(ctx context.Context, now time.Time, lookback time.Duration, channels []string) (int64, error) {
+	cmdTag, err := r.db.Exec(ctx, `
+		INSERT INTO reminder_deliveries (task_id, remind_at, user_id, channel, status, next_attempt_at, created_at, updated_at)
+		SELECT t.id, rm.remind_at, rcpt.user_id, ch.channel, 'pending', $1, $1, $1
+		FROM (
+			SELECT id AS task_id, reminder_time AS remind_at FROM tasks WHERE reminder_time IS NOT NULL
+			UNION
+			SELECT task_id, remind_at FROM task_reminders WHERE remind_at IS NOT NULL
+		) rm
+		JOIN tasks t ON t.id = rm.task_id
+		CROSS JOIN LATERAL (
+			SELECT t.user_id
+			UNION
+			SELECT a.user_id FROM task_assignees a WHERE a.task_id = t.id
+		) rcpt(user_id)
+		CROSS JOIN unnest($3::text[]) ch(channel)
+		WHERE rm.remind_at <= $1
+		  AND rm.remind_at > $2
+		  AND t.completed_at IS NULL
+		ON CONFLICT (task_id, remind_at, user_id, channel) DO NOTHING
+	`, now, now.Add(-lookback), channels)
+	if err != nil {
+		return 0, err
+	}
+	return cmdTag.RowsAffected(), nil
+}
+
+func (r *PostgresReminderRepository) ClaimDue(ctx context.Context, now time.Time, limit int) ([]domain.ReminderDelivery, error) {
+	rows, err := r.db.Query(ctx, `
+		WITH claimed AS (
+			UPDATE reminder_deliveries d
+			SET status = 'sending', attempts = d.attempts + 1, updated_at = $1
+			WHERE d.id IN (
+				SELECT id FROM reminder_deliveries
+				WHERE status = 'pending' AND next_attempt_at <= $1
+				ORDER BY next_attempt_at, id
+				LIMIT $2
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING d.id, d.task_id, d.user_id, d.channel, d.remind_at, d.attempts
+		)
+		SELECT c.id, c.task_id, c.user_id, c.channel, c.remind_at, c.attempts,
+		       t.title, t.due_at, u.name, u.email
+		FROM claimed c
+		JOIN tasks t ON t.id = c.task_id
+		JOIN users u ON u.id = c.user_id
+		ORDER BY c.remind_at, c.id
+	`, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []domain.ReminderDelivery{}
+	for rows.Next() {
+		var d domain.ReminderDelivery
+		err := rows.Scan(
+			&d.ID, &d.TaskID, &d.UserID, &d.Channel, &d.RemindAt, &d.Attempts,
+			&d.TaskTitle, &d.DueAt, &d.UserName, &d.UserEmail,
+		)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, rows.Err()
+}
+
+func (r *PostgresReminderRepository) MarkSent(ctx context.Context, id int64, at time.Time) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE reminder_deliveries SET status = 'sent', sent_at = $1, last_error = NULL, updated_at = $1
+		WHERE id = $2 AND status = 'sending'
+	`, at, id)
+	return err
+}
+
+func (r *PostgresReminderRepository) MarkFailed(ctx context.Context, id int64, reason string, retryAt *time.Time, at time.Time) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE reminder_deliveries
+		SET status = CASE WHEN $2::timestamptz IS NULL THEN 'failed' ELSE 'pending' END,
+		    next_attempt_at = COALESCE($2, next_attempt_at),
+		    last_error = $3,
+		    updated_at = $4
+		WHERE id = $1 AND status = 'sending'
+	`, id, retryAt, reason, at)
+	return err
+}
+
+func (r *PostgresReminderRepository) AbandonStale(ctx context.Context, before time.Time) (int64, error) {
+	cmdTag, err := r.db.Exec(ctx, `
+		UPDATE reminder_deliveries
+		SET status = 'failed', last_error = 'interrupted while sending; not retried to avoid a duplicate', updated_at = now()
+		WHERE status = 'sending' AND updated_at < $1
+	`, before)
+	if err != nil {
+		return 0, err
+	}
+	return cmdTag.RowsAffected(), nil
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresSessionRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewSessionRepository(db *pgxpool.Pool) domain.SessionRepository {
+	return &PostgresSessionRepository{db: db}
+}
+
+func (r *PostgresSessionRepository) CreateSession(ctx context.Context, s *domain.Session) error {
+	query := `
+		INSERT INTO auth_sessions (user_id, created_at, expires_at)
+		VALUES ($1, $2, $3)
+		RETURNING id
+	`
+	return r.db.QueryRow(ctx, query, s.UserID, s.CreatedAt, s.ExpiresAt).Scan(&s.ID)
+}
+
+func (r *PostgresSessionRepository) GetSession(ctx context.Context, id int64) (*domain.Session, error) {
+	query := `SELECT id, user_id, created_at, expires_at, revoked_at FROM auth_sessions WHERE id = $1`
+
+	var s domain.Session
+	err := r.db.QueryRow(ctx, query, id).Scan(&s.ID, &s.UserID, &s.CreatedAt, &s.ExpiresAt, &s.RevokedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+func (r *PostgresSessionRepository) RevokeSession(ctx context.Context, id int64, at time.Time) error {
+	_, err := r.db.Exec(ctx, `UPDATE auth_sessions SET revoked_at = $1 WHERE id = $2 AND revoked_at IS NULL`, at, id)
+	return err
+}
+
+func (r *PostgresSessionRepository) RevokeUserSessions(ctx context.Context, userID int64, at time.Time) error {
+	_, err := r.db.Exec(ctx, `UPDATE auth_sessions SET revoked_at = $1 WHERE user_id = $2 AND revoked_at IS NULL`, at, userID)
+	return err
+}
+
+func (r *PostgresSessionRepository) CreateRefreshToken(ctx context.Context, t *domain.RefreshToken) error {
+	query := `
+		INSERT INTO refresh_tokens (session_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`
+	return r.db.QueryRow(ctx, query, t.SessionID, t.TokenHash, t.ExpiresAt, t.CreatedAt).Scan(&t.ID)
+}
+
+func (r *PostgresSessionRepository) GetRefreshTokenByHash(ctx context.Context, hash string) (*domain.RefreshToken, error) {
+	query := `SELECT id, session_id, token_hash, expires_at, used_at, created_at FROM refresh_tokens WHERE token_hash = $1`
+
+	var t domain.RefreshToken
+	err := r.db.QueryRow(ctx, query, hash).Scan(&t.ID, &t.SessionID, &t.TokenHash, &t.ExpiresAt, &t.UsedAt, &t.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// MarkRefreshTokenUsed memakai kondisi used_at IS NULL supaya dua request paralel
+// dengan token yang sama tidak sama-sama berhasil
+func (r *PostgresSessionRepository) MarkRefreshTokenUsed(ctx context.Context, id int64, at time.Time) (bool, error) {
+	cmdTag, err := r.db.Exec(ctx, `UPDATE refresh_tokens SET used_at = $1 WHERE id = $2 AND used_at IS NULL`, at, id)
+	if err != nil {
+		return false, err
+	}
+	return cmdTag.RowsAffected() == 1, nil
+}
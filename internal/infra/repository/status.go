@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+
+	"simple-task-manager/internal/core/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresStatusRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewStatusRepository(db *pgxpool.Pool) domain.StatusRepository {
+	return &PostgresStatusRepository{db: db}
+}
+
+// GetWorkflow mengembalikan nil jika user belum punya status custom
+func (r *PostgresStatusRepository) GetWorkflow(ctx context.Context, userID int64) (*domain.Workflow, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT key, name, category, position
+		FROM task_statuses
+		WHERE user_id = $1
+		ORDER BY position, id
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	wf := &domain.Workflow{Statuses: []domain.TaskStatus{}, Transitions: []domain.StatusTransition{}}
+	for rows.Next() {
+		var st domain.TaskStatus
+		if err := rows.Scan(&st.Key, &st.Name, &st.Category, &st.Position); err != nil {
+			return nil, err
+		}
+		wf.Statuses = append(wf.Statuses, st)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(wf.Statuses) == 0 {
+		return nil, nil
+	}
+
+	trRows, err := r.db.Query(ctx, `
+		SELECT from_status, to_status
+		FROM task_status_transitions
+		WHERE user_id = $1
+		ORDER BY from_status, to_status
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer trRows.Close()
+
+	for trRows.Next() {
+		var tr domain.StatusTransition
+		if err := trRows.Scan(&tr.From, &tr.To); err != nil {
+			return nil, err
+		}
+		wf.Transitions = append(wf.Transitions, tr)
+	}
+
+	return wf, trRows.Err()
+}
+
+// SaveWorkflow mengganti seluruh status & transisi user dalam satu transaksi
+func (r *PostgresStatusRepository) SaveWorkflow(ctx context.Context, userID int64, wf *domain.Workflow) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := deleteWorkflow(ctx, tx, userID); err != nil {
+		return err
+	}
+
+	for _, st := range wf.Statuses {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO task_statuses (user_id, key, name, category, position)
+			VALUES ($1, $2, $3, $4, $5)
+		`, userID, st.Key, st.Name, st.Category, st.Position)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, tr := range wf.Transitions {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO task_status_transitions (user_id, from_status, to_status)
+			VALUES ($1, $2, $3)
+			ON CONFLICT DO NOTHING
+		`, userID, tr.From, tr.To)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *PostgresStatusRepository) DeleteWorkflow(ctx context.Context, userID int64) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := deleteWorkflow(ctx, tx, userID); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func deleteWorkflow(ctx context.Context, tx pgx.Tx, userID int64) error {
+	if _, err := tx.Exec(ctx, "DELETE FROM task_status_transitions WHERE user_id = $1", userID); err != nil {
+		return err
+	}
+	_, err := tx.Exec(ctx, "DELETE FROM task_statuses WHERE user_id = $1", userID)
+	return err
+}
+
+func (r *PostgresStatusRepository) LogChange(ctx context.Context, change *domain.StatusChange) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO task_status_history (task_id, user_id, from_status, to_status, changed_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`, change.TaskID, change.UserID, change.From, change.To, change.ChangedAt)
+	return err
+}
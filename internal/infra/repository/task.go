@@ -2,6 +2,13 @@ package repository
 
 import (
     "context"
+    "encoding/base64"
+    "encoding/json"
+    "fmt"
+    "strconv"
+    "strings"
+    "time"
+
     "simple-task-manager/internal/core/domain"
 
     "github.com/jackc/pgx/v5"
@@ -16,13 +23,16 @@ func NewTaskRepository(db *pgxpool.Pool) domain.TaskRepository {
     return &PostgresTaskRepository{db: db}
 }
 
-// Create task baru
-func (r *PostgresTaskRepository) Create(ctx context.Context, task *domain.Task) error {
+// Create task baru beserta event-nya dalam satu transaksi
+func (r *PostgresTaskRepository) Create(ctx context.Context, task *domain.Task, e domain.Event) error {
     query := `
         INSERT INTO tasks (
-            user_id, title, description, status, priority, labels, reminder_time, recurrence_pattern, next_run, created_at, updated_at
+            user_id, title, description, status, priority, labels, reminder_time, recurrence_pattern, next_run,
+            status_changed_at, started_at, completed_at, created_at, updated_at, project_id, workspace_id,
+            due_at, due_all_day, recurrence_start, missed_run_policy, recurrence_mode
         )
-        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19,
+            COALESCE(NULLIF($20, ''), 'latest'), COALESCE(NULLIF($21, ''), 'schedule'))
         RETURNING id
     `
 
@@ -31,7 +41,13 @@ func (r *PostgresTaskRepository) Create(ctx context.Context, task *domain.Task)
         task.Priority = "medium"
     }
 
-    err := r.db.QueryRow(ctx, query,
+    tx, err := r.db.Begin(ctx)
+    if err != nil {
+        return err
+    }
+    defer tx.Rollback(ctx)
+
+    err = tx.QueryRow(ctx, query,
         task.UserID,
         task.Title,
         task.Description,
@@ -41,49 +57,266 @@ func (r *PostgresTaskRepository) Create(ctx context.Context, task *domain.Task)
         task.ReminderTime,
         task.RecurrencePattern,
         task.NextRun,
+        task.StatusChangedAt,
+        task.StartedAt,
+        task.CompletedAt,
         task.CreatedAt,
         task.UpdatedAt,
+        task.ProjectID,
+        task.WorkspaceID,
+        task.DueAt,
+        task.DueAllDay,
+        task.RecurrenceStart,
+        task.MissedRunPolicy,
+        task.RecurrenceMode,
     ).Scan(&task.ID)
+    if err != nil {
+        return err
+    }
 
-    return err
+    e.TaskID = task.ID
+    if err := insertTaskEvent(ctx, tx, e); err != nil {
+        return err
+    }
+    return tx.Commit(ctx)
 }
 
 // --- SUBTASK METHODS ---
 
-func (r *PostgresTaskRepository) CreateSubtask(ctx context.Context, sub *domain.Subtask) error {
+func (r *PostgresTaskRepository) CreateSubtask(ctx context.Context, sub *domain.Subtask, e domain.Event) error {
     query := `INSERT INTO subtasks (task_id, title, is_done, created_at) VALUES ($1, $2, $3, NOW()) RETURNING id`
-    return r.db.QueryRow(ctx, query, sub.TaskID, sub.Title, sub.IsDone).Scan(&sub.ID)
+    return withTaskEvent(ctx, r.db, sub.TaskID, e, func(tx pgx.Tx) error {
+        return tx.QueryRow(ctx, query, sub.TaskID, sub.Title, sub.IsDone).Scan(&sub.ID)
+    })
+}
+
+func (r *PostgresTaskRepository) DeleteSubtask(ctx context.Context, id int64, e domain.Event) error {
+    return withTaskEvent(ctx, r.db, e.TaskID, e, func(tx pgx.Tx) error {
+        _, err := tx.Exec(ctx, "DELETE FROM subtasks WHERE id = $1", id)
+        return err
+    })
+}
+
+func (r *PostgresTaskRepository) ToggleSubtask(ctx context.Context, id int64, e domain.Event) error {
+    return withTaskEvent(ctx, r.db, e.TaskID, e, func(tx pgx.Tx) error {
+        _, err := tx.Exec(ctx, "UPDATE subtasks SET is_done = NOT is_done WHERE id = $1", id)
+        return err
+    })
+}
+
+func (r *PostgresTaskRepository) GetSubtaskTaskID(ctx context.Context, id int64) (int64, error) {
+    var taskID int64
+    err := r.db.QueryRow(ctx, "SELECT task_id FROM subtasks WHERE id = $1", id).Scan(&taskID)
+    if err != nil {
+        if err == pgx.ErrNoRows {
+            return 0, domain.ErrNotFound
+        }
+        return 0, err
+    }
+    return taskID, nil
 }
 
-func (r *PostgresTaskRepository) DeleteSubtask(ctx context.Context, id int64) error {
-    _, err := r.db.Exec(ctx, "DELETE FROM subtasks WHERE id = $1", id)
-    return err
+// --- ASSIGNEE METHODS ---
+
+func (r *PostgresTaskRepository) AddAssignee(ctx context.Context, taskID int64, userID int64, assignedBy int64, at time.Time, e domain.Event) (bool, error) {
+    var added bool
+    err := withTaskEvent(ctx, r.db, taskID, e, func(tx pgx.Tx) error {
+        cmdTag, err := tx.Exec(ctx, `
+            INSERT INTO task_assignees (task_id, user_id, assigned_by, assigned_at)
+            VALUES ($1, $2, $3, $4)
+            ON CONFLICT DO NOTHING
+        `, taskID, userID, assignedBy, at)
+        if err != nil {
+            return err
+        }
+        if added = cmdTag.RowsAffected() > 0; !added {
+            return errNoTaskEvent
+        }
+        return nil
+    })
+    return added, err
 }
 
-func (r *PostgresTaskRepository) ToggleSubtask(ctx context.Context, id int64) error {
-    _, err := r.db.Exec(ctx, "UPDATE subtasks SET is_done = NOT is_done WHERE id = $1", id)
-    return err
+func (r *PostgresTaskRepository) RemoveAssignee(ctx context.Context, taskID int64, userID int64, e domain.Event) (bool, error) {
+    var removed bool
+    err := withTaskEvent(ctx, r.db, taskID, e, func(tx pgx.Tx) error {
+        cmdTag, err := tx.Exec(ctx, "DELETE FROM task_assignees WHERE task_id = $1 AND user_id = $2", taskID, userID)
+        if err != nil {
+            return err
+        }
+        if removed = cmdTag.RowsAffected() > 0; !removed {
+            return errNoTaskEvent
+        }
+        return nil
+    })
+    return removed, err
 }
 
+// taskAssigneesExpr mengambil ID assignee task t sebagai array, urut sesuai waktu assign
+const taskAssigneesExpr = `COALESCE((SELECT array_agg(a.user_id ORDER BY a.assigned_at, a.user_id) FROM task_assignees a WHERE a.task_id = t.id), '{}')`
+
 // --- TASK METHODS ---
 
-// Fetch semua task milik user (lengkap dengan subtasks)
-func (r *PostgresTaskRepository) Fetch(ctx context.Context, userID int64) ([]domain.Task, error) {
+// taskSortColumns memetakan sort key ke ekspresi SQL dan tipe Postgres
+// yang dipakai untuk meng-cast nilai cursor kembali saat keyset pagination.
+// reminder_time & due_at di-COALESCE supaya task tanpa reminder tetap punya posisi
+// yang stabil (selalu di akhir untuk urutan ascending).
+var taskSortColumns = map[string]struct {
+    expr string
+    cast string
+}{
+    domain.TaskSortCreatedAt:    {"t.created_at", "timestamptz"},
+    domain.TaskSortUpdatedAt:    {"t.updated_at", "timestamptz"},
+    domain.TaskSortReminderTime: {"COALESCE(t.reminder_time, 'infinity'::timestamptz)", "timestamptz"},
+    domain.TaskSortPriority:     {"CASE COALESCE(t.priority, 'medium') WHEN 'high' THEN 3 WHEN 'medium' THEN 2 ELSE 1 END", "int"},
+    domain.TaskSortTitle:        {"t.title", "text"},
+    domain.TaskSortDueAt:        {"COALESCE(t.due_at, 'infinity'::timestamptz)", "timestamptz"},
+}
+
+// taskCursor adalah isi cursor pagination sebelum di-encode base64.
+// Sort key dan arah ikut disimpan agar cursor tidak dipakai dengan urutan lain.
+type taskCursor struct {
+    SortBy string `json:"s"`
+    Desc   bool   `json:"d"`
+    Value  string `json:"v"`
+    ID     int64  `json:"id"`
+}
+
+func encodeTaskCursor(c taskCursor) string {
+    b, _ := json.Marshal(c)
+    return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeTaskCursor(s string) (*taskCursor, error) {
+    b, err := base64.RawURLEncoding.DecodeString(s)
+    if err != nil {
+        return nil, err
+    }
+    var c taskCursor
+    if err := json.Unmarshal(b, &c); err != nil {
+        return nil, err
+    }
+    return &c, nil
+}
+
+// visibleToUser adalah kondisi resource yang boleh dilihat user $1: miliknya
+// sendiri yang pribadi, atau berada di workspace tempat ia menjadi anggota
+const visibleToUser = `((%[1]s.user_id = $1 AND %[1]s.workspace_id IS NULL)
+            OR %[1]s.workspace_id IN (SELECT workspace_id FROM workspace_members WHERE user_id = $1))`
+
+// Fetch task yang terlihat oleh user (lengkap dengan subtasks) sesuai filter, dengan keyset pagination
+func (r *PostgresTaskRepository) Fetch(ctx context.Context, filter domain.TaskFilter) (*domain.TaskPage, error) {
+    sortCol, ok := taskSortColumns[filter.SortBy]
+    if !ok {
+        return nil, domain.NewValidationError("sort", "unknown sort key %q", filter.SortBy)
+    }
+
+    args := []interface{}{filter.UserID}
+    conds := []string{fmt.Sprintf(visibleToUser, "t")}
+    addCond := func(format string, values ...interface{}) {
+        idx := make([]interface{}, len(values))
+        for i, v := range values {
+            args = append(args, v)
+            idx[i] = len(args)
+        }
+        conds = append(conds, fmt.Sprintf(format, idx...))
+    }
+
+    if len(filter.Statuses) > 0 {
+        addCond("t.status = ANY($%d)", filter.Statuses)
+    }
+    if len(filter.Priorities) > 0 {
+        addCond("COALESCE(t.priority, 'medium') = ANY($%d)", filter.Priorities)
+    }
+    if len(filter.Labels) > 0 {
+        addCond("t.labels && $%d", filter.Labels)
+    }
+    if filter.WorkspaceID != nil {
+        addCond("t.workspace_id = $%d", *filter.WorkspaceID)
+    }
+    if filter.PersonalOnly {
+        conds = append(conds, "t.workspace_id IS NULL")
+    }
+    if filter.AssigneeID != nil {
+        addCond("EXISTS (SELECT 1 FROM task_assignees a WHERE a.task_id = t.id AND a.user_id = $%d)", *filter.AssigneeID)
+    }
+    if filter.ProjectID != nil {
+        addCond("t.project_id = $%d", *filter.ProjectID)
+    }
+    if filter.WithoutProject {
+        conds = append(conds, "t.project_id IS NULL")
+    }
+    if filter.CreatedFrom != nil {
+        addCond("t.created_at >= $%d", *filter.CreatedFrom)
+    }
+    if filter.CreatedTo != nil {
+        addCond("t.created_at < $%d", *filter.CreatedTo)
+    }
+    if filter.UpdatedFrom != nil {
+        addCond("t.updated_at >= $%d", *filter.UpdatedFrom)
+    }
+    if filter.UpdatedTo != nil {
+        addCond("t.updated_at < $%d", *filter.UpdatedTo)
+    }
+    if filter.ReminderFrom != nil {
+        addCond("t.reminder_time >= $%d", *filter.ReminderFrom)
+    }
+    if filter.ReminderTo != nil {
+        addCond("t.reminder_time < $%d", *filter.ReminderTo)
+    }
+    if filter.DueFrom != nil {
+        addCond("t.due_at >= $%d", *filter.DueFrom)
+    }
+    if filter.DueTo != nil {
+        addCond("t.due_at < $%d", *filter.DueTo)
+    }
+    if filter.Incomplete {
+        conds = append(conds, "t.completed_at IS NULL")
+    }
+
+    direction, cmp := "ASC", ">"
+    if filter.SortDesc {
+        direction, cmp = "DESC", "<"
+    }
+
+    if filter.Cursor != "" {
+        cur, err := decodeTaskCursor(filter.Cursor)
+        if err != nil || cur.SortBy != filter.SortBy || cur.Desc != filter.SortDesc {
+            return nil, domain.NewValidationError("cursor", "invalid or does not match the requested sort")
+        }
+        addCond("("+sortCol.expr+", t.id) "+cmp+" ($%d::"+sortCol.cast+", $%d)", cur.Value, cur.ID)
+    }
+
+    args = append(args, filter.Limit+1)
+
     query := `
         SELECT 
             t.id, 
             t.user_id, 
+            t.workspace_id,
+            t.project_id,
             t.title, 
             COALESCE(t.description, ''), 
             t.status, 
             COALESCE(t.priority, 'medium'), 
             t.labels, 
             t.reminder_time,
+            t.due_at,
+            t.due_all_day,
+            ` + taskAssigneesExpr + `,
             
             -- FITUR BARU: Handle NULL Recurrence
             COALESCE(t.recurrence_pattern, ''), 
+            t.recurrence_start,
             t.next_run,
+            t.recurrence_mode,
+            t.missed_run_policy,
+            t.recurrence_parent_id,
+            t.occurrence_at,
             
+            t.status_changed_at,
+            t.started_at,
+            t.completed_at,
             t.created_at, 
             t.updated_at,
             COALESCE(
@@ -91,54 +324,88 @@ func (r *PostgresTaskRepository) Fetch(ctx context.Context, userID int64) ([]dom
                     json_build_object('id', s.id, 'task_id', s.task_id, 'title', s.title, 'is_done', s.is_done)
                 ) FILTER (WHERE s.id IS NOT NULL), 
                 '[]'
-            ) as subtasks
+            ) as subtasks,
+            (` + sortCol.expr + `)::text as sort_key
         FROM tasks t
         LEFT JOIN subtasks s ON s.task_id = t.id
-        WHERE t.user_id = $1
+        WHERE ` + strings.Join(conds, " AND ") + `
         GROUP BY t.id
-        ORDER BY t.created_at DESC
-    `
+        ORDER BY ` + sortCol.expr + ` ` + direction + `, t.id ` + direction + `
+        LIMIT $` + strconv.Itoa(len(args))
 
-    rows, err := r.db.Query(ctx, query, userID)
+    rows, err := r.db.Query(ctx, query, args...)
     if err != nil {
         return nil, err
     }
     defer rows.Close()
 
-    tasks := []domain.Task{}
+    page := &domain.TaskPage{Tasks: []domain.Task{}}
+    var lastSortKey string
     for rows.Next() {
         var t domain.Task
+        var sortKey string
         err := rows.Scan(
-            &t.ID, &t.UserID, &t.Title, &t.Description, &t.Status,
-            &t.Priority, &t.Labels, &t.ReminderTime,
+            &t.ID, &t.UserID, &t.WorkspaceID, &t.ProjectID, &t.Title, &t.Description, &t.Status,
+            &t.Priority, &t.Labels, &t.ReminderTime, &t.DueAt, &t.DueAllDay, &t.AssigneeIDs,
             &t.RecurrencePattern,
+            &t.RecurrenceStart,
             &t.NextRun,
+            &t.RecurrenceMode,
+            &t.MissedRunPolicy,
+            &t.RecurrenceParentID,
+            &t.OccurrenceAt,
+            &t.StatusChangedAt, &t.StartedAt, &t.CompletedAt,
             &t.CreatedAt, &t.UpdatedAt,
             &t.Subtasks,
+            &sortKey,
         )
         if err != nil {
             return nil, err
         }
-        tasks = append(tasks, t)
+
+        // Baris ke-(limit+1) hanya penanda bahwa masih ada halaman berikutnya
+        if len(page.Tasks) == filter.Limit {
+            last := page.Tasks[len(page.Tasks)-1]
+            page.NextCursor = encodeTaskCursor(taskCursor{
+                SortBy: filter.SortBy,
+                Desc:   filter.SortDesc,
+                Value:  lastSortKey,
+                ID:     last.ID,
+            })
+            break
+        }
+
+        page.Tasks = append(page.Tasks, t)
+        lastSortKey = sortKey
+    }
+    if err := rows.Err(); err != nil {
+        return nil, err
     }
 
-    return tasks, nil
+    return page, nil
 }
 
 // GetByID task berdasarkan ID
 func (r *PostgresTaskRepository) GetByID(ctx context.Context, id int64) (*domain.Task, error) {
     query := `
         SELECT 
-            t.id, t.user_id, t.title, 
+            t.id, t.user_id, t.workspace_id, t.project_id, t.title, 
             COALESCE(t.description, ''), 
             t.status, 
             COALESCE(t.priority, 'medium'), 
-            t.labels, t.reminder_time,
+            t.labels, t.reminder_time, t.due_at, t.due_all_day,
+            ` + taskAssigneesExpr + `,
             
             -- FITUR BARU
             COALESCE(t.recurrence_pattern, ''), 
+            t.recurrence_start,
             t.next_run,
+            t.recurrence_mode,
+            t.missed_run_policy,
+            t.recurrence_parent_id,
+            t.occurrence_at,
             
+            t.status_changed_at, t.started_at, t.completed_at,
             t.created_at, t.updated_at
         FROM tasks t
         WHERE t.id = $1
@@ -146,10 +413,16 @@ func (r *PostgresTaskRepository) GetByID(ctx context.Context, id int64) (*domain
 
     var t domain.Task
     err := r.db.QueryRow(ctx, query, id).Scan(
-        &t.ID, &t.UserID, &t.Title, &t.Description, &t.Status,
-        &t.Priority, &t.Labels, &t.ReminderTime,
+        &t.ID, &t.UserID, &t.WorkspaceID, &t.ProjectID, &t.Title, &t.Description, &t.Status,
+        &t.Priority, &t.Labels, &t.ReminderTime, &t.DueAt, &t.DueAllDay, &t.AssigneeIDs,
         &t.RecurrencePattern,
+        &t.RecurrenceStart,
         &t.NextRun,
+        &t.RecurrenceMode,
+        &t.MissedRunPolicy,
+        &t.RecurrenceParentID,
+        &t.OccurrenceAt,
+        &t.StatusChangedAt, &t.StartedAt, &t.CompletedAt,
         &t.CreatedAt, &t.UpdatedAt,
     )
 
@@ -163,15 +436,48 @@ func (r *PostgresTaskRepository) GetByID(ctx context.Context, id int64) (*domain
     return &t, nil
 }
 
-// Update task (support update status, priority, labels, reminder_time, recurrence)
-func (r *PostgresTaskRepository) Update(ctx context.Context, task *domain.Task) error {
+// Update task (support update status, priority, labels, reminder_time, recurrence, timestamp workflow)
+func (r *PostgresTaskRepository) Update(ctx context.Context, task *domain.Task, e domain.Event) error {
+    tx, err := r.db.Begin(ctx)
+    if err != nil {
+        return err
+    }
+    defer tx.Rollback(ctx)
+
+    if err := updateTask(ctx, tx, task, e); err != nil {
+        return err
+    }
+    return tx.Commit(ctx)
+}
+
+// UpdateSeries menyimpan template & instance seri recurring dalam satu transaksi
+func (r *PostgresTaskRepository) UpdateSeries(ctx context.Context, tasks []*domain.Task, events []domain.Event) error {
+    tx, err := r.db.Begin(ctx)
+    if err != nil {
+        return err
+    }
+    defer tx.Rollback(ctx)
+
+    for i, task := range tasks {
+        if err := updateTask(ctx, tx, task, events[i]); err != nil {
+            return err
+        }
+    }
+    return tx.Commit(ctx)
+}
+
+func updateTask(ctx context.Context, tx pgx.Tx, task *domain.Task, e domain.Event) error {
     query := `
         UPDATE tasks 
-        SET title = $1, description = $2, status = $3, priority = $4, labels = $5, reminder_time = $6, recurrence_pattern = $7, next_run = $8, updated_at = $9
-        WHERE id = $10
+        SET title = $1, description = $2, status = $3, priority = $4, labels = $5, reminder_time = $6, recurrence_pattern = $7, next_run = $8,
+            status_changed_at = $9, started_at = $10, completed_at = $11, updated_at = $12, project_id = $13,
+            due_at = $14, due_all_day = $15, recurrence_start = $16,
+            missed_run_policy = COALESCE(NULLIF($17, ''), missed_run_policy),
+            recurrence_mode = COALESCE(NULLIF($18, ''), recurrence_mode)
+        WHERE id = $19
     `
 
-    cmdTag, err := r.db.Exec(ctx, query,
+    cmdTag, err := tx.Exec(ctx, query,
         task.Title,
         task.Description,
         task.Status,
@@ -180,7 +486,16 @@ func (r *PostgresTaskRepository) Update(ctx context.Context, task *domain.Task)
         task.ReminderTime,
         task.RecurrencePattern,
         task.NextRun,
+        task.StatusChangedAt,
+        task.StartedAt,
+        task.CompletedAt,
         task.UpdatedAt,
+        task.ProjectID,
+        task.DueAt,
+        task.DueAllDay,
+        task.RecurrenceStart,
+        task.MissedRunPolicy,
+        task.RecurrenceMode,
         task.ID,
     )
     if err != nil {
@@ -191,12 +506,183 @@ func (r *PostgresTaskRepository) Update(ctx context.Context, task *domain.Task)
         return domain.ErrNotFound
     }
 
-    return nil
+    e.TaskID = task.ID
+    return insertTaskEvent(ctx, tx, e)
+}
+
+// UpdateWith menyimpan task beserta efek turunannya dalam satu transaksi
+func (r *PostgresTaskRepository) UpdateWith(ctx context.Context, task *domain.Task, e domain.Event, effects domain.TaskEffects) error {
+    tx, err := r.db.Begin(ctx)
+    if err != nil {
+        return err
+    }
+    defer tx.Rollback(ctx)
+
+    if err := updateTask(ctx, tx, task, e); err != nil {
+        return err
+    }
+    if next := effects.NextInstance; next != nil {
+        if _, err := insertNextInstance(ctx, tx, &next.Task, next.ReminderBase, next.Event); err != nil {
+            return err
+        }
+    }
+    if effects.RecomputeReminders {
+        if err := recomputeRelative(ctx, tx, task.ID, effects.ReminderBase, task.UpdatedAt); err != nil {
+            return err
+        }
+    }
+    return tx.Commit(ctx)
+}
+
+// insertNextInstance membuat kemunculan berikutnya seri berbasis penyelesaian. Baris template
+// dikunci sampai transaksi selesai supaya dua penyelesaian bersamaan tidak sama-sama melihat
+// seri tanpa kemunculan terbuka.
+func insertNextInstance(ctx context.Context, tx pgx.Tx, inst *domain.Task, reminderBase *time.Time, e domain.Event) (bool, error) {
+    var open bool
+    err := tx.QueryRow(ctx, `SELECT id FROM tasks WHERE id = $1 FOR UPDATE`, *inst.RecurrenceParentID).Scan(new(int64))
+    if err == pgx.ErrNoRows {
+        return false, nil // template sudah dihapus: seri berhenti
+    }
+    if err != nil {
+        return false, err
+    }
+    err = tx.QueryRow(ctx, `
+        SELECT EXISTS (
+            SELECT 1 FROM tasks
+            WHERE (id = $1 OR recurrence_parent_id = $1) AND completed_at IS NULL
+        )`, *inst.RecurrenceParentID).Scan(&open)
+    if err != nil || open {
+        return false, err // kemunculan berikutnya sudah ada
+    }
+    return insertInstance(ctx, tx, inst, reminderBase, e)
+}
+
+// insertInstance menulis instance, salinan checklist & reminder relatif, dan event-nya
+// memakai transaksi pemanggil. Bernilai false jika instance untuk kemunculan itu sudah ada.
+func insertInstance(ctx context.Context, tx pgx.Tx, inst *domain.Task, reminderBase *time.Time, e domain.Event) (bool, error) {
+    // Unik per (template, kemunculan): instance yang sudah ada dilewati
+    query := `
+        INSERT INTO tasks (user_id, workspace_id, project_id, title, description, status, priority, labels,
+            reminder_time, due_at, due_all_day, recurrence_parent_id, occurrence_at,
+            status_changed_at, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $15)
+        ON CONFLICT (recurrence_parent_id, occurrence_at) WHERE recurrence_parent_id IS NOT NULL DO NOTHING
+        RETURNING id
+    `
+    err := tx.QueryRow(ctx, query,
+        inst.UserID,
+        inst.WorkspaceID,
+        inst.ProjectID,
+        inst.Title,
+        inst.Description,
+        inst.Status,
+        inst.Priority,
+        inst.Labels,
+        inst.ReminderTime,
+        inst.DueAt,
+        inst.DueAllDay,
+        inst.RecurrenceParentID,
+        inst.OccurrenceAt,
+        inst.StatusChangedAt,
+        inst.CreatedAt,
+    ).Scan(&inst.ID)
+    if err == pgx.ErrNoRows {
+        return false, nil
+    }
+    if err != nil {
+        return false, err
+    }
+
+    _, err = tx.Exec(ctx, `
+        INSERT INTO subtasks (task_id, title, is_done)
+        SELECT $1, title, false FROM subtasks WHERE task_id = $2 ORDER BY id
+    `, inst.ID, *inst.RecurrenceParentID)
+    if err != nil {
+        return false, err
+    }
+
+    // Reminder relatif dihitung dari tenggat instance; NULL selama instance tanpa tenggat
+    _, err = tx.Exec(ctx, `
+        INSERT INTO task_reminders (task_id, offset_minutes, remind_at)
+        SELECT $1, offset_minutes, $2::timestamptz - make_interval(mins => offset_minutes)
+        FROM task_reminders WHERE task_id = $3 AND offset_minutes IS NOT NULL ORDER BY id
+    `, inst.ID, reminderBase, *inst.RecurrenceParentID)
+    if err != nil {
+        return false, err
+    }
+
+    e.TaskID = inst.ID
+    if err := insertTaskEvent(ctx, tx, e); err != nil {
+        return false, err
+    }
+    return true, nil
 }
 
-// Delete task
-func (r *PostgresTaskRepository) Delete(ctx context.Context, id int64) error {
+// ListSeries mengembalikan instance yang dibuat scheduler dari template parentID
+func (r *PostgresTaskRepository) ListSeries(ctx context.Context, parentID int64) ([]domain.Task, error) {
+    query := `
+        SELECT 
+            t.id, t.user_id, t.workspace_id, t.project_id, t.title, 
+            COALESCE(t.description, ''), 
+            t.status, 
+            COALESCE(t.priority, 'medium'), 
+            t.labels, t.reminder_time, t.due_at, t.due_all_day,
+            ` + taskAssigneesExpr + `,
+            COALESCE(t.recurrence_pattern, ''), 
+            t.recurrence_start,
+            t.next_run,
+            t.recurrence_mode,
+            t.missed_run_policy,
+            t.recurrence_parent_id,
+            t.occurrence_at,
+            t.status_changed_at, t.started_at, t.completed_at,
+            t.created_at, t.updated_at,
+            COALESCE(
+                (SELECT json_agg(json_build_object('id', s.id, 'task_id', s.task_id, 'title', s.title, 'is_done', s.is_done) ORDER BY s.id)
+                 FROM subtasks s WHERE s.task_id = t.id),
+                '[]'
+            )
+        FROM tasks t
+        WHERE t.recurrence_parent_id = $1
+        ORDER BY t.occurrence_at, t.id
+    `
+
+    rows, err := r.db.Query(ctx, query, parentID)
+    if err != nil {
+        return nil, err
+    }
+    defer rows.Close()
+
+    tasks := []domain.Task{}
+    for rows.Next() {
+        var t domain.Task
+        err := rows.Scan(
+            &t.ID, &t.UserID, &t.WorkspaceID, &t.ProjectID, &t.Title, &t.Description, &t.Status,
+            &t.Priority, &t.Labels, &t.ReminderTime, &t.DueAt, &t.DueAllDay, &t.AssigneeIDs,
+            &t.RecurrencePattern,
+            &t.RecurrenceStart,
+            &t.NextRun,
+            &t.RecurrenceMode,
+            &t.MissedRunPolicy,
+            &t.RecurrenceParentID,
+            &t.OccurrenceAt,
+            &t.StatusChangedAt, &t.StartedAt, &t.CompletedAt,
+            &t.CreatedAt, &t.UpdatedAt,
+            &t.Subtasks,
+        )
+        if err != nil {
+            return nil, err
+        }
+        tasks = append(tasks, t)
+    }
+    return tasks, rows.Err()
+}
+
+// Delete task; event tetap tersimpan di outbox walau task-nya sudah hilang
+func (r *PostgresTaskRepository) Delete(ctx context.Context, id int64, e domain.Event) error {
     query := `DELETE FROM tasks WHERE id = $1`
-    _, err := r.db.Exec(ctx, query, id)
-    return err
+    return withTaskEvent(ctx, r.db, id, e, func(tx pgx.Tx) error {
+        _, err := tx.Exec(ctx, query, id)
+        return err
+    })
 }
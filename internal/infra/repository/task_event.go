@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// taskEventRelayLock adalah kunci advisory yang memastikan hanya satu relay
+// mengosongkan outbox pada satu waktu, sehingga urutan event terjaga
+const taskEventRelayLock int64 = 0x7461736b_6576656e // "taskeven"
+
+// errNoTaskEvent dikembalikan fn di withTaskEvent saat tidak ada perubahan;
+// transaksi di-rollback tanpa menulis event dan tanpa error ke pemanggil
+var errNoTaskEvent = errors.New("no change, skip task event")
+
+// insertTaskEvent menulis event ke outbox memakai transaksi perubahan datanya
+func insertTaskEvent(ctx context.Context, tx pgx.Tx, e domain.Event) error {
+	e.ID = 0
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+		INSERT INTO task_events (event_type, task_id, payload, created_at)
+		VALUES ($1, $2, $3, $4)
+	`, e.Type, e.TaskID, payload, e.OccurredAt)
+	return err
+}
+
+// withTaskEvent menjalankan fn lalu menulis event untuk taskID dalam satu transaksi
+func withTaskEvent(ctx context.Context, db *pgxpool.Pool, taskID int64, e domain.Event, fn func(tx pgx.Tx) error) error {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(tx); err != nil {
+		if errors.Is(err, errNoTaskEvent) {
+			return nil
+		}
+		return err
+	}
+
+	e.TaskID = taskID
+	if err := insertTaskEvent(ctx, tx, e); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+type PostgresTaskEventRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewTaskEventRepository(db *pgxpool.Pool) domain.TaskEventRepository {
+	return &PostgresTaskEventRepository{db: db}
+}
+
+func (r *PostgresTaskEventRepository) Drain(ctx context.Context, limit, maxAttempts int, fn domain.EventHandler) (int, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	// Kunci dilepas otomatis saat transaksi selesai; relay lain melewati putaran ini
+	var locked bool
+	if err := tx.QueryRow(ctx, `SELECT pg_try_advisory_xact_lock($1)`, taskEventRelayLock).Scan(&locked); err != nil {
+		return 0, err
+	}
+	if !locked {
+		return 0, nil
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT id, payload FROM task_events
+		WHERE published_at IS NULL AND failed_at IS NULL
+		ORDER BY id
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return 0, err
+	}
+	var events []domain.Event
+	for rows.Next() {
+		var id int64
+		var payload []byte
+		if err := rows.Scan(&id, &payload); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		var e domain.Event
+		if err := json.Unmarshal(payload, &e); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		e.ID = id
+		events = append(events, e)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	published := 0
+	for _, e := range events {
+		if handlerErr := fn(ctx, e); handlerErr != nil {
+			var failed bool
+			err := tx.QueryRow(ctx, `
+				UPDATE task_events
+				SET attempts = attempts + 1, last_error = $1,
+				    failed_at = CASE WHEN attempts + 1 >= $3 THEN $4 END
+				WHERE id = $2
+				RETURNING failed_at IS NOT NULL
+			`, handlerErr.Error(), e.ID, maxAttempts, time.Now()).Scan(&failed)
+			if err != nil {
+				return published, err
+			}
+			if failed {
+				published++
+				continue // dead letter: event sesudahnya tetap dikirim
+			}
+			if err := tx.Commit(ctx); err != nil {
+				return published, err
+			}
+			return published, handlerErr
+		}
+		_, err := tx.Exec(ctx, `
+			UPDATE task_events SET published_at = $1, attempts = attempts + 1, last_error = NULL WHERE id = $2
+		`, time.Now(), e.ID)
+		if err != nil {
+			return published, err
+		}
+		published++
+	}
+
+	return published, tx.Commit(ctx)
+}
+
+func (r *PostgresTaskEventRepository) PurgePublished(ctx context.Context, before time.Time) (int64, error) {
+	cmdTag, err := r.db.Exec(ctx, `DELETE FROM task_events WHERE published_at < $1 OR failed_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return cmdTag.RowsAffected(), nil
+}
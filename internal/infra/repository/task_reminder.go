@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresTaskReminderRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewTaskReminderRepository(db *pgxpool.Pool) domain.TaskReminderRepository {
+	return &PostgresTaskReminderRepository{db: db}
+}
+
+const taskReminderColumns = `id, task_id, remind_at, offset_minutes, created_at, updated_at`
+
+func scanTaskReminder(row pgx.Row) (*domain.TaskReminder, error) {
+	var r domain.TaskReminder
+	if err := row.Scan(&r.ID, &r.TaskID, &r.RemindAt, &r.OffsetMinutes, &r.CreatedAt, &r.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+func (r *PostgresTaskReminderRepository) Create(ctx context.Context, reminder *domain.TaskReminder) error {
+	return r.db.QueryRow(ctx, `
+		INSERT INTO task_reminders (task_id, remind_at, offset_minutes, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`, reminder.TaskID, reminder.RemindAt, reminder.OffsetMinutes, reminder.CreatedAt, reminder.UpdatedAt).Scan(&reminder.ID)
+}
+
+func (r *PostgresTaskReminderRepository) GetByID(ctx context.Context, id int64) (*domain.TaskReminder, error) {
+	reminder, err := scanTaskReminder(r.db.QueryRow(ctx, `SELECT `+taskReminderColumns+` FROM task_reminders WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return reminder, nil
+}
+
+// ListByTask diurutkan dari yang paling awal berbunyi; reminder tanpa waktu di akhir
+func (r *PostgresTaskReminderRepository) ListByTask(ctx context.Context, taskID int64) ([]domain.TaskReminder, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+taskReminderColumns+`
+		FROM task_reminders
+		WHERE task_id = $1
+		ORDER BY remind_at NULLS LAST, id
+	`, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reminders := []domain.TaskReminder{}
+	for rows.Next() {
+		reminder, err := scanTaskReminder(rows)
+		if err != nil {
+			return nil, err
+		}
+		reminders = append(reminders, *reminder)
+	}
+	return reminders, rows.Err()
+}
+
+func (r *PostgresTaskReminderRepository) Update(ctx context.Context, reminder *domain.TaskReminder) error {
+	cmdTag, err := r.db.Exec(ctx, `
+		UPDATE task_reminders SET remind_at = $1, offset_minutes = $2, updated_at = $3 WHERE id = $4
+	`, reminder.RemindAt, reminder.OffsetMinutes, reminder.UpdatedAt, reminder.ID)
+	if err != nil {
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *PostgresTaskReminderRepository) Delete(ctx context.Context, id int64) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM task_reminders WHERE id = $1`, id)
+	return err
+}
+
+// recomputeRelative menghitung ulang reminder relatif task dari base di dalam transaksi
+// perubahan tenggatnya
+func recomputeRelative(ctx context.Context, tx pgx.Tx, taskID int64, base *time.Time, at time.Time) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE task_reminders
+		SET remind_at = $2::timestamptz - make_interval(mins => offset_minutes), updated_at = $3
+		WHERE task_id = $1 AND offset_minutes IS NOT NULL
+	`, taskID, base, at)
+	return err
+}
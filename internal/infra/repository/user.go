@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"simple-task-manager/internal/core/domain"
 
@@ -20,8 +21,8 @@ func NewUserRepository(db *pgxpool.Pool) domain.UserRepository {
 
 func (r *PostgresUserRepository) Create(ctx context.Context, user *domain.User) error {
 	query := `
-		INSERT INTO users (name, email, password, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO users (name, email, password, timezone, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
 		RETURNING id
 	`
 
@@ -29,6 +30,7 @@ func (r *PostgresUserRepository) Create(ctx context.Context, user *domain.User)
 		user.Name, 
 		user.Email, 
 		user.Password, 
+		user.Timezone,
 		user.CreatedAt, 
 		user.UpdatedAt,
 	).Scan(&user.ID)
@@ -41,7 +43,7 @@ func (r *PostgresUserRepository) Create(ctx context.Context, user *domain.User)
 }
 
 func (r *PostgresUserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
-	query := `SELECT id, name, email, password, created_at, updated_at FROM users WHERE email = $1`
+	query := `SELECT id, name, email, password, email_verified_at, timezone, COALESCE(totp_secret, ''), mfa_enabled_at, created_at, updated_at FROM users WHERE lower(email) = lower($1)`
 
 	var user domain.User
 	err := r.db.QueryRow(ctx, query, email).Scan(
@@ -49,6 +51,10 @@ func (r *PostgresUserRepository) GetByEmail(ctx context.Context, email string) (
 		&user.Name,
 		&user.Email,
 		&user.Password,
+		&user.EmailVerifiedAt,
+		&user.Timezone,
+		&user.TOTPSecret,
+		&user.MFAEnabledAt,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -64,7 +70,7 @@ func (r *PostgresUserRepository) GetByEmail(ctx context.Context, email string) (
 }
 
 func (r *PostgresUserRepository) GetByID(ctx context.Context, id int64) (*domain.User, error) {
-	query := `SELECT id, name, email, password, created_at, updated_at FROM users WHERE id = $1`
+	query := `SELECT id, name, email, password, email_verified_at, timezone, COALESCE(totp_secret, ''), mfa_enabled_at, created_at, updated_at FROM users WHERE id = $1`
 
 	var user domain.User
 	err := r.db.QueryRow(ctx, query, id).Scan(
@@ -72,6 +78,10 @@ func (r *PostgresUserRepository) GetByID(ctx context.Context, id int64) (*domain
 		&user.Name,
 		&user.Email,
 		&user.Password,
+		&user.EmailVerifiedAt,
+		&user.Timezone,
+		&user.TOTPSecret,
+		&user.MFAEnabledAt,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -84,4 +94,141 @@ func (r *PostgresUserRepository) GetByID(ctx context.Context, id int64) (*domain
 	}
 
 	return &user, nil
-}
\ No newline at end of file
+}
+
+func (r *PostgresUserRepository) MarkEmailVerified(ctx context.Context, id int64, at time.Time) error {
+	query := `UPDATE users SET email_verified_at = COALESCE(email_verified_at, $1), updated_at = $1 WHERE id = $2`
+
+	cmdTag, err := r.db.Exec(ctx, query, at, id)
+	if err != nil {
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *PostgresUserRepository) UpdatePassword(ctx context.Context, id int64, hashedPassword string, at time.Time) error {
+	query := `UPDATE users SET password = $1, updated_at = $2 WHERE id = $3`
+
+	cmdTag, err := r.db.Exec(ctx, query, hashedPassword, at, id)
+	if err != nil {
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *PostgresUserRepository) UpdateProfile(ctx context.Context, user *domain.User) error {
+	query := `UPDATE users SET name = $1, timezone = $2, updated_at = $3 WHERE id = $4`
+
+	cmdTag, err := r.db.Exec(ctx, query, user.Name, user.Timezone, user.UpdatedAt, user.ID)
+	if err != nil {
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *PostgresUserRepository) SetTOTPSecret(ctx context.Context, id int64, secret string, at time.Time) error {
+	// Secret hanya boleh diganti selama MFA belum aktif
+	query := `UPDATE users SET totp_secret = $1, totp_last_step = NULL, updated_at = $2 WHERE id = $3 AND mfa_enabled_at IS NULL`
+
+	cmdTag, err := r.db.Exec(ctx, query, secret, at, id)
+	if err != nil {
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrConflict
+	}
+	return nil
+}
+
+func (r *PostgresUserRepository) EnableMFA(ctx context.Context, id int64, at time.Time) error {
+	query := `UPDATE users SET mfa_enabled_at = $1, updated_at = $1 WHERE id = $2 AND totp_secret IS NOT NULL`
+
+	cmdTag, err := r.db.Exec(ctx, query, at, id)
+	if err != nil {
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *PostgresUserRepository) DisableMFA(ctx context.Context, id int64, at time.Time) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	cmdTag, err := tx.Exec(ctx, `
+		UPDATE users SET totp_secret = NULL, totp_last_step = NULL, mfa_enabled_at = NULL, updated_at = $1
+		WHERE id = $2`, at, id)
+	if err != nil {
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, id); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *PostgresUserRepository) ClaimTOTPStep(ctx context.Context, id int64, step int64) (bool, error) {
+	query := `
+		UPDATE users SET totp_last_step = $1
+		WHERE id = $2 AND (totp_last_step IS NULL OR totp_last_step < $1)
+	`
+
+	cmdTag, err := r.db.Exec(ctx, query, step, id)
+	if err != nil {
+		return false, err
+	}
+	return cmdTag.RowsAffected() == 1, nil
+}
+
+func (r *PostgresUserRepository) ReplaceRecoveryCodes(ctx context.Context, userID int64, hashes []string, at time.Time) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+
+	for _, hash := range hashes {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO mfa_recovery_codes (user_id, code_hash, created_at)
+			VALUES ($1, $2, $3)`, userID, hash, at)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *PostgresUserRepository) UseRecoveryCode(ctx context.Context, userID int64, hash string, at time.Time) (bool, error) {
+	query := `
+		UPDATE mfa_recovery_codes SET used_at = $1
+		WHERE user_id = $2 AND code_hash = $3 AND used_at IS NULL
+	`
+
+	cmdTag, err := r.db.Exec(ctx, query, at, userID, hash)
+	if err != nil {
+		return false, err
+	}
+	return cmdTag.RowsAffected() == 1, nil
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresUserTokenRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewUserTokenRepository(db *pgxpool.Pool) domain.UserTokenRepository {
+	return &PostgresUserTokenRepository{db: db}
+}
+
+func (r *PostgresUserTokenRepository) Create(ctx context.Context, t *domain.UserToken) error {
+	query := `
+		INSERT INTO user_tokens (user_id, purpose, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`
+	return r.db.QueryRow(ctx, query, t.UserID, t.Purpose, t.TokenHash, t.ExpiresAt, t.CreatedAt).Scan(&t.ID)
+}
+
+func (r *PostgresUserTokenRepository) GetByHash(ctx context.Context, purpose string, hash string) (*domain.UserToken, error) {
+	query := `
+		SELECT id, user_id, purpose, token_hash, expires_at, used_at, created_at
+		FROM user_tokens
+		WHERE purpose = $1 AND token_hash = $2
+	`
+
+	var t domain.UserToken
+	err := r.db.QueryRow(ctx, query, purpose, hash).Scan(
+		&t.ID, &t.UserID, &t.Purpose, &t.TokenHash, &t.ExpiresAt, &t.UsedAt, &t.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+func (r *PostgresUserTokenRepository) MarkUsed(ctx context.Context, id int64, at time.Time) (bool, error) {
+	cmdTag, err := r.db.Exec(ctx, `UPDATE user_tokens SET used_at = $1 WHERE id = $2 AND used_at IS NULL`, at, id)
+	if err != nil {
+		return false, err
+	}
+	return cmdTag.RowsAffected() == 1, nil
+}
+
+func (r *PostgresUserTokenRepository) InvalidateAll(ctx context.Context, userID int64, purpose string, at time.Time) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE user_tokens SET used_at = $1
+		WHERE user_id = $2 AND purpose = $3 AND used_at IS NULL
+	`, at, userID, purpose)
+	return err
+}
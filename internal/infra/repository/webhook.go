@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresWebhookRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewWebhookRepository(db *pgxpool.Pool) domain.WebhookRepository {
+	return &PostgresWebhookRepository{db: db}
+}
+
+const webhookColumns = `id, user_id, workspace_id, url, events, secret, active, created_at, updated_at`
+
+func scanWebhook(row pgx.Row) (*domain.Webhook, error) {
+	var w domain.Webhook
+	err := row.Scan(&w.ID, &w.UserID, &w.WorkspaceID, &w.URL, &w.Events, &w.Secret, &w.Active, &w.CreatedAt, &w.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+func (r *PostgresWebhookRepository) queryWebhooks(ctx context.Context, query string, args ...interface{}) ([]domain.Webhook, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hooks := []domain.Webhook{}
+	for rows.Next() {
+		w, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		hooks = append(hooks, *w)
+	}
+	return hooks, rows.Err()
+}
+
+func (r *PostgresWebhookRepository) Create(ctx context.Context, hook *domain.Webhook) error {
+	return r.db.QueryRow(ctx, `
+		INSERT INTO webhooks (user_id, workspace_id, url, events, secret, active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id
+	`, hook.UserID, hook.WorkspaceID, hook.URL, hook.Events, hook.Secret, hook.Active, hook.CreatedAt, hook.UpdatedAt).Scan(&hook.ID)
+}
+
+func (r *PostgresWebhookRepository) GetByID(ctx context.Context, id int64) (*domain.Webhook, error) {
+	w, err := scanWebhook(r.db.QueryRow(ctx, `SELECT `+webhookColumns+` FROM webhooks WHERE id = $1`, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return w, nil
+}
+
+func (r *PostgresWebhookRepository) List(ctx context.Context, userID int64, workspaceID *int64) ([]domain.Webhook, error) {
+	if workspaceID != nil {
+		return r.queryWebhooks(ctx, `SELECT `+webhookColumns+` FROM webhooks WHERE workspace_id = $1 ORDER BY id`, *workspaceID)
+	}
+	return r.queryWebhooks(ctx, `SELECT `+webhookColumns+` FROM webhooks WHERE user_id = $1 AND workspace_id IS NULL ORDER BY id`, userID)
+}
+
+func (r *PostgresWebhookRepository) Update(ctx context.Context, hook *domain.Webhook) error {
+	cmdTag, err := r.db.Exec(ctx, `
+		UPDATE webhooks SET url = $1, events = $2, active = $3, updated_at = $4 WHERE id = $5
+	`, hook.URL, hook.Events, hook.Active, hook.UpdatedAt, hook.ID)
+	if err != nil {
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// Delete webhook; log pengirimannya ikut terhapus lewat ON DELETE CASCADE
+func (r *PostgresWebhookRepository) Delete(ctx context.Context, id int64) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM webhooks WHERE id = $1`, id)
+	return err
+}
+
+func (r *PostgresWebhookRepository) ListSubscribed(ctx context.Context, eventType string, workspaceID *int64, userIDs []int64) ([]domain.Webhook, error) {
+	if workspaceID != nil {
+		return r.queryWebhooks(ctx, `
+			SELECT `+webhookColumns+` FROM webhooks
+			WHERE active AND $1 = ANY(events) AND workspace_id = $2
+		`, eventType, *workspaceID)
+	}
+	return r.queryWebhooks(ctx, `
+		SELECT `+webhookColumns+` FROM webhooks
+		WHERE active AND $1 = ANY(events) AND workspace_id IS NULL AND user_id = ANY($2)
+	`, eventType, userIDs)
+}
+
+// --- DELIVERIES ---
+
+const webhookDeliveryColumns = `id, webhook_id, event_type, event_id, payload, status, attempts, next_attempt_at,
+	response_status, last_error, delivered_at, created_at, updated_at`
+
+func scanWebhookDelivery(row pgx.Row) (*domain.WebhookDelivery, error) {
+	var d domain.WebhookDelivery
+	err := row.Scan(
+		&d.ID, &d.WebhookID, &d.EventType, &d.EventID, &d.Payload, &d.Status, &d.Attempts, &d.NextAttemptAt,
+		&d.ResponseStatus, &d.LastError, &d.DeliveredAt, &d.CreatedAt, &d.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (r *PostgresWebhookRepository) queryDeliveries(ctx context.Context, query string, args ...interface{}) ([]domain.WebhookDelivery, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []domain.WebhookDelivery{}
+	for rows.Next() {
+		d, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, *d)
+	}
+	return deliveries, rows.Err()
+}
+
+func (r *PostgresWebhookRepository) EnqueueDelivery(ctx context.Context, d *domain.WebhookDelivery) error {
+	err := r.db.QueryRow(ctx, `
+		INSERT INTO webhook_deliveries (webhook_id, event_type, event_id, payload, status, attempts, next_attempt_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $8)
+		ON CONFLICT (webhook_id, event_id) DO NOTHING
+		RETURNING id
+	`, d.WebhookID, d.EventType, d.EventID, d.Payload, d.Status, d.Attempts, d.NextAttemptAt, d.CreatedAt).Scan(&d.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrConflict
+	}
+	if isForeignKeyViolation(err) {
+		return domain.NotFound("webhook no longer exists")
+	}
+	return err
+}
+
+func (r *PostgresWebhookRepository) ClaimDeliveries(ctx context.Context, now time.Time, limit int) ([]domain.WebhookDelivery, error) {
+	return r.queryDeliveries(ctx, `
+		UPDATE webhook_deliveries d
+		SET status = 'sending', attempts = d.attempts + 1, updated_at = $1
+		WHERE d.id IN (
+			SELECT wd.id FROM webhook_deliveries wd
+			JOIN webhooks w ON w.id = wd.webhook_id
+			WHERE wd.status = 'pending' AND wd.next_attempt_at <= $1 AND w.active
+			ORDER BY wd.next_attempt_at, wd.id
+			LIMIT $2
+			FOR UPDATE OF wd SKIP LOCKED
+		)
+		RETURNING `+webhookDeliveryColumns, now, limit)
+}
+
+func (r *PostgresWebhookRepository) CompleteDelivery(ctx context.Context, d *domain.WebhookDelivery) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE webhook_deliveries
+		SET status = $2, next_attempt_at = $3, response_status = $4, last_error = $5, delivered_at = $6, updated_at = $7
+		WHERE id = $1
+	`, d.ID, d.Status, d.NextAttemptAt, d.ResponseStatus, d.LastError, d.DeliveredAt, d.UpdatedAt)
+	return err
+}
+
+// RequeueStale: webhook dikirim at-least-once, jadi baris yang terputus di tengah
+// pengiriman dicoba lagi; penerima bisa membuang duplikat lewat X-Webhook-Delivery
+func (r *PostgresWebhookRepository) RequeueStale(ctx context.Context, before time.Time) (int64, error) {
+	cmdTag, err := r.db.Exec(ctx, `
+		UPDATE webhook_deliveries SET status = 'pending', next_attempt_at = now(), updated_at = now()
+		WHERE status = 'sending' AND updated_at < $1
+	`, before)
+	if err != nil {
+		return 0, err
+	}
+	return cmdTag.RowsAffected(), nil
+}
+
+func (r *PostgresWebhookRepository) ListDeliveries(ctx context.Context, webhookID int64, limit int) ([]domain.WebhookDelivery, error) {
+	return r.queryDeliveries(ctx, `
+		SELECT `+webhookDeliveryColumns+`
+		FROM webhook_deliveries
+		WHERE webhook_id = $1
+		ORDER BY id DESC
+		LIMIT $2
+	`, webhookID, limit)
+}
@@ -0,0 +1,303 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PostgresWorkspaceRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewWorkspaceRepository(db *pgxpool.Pool) domain.WorkspaceRepository {
+	return &PostgresWorkspaceRepository{db: db}
+}
+
+// Create menyimpan workspace dan keanggotaan owner dalam satu transaksi
+func (r *PostgresWorkspaceRepository) Create(ctx context.Context, ws *domain.Workspace) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO workspaces (name, owner_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`, ws.Name, ws.OwnerID, ws.CreatedAt, ws.UpdatedAt).Scan(&ws.ID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO workspace_members (workspace_id, user_id, role, joined_at)
+		VALUES ($1, $2, $3, $4)
+	`, ws.ID, ws.OwnerID, domain.RoleOwner, ws.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *PostgresWorkspaceRepository) GetByID(ctx context.Context, id int64) (*domain.Workspace, error) {
+	var ws domain.Workspace
+	err := r.db.QueryRow(ctx, `
+		SELECT id, name, owner_id, created_at, updated_at FROM workspaces WHERE id = $1
+	`, id).Scan(&ws.ID, &ws.Name, &ws.OwnerID, &ws.CreatedAt, &ws.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &ws, nil
+}
+
+func (r *PostgresWorkspaceRepository) ListByMember(ctx context.Context, userID int64) ([]domain.Workspace, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT w.id, w.name, w.owner_id, m.role, w.created_at, w.updated_at
+		FROM workspaces w
+		JOIN workspace_members m ON m.workspace_id = w.id
+		WHERE m.user_id = $1
+		ORDER BY w.name, w.id
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	workspaces := []domain.Workspace{}
+	for rows.Next() {
+		var ws domain.Workspace
+		if err := rows.Scan(&ws.ID, &ws.Name, &ws.OwnerID, &ws.Role, &ws.CreatedAt, &ws.UpdatedAt); err != nil {
+			return nil, err
+		}
+		workspaces = append(workspaces, ws)
+	}
+	return workspaces, rows.Err()
+}
+
+func (r *PostgresWorkspaceRepository) Update(ctx context.Context, ws *domain.Workspace) error {
+	cmdTag, err := r.db.Exec(ctx, `UPDATE workspaces SET name = $1, updated_at = $2 WHERE id = $3`, ws.Name, ws.UpdatedAt, ws.ID)
+	if err != nil {
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// Delete workspace; anggota, undangan, task dan project ikut terhapus lewat ON DELETE CASCADE
+func (r *PostgresWorkspaceRepository) Delete(ctx context.Context, id int64) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM workspaces WHERE id = $1`, id)
+	return err
+}
+
+// --- MEMBERS ---
+
+const workspaceMemberQuery = `
+	SELECT m.workspace_id, m.user_id, u.name, u.email, m.role, m.joined_at
+	FROM workspace_members m
+	JOIN users u ON u.id = m.user_id
+`
+
+func scanWorkspaceMember(row pgx.Row) (*domain.WorkspaceMember, error) {
+	var m domain.WorkspaceMember
+	if err := row.Scan(&m.WorkspaceID, &m.UserID, &m.Name, &m.Email, &m.Role, &m.JoinedAt); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+func (r *PostgresWorkspaceRepository) GetMember(ctx context.Context, workspaceID int64, userID int64) (*domain.WorkspaceMember, error) {
+	m, err := scanWorkspaceMember(r.db.QueryRow(ctx,
+		workspaceMemberQuery+` WHERE m.workspace_id = $1 AND m.user_id = $2`, workspaceID, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return m, nil
+}
+
+// ListMembers diurutkan dari role tertinggi, lalu nama
+func (r *PostgresWorkspaceRepository) ListMembers(ctx context.Context, workspaceID int64) ([]domain.WorkspaceMember, error) {
+	rows, err := r.db.Query(ctx, workspaceMemberQuery+`
+		WHERE m.workspace_id = $1
+		ORDER BY CASE m.role WHEN 'owner' THEN 0 WHEN 'admin' THEN 1 WHEN 'member' THEN 2 ELSE 3 END, u.name, u.id
+	`, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := []domain.WorkspaceMember{}
+	for rows.Next() {
+		m, err := scanWorkspaceMember(rows)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, *m)
+	}
+	return members, rows.Err()
+}
+
+func (r *PostgresWorkspaceRepository) UpdateMemberRole(ctx context.Context, workspaceID int64, userID int64, role string) error {
+	cmdTag, err := r.db.Exec(ctx, `
+		UPDATE workspace_members SET role = $1 WHERE workspace_id = $2 AND user_id = $3
+	`, role, workspaceID, userID)
+	if err != nil {
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// RemoveMember sekaligus melepas assignment user dari task di workspace tersebut,
+// karena ia tidak lagi bisa melihat task-nya
+func (r *PostgresWorkspaceRepository) RemoveMember(ctx context.Context, workspaceID int64, userID int64) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		DELETE FROM task_assignees
+		WHERE user_id = $2 AND task_id IN (SELECT id FROM tasks WHERE workspace_id = $1)
+	`, workspaceID, userID)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM workspace_members WHERE workspace_id = $1 AND user_id = $2`, workspaceID, userID); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// --- INVITATIONS ---
+
+const invitationColumns = `id, workspace_id, email, role, token_hash, invited_by, expires_at, accepted_at, revoked_at, created_at`
+
+func scanInvitation(row pgx.Row) (*domain.WorkspaceInvitation, error) {
+	var inv domain.WorkspaceInvitation
+	err := row.Scan(
+		&inv.ID, &inv.WorkspaceID, &inv.Email, &inv.Role, &inv.TokenHash, &inv.InvitedBy,
+		&inv.ExpiresAt, &inv.AcceptedAt, &inv.RevokedAt, &inv.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &inv, nil
+}
+
+func (r *PostgresWorkspaceRepository) CreateInvitation(ctx context.Context, inv *domain.WorkspaceInvitation) error {
+	return r.db.QueryRow(ctx, `
+		INSERT INTO workspace_invitations (workspace_id, email, role, token_hash, invited_by, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`, inv.WorkspaceID, inv.Email, inv.Role, inv.TokenHash, inv.InvitedBy, inv.ExpiresAt, inv.CreatedAt).Scan(&inv.ID)
+}
+
+func (r *PostgresWorkspaceRepository) getInvitation(ctx context.Context, where string, arg interface{}) (*domain.WorkspaceInvitation, error) {
+	inv, err := scanInvitation(r.db.QueryRow(ctx, `SELECT `+invitationColumns+` FROM workspace_invitations WHERE `+where, arg))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return inv, nil
+}
+
+func (r *PostgresWorkspaceRepository) GetInvitation(ctx context.Context, id int64) (*domain.WorkspaceInvitation, error) {
+	return r.getInvitation(ctx, "id = $1", id)
+}
+
+func (r *PostgresWorkspaceRepository) GetInvitationByHash(ctx context.Context, hash string) (*domain.WorkspaceInvitation, error) {
+	return r.getInvitation(ctx, "token_hash = $1", hash)
+}
+
+func (r *PostgresWorkspaceRepository) ListInvitations(ctx context.Context, workspaceID int64) ([]domain.WorkspaceInvitation, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+invitationColumns+`
+		FROM workspace_invitations
+		WHERE workspace_id = $1 AND accepted_at IS NULL AND revoked_at IS NULL
+		ORDER BY created_at DESC, id DESC
+	`, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	invitations := []domain.WorkspaceInvitation{}
+	for rows.Next() {
+		inv, err := scanInvitation(rows)
+		if err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, *inv)
+	}
+	return invitations, rows.Err()
+}
+
+func (r *PostgresWorkspaceRepository) RevokeInvitation(ctx context.Context, id int64, at time.Time) error {
+	cmdTag, err := r.db.Exec(ctx, `
+		UPDATE workspace_invitations SET revoked_at = $1
+		WHERE id = $2 AND accepted_at IS NULL AND revoked_at IS NULL
+	`, at, id)
+	if err != nil {
+		return err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// AcceptInvitation mengklaim undangan secara atomik supaya satu link tidak bisa dipakai dua kali.
+// Mengembalikan ErrConflict jika user ternyata sudah menjadi anggota.
+func (r *PostgresWorkspaceRepository) AcceptInvitation(ctx context.Context, invitationID int64, member *domain.WorkspaceMember, at time.Time) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	cmdTag, err := tx.Exec(ctx, `
+		UPDATE workspace_invitations SET accepted_at = $1
+		WHERE id = $2 AND accepted_at IS NULL AND revoked_at IS NULL AND expires_at > $1
+	`, at, invitationID)
+	if err != nil {
+		return false, err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	cmdTag, err = tx.Exec(ctx, `
+		INSERT INTO workspace_members (workspace_id, user_id, role, joined_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT DO NOTHING
+	`, member.WorkspaceID, member.UserID, member.Role, member.JoinedAt)
+	if err != nil {
+		return false, err
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return false, domain.ErrConflict
+	}
+
+	return true, tx.Commit(ctx)
+}
@@ -0,0 +1,108 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+)
+
+// OutboxConfig mengatur relay outbox task_events
+type OutboxConfig struct {
+	BatchSize     int
+	MaxBatches    int
+	Retention     time.Duration // event terkirim/gagal disimpan selama ini sebelum dihapus
+	PurgeInterval time.Duration
+	MaxAttempts   int           // setelah sekian kali gagal, event ditandai gagal dan dilewati
+	BaseBackoff   time.Duration // jeda sebelum mencoba lagi setelah gagal; berlipat dua setiap kegagalan
+	MaxBackoff    time.Duration
+}
+
+// OutboxRelay meneruskan event dari outbox task_events ke subscriber event bus,
+// urut ID. Event ditandai terkirim hanya setelah Publish berhasil, sehingga crash
+// di tengah jalan membuat event dikirim ulang (at-least-once); subscriber bisa
+// memakai Event.ID untuk mengenali duplikat. Event yang terus gagal menahan event
+// sesudahnya hanya selama jatah percobaannya, dengan jeda yang makin panjang.
+type OutboxRelay struct {
+	repo      domain.TaskEventRepository
+	events    domain.EventPublisher
+	cfg       OutboxConfig
+	now       func() time.Time
+	lastPurge time.Time
+	failures  int       // kegagalan berturut-turut
+	retryAt   time.Time // Relay dilewati sampai waktu ini setelah gagal
+}
+
+func NewOutboxRelay(repo domain.TaskEventRepository, events domain.EventPublisher, cfg OutboxConfig) *OutboxRelay {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+	if cfg.MaxBatches <= 0 {
+		cfg.MaxBatches = 10
+	}
+	if cfg.Retention <= 0 {
+		cfg.Retention = 7 * 24 * time.Hour
+	}
+	if cfg.PurgeInterval <= 0 {
+		cfg.PurgeInterval = time.Hour
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 8
+	}
+	if cfg.BaseBackoff <= 0 {
+		cfg.BaseBackoff = time.Second
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = time.Minute
+	}
+	return &OutboxRelay{repo: repo, events: events, cfg: cfg, now: time.Now}
+}
+
+// RelayEvents akan dipanggil setiap kali Cron berjalan
+func (r *OutboxRelay) RelayEvents() {
+	if err := r.Relay(context.Background()); err != nil {
+		log.Printf("[CRON ERROR] Relaying task events: %v", err)
+	}
+}
+
+// Relay mengosongkan outbox sampai habis atau MaxBatches tercapai. Setelah gagal, putaran
+// berikutnya menunggu backoff supaya event yang gagal tidak dihabiskan jatahnya dalam hitungan detik.
+func (r *OutboxRelay) Relay(ctx context.Context) error {
+	if r.now().Before(r.retryAt) {
+		return nil
+	}
+	for i := 0; i < r.cfg.MaxBatches; i++ {
+		n, err := r.repo.Drain(ctx, r.cfg.BatchSize, r.cfg.MaxAttempts, r.events.Publish)
+		if err != nil {
+			r.failures++
+			r.retryAt = r.now().Add(r.backoff(r.failures))
+			return err
+		}
+		r.failures = 0
+		if n < r.cfg.BatchSize {
+			break
+		}
+	}
+
+	if now := r.now(); now.Sub(r.lastPurge) >= r.cfg.PurgeInterval {
+		purged, err := r.repo.PurgePublished(ctx, now.Add(-r.cfg.Retention))
+		if err != nil {
+			return err
+		}
+		if purged > 0 {
+			log.Printf("[OUTBOX] Purged %d published task event(s)", purged)
+		}
+		r.lastPurge = now
+	}
+	return nil
+}
+
+// backoff: BaseBackoff * 2^(failures-1), dibatasi MaxBackoff
+func (r *OutboxRelay) backoff(failures int) time.Duration {
+	wait := r.cfg.BaseBackoff
+	for i := 1; i < failures && wait < r.cfg.MaxBackoff; i++ {
+		wait *= 2
+	}
+	return min(wait, r.cfg.MaxBackoff)
+}
@@ -0,0 +1,212 @@
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+	"simple-task-manager/internal/core/usecase"
+	"simple-task-manager/internal/core/usecase/mocks"
+	"simple-task-manager/internal/infra/event"
+	"simple-task-manager/internal/infra/scheduler"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// drainEvents meniru Drain: meneruskan events ke fn berurutan dan berhenti di error pertama
+func drainEvents(events ...domain.Event) func(mock.Arguments) {
+	return func(args mock.Arguments) {
+		fn := args.Get(3).(domain.EventHandler)
+		for _, e := range events {
+			if fn(args.Get(0).(context.Context), e) != nil {
+				return
+			}
+		}
+	}
+}
+
+func TestOutboxRelay(t *testing.T) {
+	cfg := scheduler.OutboxConfig{BatchSize: 2, MaxBatches: 3}
+
+	t.Run("Drains Until Batch Is Short", func(t *testing.T) {
+		repo, publisher := new(mocks.TaskEventRepository), new(mocks.EventPublisher)
+		r := scheduler.NewOutboxRelay(repo, publisher, cfg)
+
+		var seen []int64
+		publisher.On("Publish", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			seen = append(seen, args.Get(1).(domain.Event).ID)
+		}).Return(nil)
+		repo.On("Drain", mock.Anything, 2, 8, mock.Anything).Run(drainEvents(domain.Event{ID: 1}, domain.Event{ID: 2})).Return(2, nil).Once()
+		repo.On("Drain", mock.Anything, 2, 8, mock.Anything).Run(drainEvents(domain.Event{ID: 3})).Return(1, nil).Once()
+		repo.On("PurgePublished", mock.Anything, mock.Anything).Return(int64(0), nil).Once()
+
+		assert.NoError(t, r.Relay(context.Background()))
+		assert.Equal(t, []int64{1, 2, 3}, seen)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Stops On Publish Error And Purges Only Hourly", func(t *testing.T) {
+		repo, publisher := new(mocks.TaskEventRepository), new(mocks.EventPublisher)
+		r := scheduler.NewOutboxRelay(repo, publisher, scheduler.OutboxConfig{BatchSize: 2, MaxBatches: 3, BaseBackoff: time.Nanosecond})
+
+		repo.On("Drain", mock.Anything, 2, 8, mock.Anything).Return(0, errors.New("bus down")).Once()
+
+		assert.EqualError(t, r.Relay(context.Background()), "bus down")
+		repo.AssertNotCalled(t, "PurgePublished", mock.Anything, mock.Anything)
+
+		repo.On("Drain", mock.Anything, 2, 8, mock.Anything).Return(0, nil).Twice()
+		repo.On("PurgePublished", mock.Anything, mock.Anything).Return(int64(4), nil).Once()
+
+		assert.NoError(t, r.Relay(context.Background()))
+		assert.NoError(t, r.Relay(context.Background()))
+		repo.AssertNumberOfCalls(t, "PurgePublished", 1)
+	})
+
+	t.Run("Backs Off After Failure", func(t *testing.T) {
+		repo, publisher := new(mocks.TaskEventRepository), new(mocks.EventPublisher)
+		r := scheduler.NewOutboxRelay(repo, publisher, scheduler.OutboxConfig{BaseBackoff: time.Hour})
+
+		repo.On("Drain", mock.Anything, 100, 8, mock.Anything).Return(0, errors.New("bus down")).Once()
+
+		assert.Error(t, r.Relay(context.Background()))
+		assert.NoError(t, r.Relay(context.Background())) // masih dalam jeda: outbox tidak disentuh
+		repo.AssertNumberOfCalls(t, "Drain", 1)
+	})
+}
+
+// memOutbox meniru semantik Drain Postgres: event ditandai terkirim hanya jika handler berhasil,
+// dan ditandai gagal (dilewati) setelah maxAttempts percobaan yang gagal
+type memOutbox struct {
+	events    []domain.Event
+	published map[int64]bool
+	attempts  map[int64]int
+	failed    map[int64]bool
+}
+
+func (o *memOutbox) Drain(ctx context.Context, limit, maxAttempts int, fn domain.EventHandler) (int, error) {
+	n := 0
+	for _, e := range o.events {
+		if o.published[e.ID] || o.failed[e.ID] || n == limit {
+			continue
+		}
+		o.attempts[e.ID]++
+		if err := fn(ctx, e); err != nil {
+			if o.attempts[e.ID] >= maxAttempts {
+				o.failed[e.ID] = true
+				n++
+				continue
+			}
+			return n, err
+		}
+		o.published[e.ID] = true
+		n++
+	}
+	return n, nil
+}
+
+func newMemOutbox(events ...domain.Event) *memOutbox {
+	return &memOutbox{events: events, published: map[int64]bool{}, attempts: map[int64]int{}, failed: map[int64]bool{}}
+}
+
+func (o *memOutbox) PurgePublished(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+// uniqueNotifications meniru unique (user_id, event_id) dan FK task_id pada tabel notifications
+type uniqueNotifications struct {
+	mocks.NotificationRepository
+	stored   map[string]bool
+	failNext map[int64]bool
+	deleted  map[int64]bool // task yang sudah dihapus
+}
+
+func (r *uniqueNotifications) Create(ctx context.Context, n *domain.Notification) error {
+	if n.TaskID != nil && r.deleted[*n.TaskID] {
+		return domain.NotFound("task no longer exists")
+	}
+	if r.failNext[*n.EventID] {
+		delete(r.failNext, *n.EventID)
+		return errors.New("connection reset")
+	}
+	key := fmt.Sprintf("%d/%d", n.UserID, *n.EventID)
+	if r.stored[key] {
+		return domain.ErrConflict
+	}
+	r.stored[key] = true
+	return nil
+}
+
+func TestOutboxRelayWithBus(t *testing.T) {
+	outbox := newMemOutbox(
+		domain.Event{ID: 1, Type: domain.EventTaskAssigned, TaskID: 10, ActorID: 1, Recipients: []int64{2}, Data: map[string]any{"title": "A"}},
+		domain.Event{ID: 2, Type: domain.EventTaskAssigned, TaskID: 11, ActorID: 1, Recipients: []int64{2}, Data: map[string]any{"title": "B"}},
+	)
+
+	// insert pertama untuk event 2 gagal
+	notificationRepo := &uniqueNotifications{stored: map[string]bool{}, failNext: map[int64]bool{2: true}}
+
+	bus := event.NewBus()
+	notifications := usecase.NewNotificationUsecase(notificationRepo, bus, 2*time.Second)
+	bus.SubscribeDurable(domain.EventTaskAssigned, notifications.HandleEvent)
+	streamed := 0
+	bus.SubscribeAll(func(ctx context.Context, e domain.Event) error {
+		streamed++
+		return errors.New("stream client gone") // subscriber best-effort tidak menahan outbox
+	})
+
+	relay := scheduler.NewOutboxRelay(outbox, bus, scheduler.OutboxConfig{BaseBackoff: time.Nanosecond})
+
+	// Putaran 1: event 2 gagal disimpan sehingga tetap di outbox
+	assert.ErrorContains(t, relay.Relay(context.Background()), "connection reset")
+	assert.True(t, outbox.published[1])
+	assert.False(t, outbox.published[2])
+
+	// Putaran 2: event 2 dikirim ulang dan berhasil
+	assert.NoError(t, relay.Relay(context.Background()))
+	assert.True(t, outbox.published[2])
+
+	// Relay crash setelah handler berjalan: event dikirim lagi tanpa notifikasi ganda
+	outbox.published[2] = false
+	assert.NoError(t, relay.Relay(context.Background()))
+	assert.Equal(t, map[string]bool{"2/1": true, "2/2": true}, notificationRepo.stored)
+	assert.Positive(t, streamed)
+}
+
+func TestOutboxRelaySkipsPoisonEvent(t *testing.T) {
+	outbox := newMemOutbox(
+		domain.Event{ID: 1, Type: domain.EventTaskAssigned, TaskID: 10, ActorID: 1, Recipients: []int64{2}, Data: map[string]any{"title": "A"}},
+		domain.Event{ID: 2, Type: domain.EventTaskAssigned, TaskID: 11, ActorID: 1, Recipients: []int64{2}, Data: map[string]any{"title": "B"}},
+		domain.Event{ID: 3, Type: domain.EventTaskAssigned, TaskID: 12, ActorID: 1, Recipients: []int64{2}, Data: map[string]any{"title": "C"}},
+	)
+	// task 10 dihapus sebelum relay berjalan; event 2 selalu gagal di subscriber lain
+	notificationRepo := &uniqueNotifications{stored: map[string]bool{}, deleted: map[int64]bool{10: true}}
+
+	bus := event.NewBus()
+	notifications := usecase.NewNotificationUsecase(notificationRepo, bus, 2*time.Second)
+	bus.SubscribeDurable(domain.EventTaskAssigned, notifications.HandleEvent)
+	bus.SubscribeDurable(domain.EventTaskAssigned, func(ctx context.Context, e domain.Event) error {
+		if e.ID == 2 {
+			return errors.New("always fails")
+		}
+		return nil
+	})
+
+	relay := scheduler.NewOutboxRelay(outbox, bus, scheduler.OutboxConfig{MaxAttempts: 3, BaseBackoff: time.Nanosecond})
+
+	// Task yang sudah dihapus bukan error: event 1 langsung terkirim
+	assert.Error(t, relay.Relay(context.Background()))
+	assert.True(t, outbox.published[1])
+	assert.False(t, outbox.published[3], "event 3 waits while event 2 still has attempts left")
+
+	assert.Error(t, relay.Relay(context.Background()))
+	// Percobaan ketiga: event 2 masuk dead letter dan event 3 tidak lagi tertahan
+	assert.NoError(t, relay.Relay(context.Background()))
+	assert.True(t, outbox.failed[2])
+	assert.True(t, outbox.published[3])
+	assert.Equal(t, 3, outbox.attempts[2])
+	assert.Equal(t, map[string]bool{"2/2": true, "2/3": true}, notificationRepo.stored)
+}
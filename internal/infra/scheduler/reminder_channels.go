@@ -0,0 +1,110 @@
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+)
+
+// EmailChannel mengirim reminder ke email penerima
+type EmailChannel struct {
+	mailer domain.Mailer
+	appURL string
+}
+
+func NewEmailChannel(mailer domain.Mailer, appURL string) *EmailChannel {
+	return &EmailChannel{mailer: mailer, appURL: strings.TrimRight(appURL, "/")}
+}
+
+func (c *EmailChannel) Name() string { return domain.ChannelEmail }
+
+func (c *EmailChannel) Send(ctx context.Context, d domain.ReminderDelivery) error {
+	body := fmt.Sprintf("Hi %s,\n\nThis is a reminder for your task \"%s\".\n", d.UserName, d.TaskTitle)
+	if d.DueAt != nil {
+		body += fmt.Sprintf("It is due %s.\n", d.DueAt.UTC().Format("Mon, 02 Jan 2006 15:04 MST"))
+	}
+	body += fmt.Sprintf("\nOpen it here: %s/tasks/%d\n", c.appURL, d.TaskID)
+
+	return c.mailer.Send(ctx, domain.Mail{
+		To:      d.UserEmail,
+		Subject: "Reminder: " + d.TaskTitle,
+		Body:    body,
+	})
+}
+
+// WebhookChannel mengirim reminder sebagai JSON POST ke satu URL.
+// Header Idempotency-Key memungkinkan penerima membuang duplikat.
+type WebhookChannel struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookChannel(url string, timeout time.Duration) *WebhookChannel {
+	return &WebhookChannel{url: url, client: &http.Client{Timeout: timeout}}
+}
+
+func (c *WebhookChannel) Name() string { return domain.ChannelWebhook }
+
+func (c *WebhookChannel) Send(ctx context.Context, d domain.ReminderDelivery) error {
+	payload, err := json.Marshal(map[string]any{
+		"type":        domain.EventReminderDue,
+		"delivery_id": d.ID,
+		"task_id":     d.TaskID,
+		"user_id":     d.UserID,
+		"title":       d.TaskTitle,
+		"remind_at":   d.RemindAt,
+		"due_at":      d.DueAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", fmt.Sprintf("reminder-%d", d.ID))
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// InAppChannel meneruskan reminder ke event bus sebagai EventReminderDue
+type InAppChannel struct {
+	events domain.EventPublisher
+}
+
+func NewInAppChannel(events domain.EventPublisher) *InAppChannel {
+	return &InAppChannel{events: events}
+}
+
+func (c *InAppChannel) Name() string { return domain.ChannelInApp }
+
+func (c *InAppChannel) Send(ctx context.Context, d domain.ReminderDelivery) error {
+	return c.events.Publish(ctx, domain.Event{
+		Type:       domain.EventReminderDue,
+		TaskID:     d.TaskID,
+		Recipients: []int64{d.UserID},
+		Data: map[string]any{
+			"title":     d.TaskTitle,
+			"remind_at": d.RemindAt,
+			"due_at":    d.DueAt,
+		},
+		OccurredAt: time.Now(),
+	})
+}
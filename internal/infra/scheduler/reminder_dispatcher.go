@@ -0,0 +1,142 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+)
+
+// ReminderConfig mengatur ritme dan batas pengiriman reminder
+type ReminderConfig struct {
+	BatchSize   int           // baris yang diklaim per putaran
+	MaxBatches  int           // batas putaran per tick, supaya satu tick tidak berjalan tanpa akhir
+	Lookback    time.Duration // reminder yang lebih tua dari ini diabaikan (mis. setelah downtime panjang)
+	Lease       time.Duration // batas waktu satu pengiriman; lewat dari ini dianggap terputus
+	MaxAttempts int           // percobaan maksimal sebelum ditandai failed
+}
+
+// ReminderDispatcher mengirim Task.ReminderTime yang jatuh tempo ke semua channel terdaftar.
+//
+// Setiap (reminder, penerima, channel) punya satu baris di reminder_deliveries yang diklaim
+// dengan FOR UPDATE SKIP LOCKED, sehingga restart atau replica kedua tidak mengirim ulang.
+// Pengiriman yang terputus di tengah jalan ditandai failed, bukan dikirim ulang, karena
+// tidak bisa dipastikan apakah pesan sudah sampai.
+type ReminderDispatcher struct {
+	repo     domain.ReminderRepository
+	channels map[string]domain.ReminderChannel
+	names    []string
+	cfg      ReminderConfig
+	now      func() time.Time
+}
+
+func NewReminderDispatcher(repo domain.ReminderRepository, cfg ReminderConfig, channels ...domain.ReminderChannel) *ReminderDispatcher {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+	if cfg.MaxBatches <= 0 {
+		cfg.MaxBatches = 10
+	}
+	if cfg.Lookback <= 0 {
+		cfg.Lookback = 24 * time.Hour
+	}
+	if cfg.Lease <= 0 {
+		cfg.Lease = 5 * time.Minute
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 5
+	}
+
+	d := &ReminderDispatcher{
+		repo:     repo,
+		channels: make(map[string]domain.ReminderChannel, len(channels)),
+		cfg:      cfg,
+		now:      time.Now,
+	}
+	for _, ch := range channels {
+		d.channels[ch.Name()] = ch
+		d.names = append(d.names, ch.Name())
+	}
+	return d
+}
+
+// DispatchDueReminders akan dipanggil setiap kali Cron berjalan
+func (d *ReminderDispatcher) DispatchDueReminders() {
+	if err := d.Dispatch(context.Background()); err != nil {
+		log.Printf("[CRON ERROR] Dispatching reminders: %v", err)
+	}
+}
+
+// Dispatch menjalankan satu putaran: bereskan klaim basi, jadwalkan reminder baru, lalu kirim
+func (d *ReminderDispatcher) Dispatch(ctx context.Context) error {
+	if len(d.names) == 0 {
+		return nil
+	}
+
+	now := d.now()
+	if n, err := d.repo.AbandonStale(ctx, now.Add(-d.cfg.Lease)); err != nil {
+		return err
+	} else if n > 0 {
+		log.Printf("[REMINDER] %d delivery(s) interrupted while sending, marked failed", n)
+	}
+
+	if _, err := d.repo.ScheduleDue(ctx, now, d.cfg.Lookback, d.names); err != nil {
+		return err
+	}
+
+	for i := 0; i < d.cfg.MaxBatches; i++ {
+		batch, err := d.repo.ClaimDue(ctx, d.now(), d.cfg.BatchSize)
+		if err != nil {
+			return err
+		}
+		for _, delivery := range batch {
+			d.deliver(ctx, delivery)
+		}
+		if len(batch) < d.cfg.BatchSize {
+			return nil
+		}
+	}
+	return nil
+}
+
+// deliver mengirim satu baris yang sudah diklaim dan mencatat hasilnya
+func (d *ReminderDispatcher) deliver(ctx context.Context, delivery domain.ReminderDelivery) {
+	ch, ok := d.channels[delivery.Channel]
+	if !ok {
+		// channel dimatikan setelah baris dijadwalkan
+		d.markFailed(ctx, delivery, "channel is not configured", nil)
+		return
+	}
+
+	// batasi di bawah lease supaya baris tidak dianggap terputus saat masih dikirim
+	sendCtx, cancel := context.WithTimeout(ctx, d.cfg.Lease/2)
+	err := ch.Send(sendCtx, delivery)
+	cancel()
+
+	if err != nil {
+		var retryAt *time.Time
+		if delivery.Attempts < d.cfg.MaxAttempts {
+			at := d.now().Add(reminderBackoff(delivery.Attempts))
+			retryAt = &at
+		}
+		d.markFailed(ctx, delivery, err.Error(), retryAt)
+		return
+	}
+
+	if err := d.repo.MarkSent(ctx, delivery.ID, d.now()); err != nil {
+		log.Printf("[REMINDER ERROR] Marking delivery %d sent: %v", delivery.ID, err)
+	}
+}
+
+func (d *ReminderDispatcher) markFailed(ctx context.Context, delivery domain.ReminderDelivery, reason string, retryAt *time.Time) {
+	log.Printf("[REMINDER] Delivery %d via %s failed (attempt %d): %s", delivery.ID, delivery.Channel, delivery.Attempts, reason)
+	if err := d.repo.MarkFailed(ctx, delivery.ID, reason, retryAt, d.now()); err != nil {
+		log.Printf("[REMINDER ERROR] Marking delivery %d failed: %v", delivery.ID, err)
+	}
+}
+
+// reminderBackoff: 1m, 4m, 9m, ... setelah percobaan ke-n
+func reminderBackoff(attempts int) time.Duration {
+	return time.Duration(attempts*attempts) * time.Minute
+}
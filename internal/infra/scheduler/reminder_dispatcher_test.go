@@ -0,0 +1,93 @@
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+	"simple-task-manager/internal/core/usecase/mocks"
+	"simple-task-manager/internal/infra/scheduler"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type fakeChannel struct {
+	name string
+	err  error
+	sent []int64
+}
+
+func (c *fakeChannel) Name() string { return c.name }
+
+func (c *fakeChannel) Send(ctx context.Context, d domain.ReminderDelivery) error {
+	c.sent = append(c.sent, d.ID)
+	return c.err
+}
+
+func TestDispatchReminders(t *testing.T) {
+	cfg := scheduler.ReminderConfig{BatchSize: 10, MaxAttempts: 3}
+
+	expectTick := func(repo *mocks.ReminderRepository, batch []domain.ReminderDelivery) {
+		repo.On("AbandonStale", mock.Anything, mock.Anything).Return(int64(0), nil).Once()
+		repo.On("ScheduleDue", mock.Anything, mock.Anything, 24*time.Hour, []string{domain.ChannelEmail}).Return(int64(len(batch)), nil).Once()
+		repo.On("ClaimDue", mock.Anything, mock.Anything, 10).Return(batch, nil).Once()
+	}
+
+	t.Run("Success - Marks Sent", func(t *testing.T) {
+		repo := new(mocks.ReminderRepository)
+		email := &fakeChannel{name: domain.ChannelEmail}
+		d := scheduler.NewReminderDispatcher(repo, cfg, email)
+
+		expectTick(repo, []domain.ReminderDelivery{{ID: 1, Channel: domain.ChannelEmail, Attempts: 1}})
+		repo.On("MarkSent", mock.Anything, int64(1), mock.Anything).Return(nil).Once()
+
+		err := d.Dispatch(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{1}, email.sent)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Failed - Retried With Backoff", func(t *testing.T) {
+		repo := new(mocks.ReminderRepository)
+		email := &fakeChannel{name: domain.ChannelEmail, err: errors.New("smtp down")}
+		d := scheduler.NewReminderDispatcher(repo, cfg, email)
+
+		expectTick(repo, []domain.ReminderDelivery{{ID: 2, Channel: domain.ChannelEmail, Attempts: 2}})
+		repo.On("MarkFailed", mock.Anything, int64(2), "smtp down", mock.MatchedBy(func(at *time.Time) bool {
+			return at != nil && at.After(time.Now().Add(3*time.Minute))
+		}), mock.Anything).Return(nil).Once()
+
+		assert.NoError(t, d.Dispatch(context.Background()))
+		repo.AssertExpectations(t)
+		repo.AssertNotCalled(t, "MarkSent", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failed - Gives Up After Max Attempts", func(t *testing.T) {
+		repo := new(mocks.ReminderRepository)
+		email := &fakeChannel{name: domain.ChannelEmail, err: errors.New("smtp down")}
+		d := scheduler.NewReminderDispatcher(repo, cfg, email)
+
+		expectTick(repo, []domain.ReminderDelivery{{ID: 3, Channel: domain.ChannelEmail, Attempts: 3}})
+		repo.On("MarkFailed", mock.Anything, int64(3), "smtp down", (*time.Time)(nil), mock.Anything).Return(nil).Once()
+
+		assert.NoError(t, d.Dispatch(context.Background()))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Failed - Channel No Longer Configured", func(t *testing.T) {
+		repo := new(mocks.ReminderRepository)
+		email := &fakeChannel{name: domain.ChannelEmail}
+		d := scheduler.NewReminderDispatcher(repo, cfg, email)
+
+		expectTick(repo, []domain.ReminderDelivery{{ID: 4, Channel: domain.ChannelWebhook, Attempts: 1}})
+		repo.On("MarkFailed", mock.Anything, int64(4), mock.Anything, (*time.Time)(nil), mock.Anything).Return(nil).Once()
+
+		assert.NoError(t, d.Dispatch(context.Background()))
+		assert.Empty(t, email.sent)
+		repo.AssertExpectations(t)
+	})
+}
@@ -2,93 +2,124 @@ package scheduler
 
 import (
 	"context"
-	"fmt"
 	"log"
 	"time"
 
 	"simple-task-manager/internal/core/domain"
-
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-type TaskScheduler struct {
-	db *pgxpool.Pool
+// RecurrenceConfig mengatur scheduler task recurring
+type RecurrenceConfig struct {
+	BatchSize int // task maksimal per putaran; sisanya diproses putaran berikutnya
 }
 
-func NewTaskScheduler(db *pgxpool.Pool) *TaskScheduler {
-	return &TaskScheduler{db: db}
+// TaskScheduler membuat instance task recurring terjadwal. Klaim, pembuatan instance dan
+// pemajuan next_run terjadi dalam satu transaksi per task (lihat RecurrenceRepository),
+// sehingga beberapa replica boleh menjalankan scheduler ini bersamaan tanpa instance ganda.
+type TaskScheduler struct {
+	repo domain.RecurrenceRepository
+	cfg  RecurrenceConfig
 }
 
-// ProcessRecurringTasks akan dipanggil setiap kali Cron berjalan
-func (s *TaskScheduler) ProcessRecurringTasks() {
-	ctx := context.Background()
-	log.Println(">>> [CRON] Checking for recurring tasks...")
+func NewTaskScheduler(repo domain.RecurrenceRepository, cfg RecurrenceConfig) *TaskScheduler {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 500
+	}
+	return &TaskScheduler{repo: repo, cfg: cfg}
+}
 
-	// 1. Cari task yang recurrence pattern-nya aktif DAN waktunya sudah lewat (NextRun < Now)
-	query := `
-		SELECT id, user_id, title, description, priority, labels, recurrence_pattern, next_run
-		FROM tasks 
-		WHERE recurrence_pattern != '' 
-		AND next_run <= NOW()
-	`
+// RecurrenceReport merangkum satu putaran scheduler recurring
+type RecurrenceReport struct {
+	Tasks   int // task recurring jatuh tempo yang diproses scheduler ini
+	Created int // instance yang dibuat
+	Skipped int // kemunculan terlewat yang tidak dibuatkan instance (sesuai missed_run_policy)
+}
 
-	rows, err := s.db.Query(ctx, query)
+// ProcessRecurringTasks akan dipanggil setiap kali Cron berjalan. Semua kemunculan yang
+// terlewat sejak next_run diproses sekaligus, lalu next_run dimajukan melewati sekarang.
+func (s *TaskScheduler) ProcessRecurringTasks() RecurrenceReport {
+	report, err := s.Process(context.Background())
 	if err != nil {
-		log.Printf("[CRON ERROR] Fetching tasks: %v", err)
-		return
+		log.Printf("[CRON ERROR] Processing recurring tasks: %v", err)
+	}
+	if report.Tasks > 0 {
+		log.Printf("[CRON] Recurring tasks: %d due, %d instance(s) created, %d occurrence(s) skipped",
+			report.Tasks, report.Created, report.Skipped)
 	}
-	defer rows.Close()
+	return report
+}
 
-	for rows.Next() {
-		var t domain.Task
-		err := rows.Scan(&t.ID, &t.UserID, &t.Title, &t.Description, &t.Priority, &t.Labels, &t.RecurrencePattern, &t.NextRun)
-		if err != nil {
-			continue
-		}
+// Process menjalankan satu putaran; report tetap berisi task yang berhasil walau ada error
+func (s *TaskScheduler) Process(ctx context.Context) (RecurrenceReport, error) {
+	var report RecurrenceReport
+	now := time.Now()
+	tasks, created, err := s.repo.ProcessDue(ctx, now, s.cfg.BatchSize, func(c domain.RecurrenceClaim) domain.RecurrenceAdvance {
+		advance, skipped := s.advance(c, now)
+		report.Skipped += skipped
+		return advance
+	})
+	report.Tasks, report.Created = tasks, created
+	return report, err
+}
 
-		// 2. Clone Task Baru
-		s.createNextInstance(ctx, &t)
+// advance menghitung instance yang dibuat untuk satu task & next_run barunya
+func (s *TaskScheduler) advance(c domain.RecurrenceClaim, now time.Time) (domain.RecurrenceAdvance, int) {
+	t := c.Task
+	rule, err := domain.ParseRecurrence(t.RecurrencePattern)
+	if err != nil {
+		// Aturan rusak dimatikan supaya tidak diklaim setiap menit
+		log.Printf("[CRON ERROR] Invalid recurrence %q on task %d, stopping it: %v", t.RecurrencePattern, t.ID, err)
+		return domain.RecurrenceAdvance{}, 0
+	}
 
-		// 3. Update NextRun di Task Induk (Supaya tidak digenerate terus menerus)
-		s.updateNextRun(ctx, &t)
+	// 1. Hitung semua kemunculan yang terlewat & pilih sesuai kebijakan task
+	plan := rule.CatchUp(*t.RecurrenceStart, *t.NextRun, now, t.MissedRunPolicy, c.Location)
+	if plan.Skipped > 0 {
+		log.Printf("[CRON] Task %d: skipped %d missed occurrence(s) (policy %s)", t.ID, plan.Skipped, t.MissedRunPolicy)
 	}
-}
 
-func (s *TaskScheduler) createNextInstance(ctx context.Context, parent *domain.Task) {
-	// Buat task baru berdasarkan parent, tapi reset status jadi pending
-	insertQuery := `
-		INSERT INTO tasks (user_id, title, description, status, priority, labels, created_at, updated_at)
-		VALUES ($1, $2, $3, 'pending', $4, $5, NOW(), NOW())
-	`
-	// Tambahkan penanda di judul (Opsional)
-	newTitle := fmt.Sprintf("%s (Auto)", parent.Title)
+	// 2. Clone task untuk setiap kemunculan terpilih
+	var advance domain.RecurrenceAdvance
+	for i, occurrence := range plan.Create {
+		skipped := 0
+		if i == 0 {
+			skipped = plan.Skipped // dilaporkan sekali per putaran
+		}
+		advance.Instances = append(advance.Instances, newInstance(&t, occurrence.UTC(), skipped, c, now))
+	}
 
-	_, err := s.db.Exec(ctx, insertQuery, parent.UserID, newTitle, parent.Description, parent.Priority, parent.Labels)
-	if err != nil {
-		log.Printf("[CRON ERROR] Failed creating instance for task %d: %v", parent.ID, err)
-	} else {
-		log.Printf("[CRON SUCCESS] Created new instance for task: %s", parent.Title)
+	// 3. Majukan next_run melewati sekarang; nil jika seri sudah habis (COUNT/UNTIL)
+	if plan.Next == nil {
+		log.Printf("[CRON] Recurrence of task %d has ended", t.ID)
+		return advance, plan.Skipped
 	}
+	next := plan.Next.UTC()
+	advance.NextRun = &next
+	return advance, plan.Skipped
 }
 
-func (s *TaskScheduler) updateNextRun(ctx context.Context, t *domain.Task) {
-	var nextTime time.Time
-	
-	// Hitung waktu berikutnya berdasarkan pattern
-	switch t.RecurrencePattern {
-	case "daily":
-		nextTime = t.NextRun.AddDate(0, 0, 1) // Tambah 1 hari
-	case "weekly":
-		nextTime = t.NextRun.AddDate(0, 0, 7) // Tambah 7 hari
-	case "monthly":
-		nextTime = t.NextRun.AddDate(0, 1, 0) // Tambah 1 bulan
-	default:
-		return // Pattern tidak dikenal
-	}
+// newInstance menyalin template untuk kemunculan occurrence. Tenggat & reminder_time
+// template digeser sebanyak hari kalender kemunculan ini dari DTSTART di zona waktu pemilik.
+func newInstance(parent *domain.Task, occurrence time.Time, skipped int, c domain.RecurrenceClaim, now time.Time) domain.RecurrenceInstance {
+	inst := parent.Instance(occurrence, c.Location)
+	inst.Status = c.InitialStatus
+	inst.StatusChangedAt, inst.CreatedAt, inst.UpdatedAt = &now, now, now
 
-	// Update DB
-	_, err := s.db.Exec(ctx, "UPDATE tasks SET next_run = $1 WHERE id = $2", nextTime, t.ID)
-	if err != nil {
-		log.Printf("[CRON ERROR] Failed update next_run for task %d: %v", t.ID, err)
+	return domain.RecurrenceInstance{
+		Task:         inst,
+		ReminderBase: inst.ReminderBase(c.Location),
+		// Beri tahu pemilik lewat event bus (notifikasi in-app); ikut masuk outbox
+		Event: domain.Event{
+			Type:        domain.EventRecurrenceInstanceCreated,
+			WorkspaceID: inst.WorkspaceID,
+			Recipients:  []int64{parent.UserID},
+			Data: map[string]any{
+				"title":      inst.Title,
+				"parent_id":  parent.ID,
+				"occurrence": occurrence,
+				"skipped":    skipped,
+			},
+			OccurredAt: now,
+		},
 	}
-}
\ No newline at end of file
+}
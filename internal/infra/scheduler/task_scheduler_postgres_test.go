@@ -0,0 +1,126 @@
+package scheduler_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+	"simple-task-manager/internal/infra/repository"
+	"simple-task-manager/internal/infra/scheduler"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+)
+
+// testDB membuka database dari TEST_DB_URL dan menerapkan init.sql (idempoten).
+// Test dilewati jika TEST_DB_URL tidak diset; jangan arahkan ke database produksi.
+func testDB(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+	url := os.Getenv("TEST_DB_URL")
+	if url == "" {
+		t.Skip("TEST_DB_URL is not set")
+	}
+
+	ctx := context.Background()
+	db, err := pgxpool.New(ctx, url)
+	if err != nil {
+		t.Fatalf("connecting to test database: %v", err)
+	}
+	t.Cleanup(db.Close)
+
+	schema, err := os.ReadFile("../../../init.sql")
+	if err != nil {
+		t.Fatalf("reading schema: %v", err)
+	}
+	if _, err := db.Exec(ctx, string(schema)); err != nil {
+		t.Fatalf("applying schema: %v", err)
+	}
+	return db
+}
+
+// claimCounter mencatat berapa kali tiap task diklaim oleh semua scheduler
+type claimCounter struct {
+	domain.RecurrenceRepository
+	mu     sync.Mutex
+	claims map[int64]int
+}
+
+func (c *claimCounter) ProcessDue(ctx context.Context, now time.Time, limit int, fn func(domain.RecurrenceClaim) domain.RecurrenceAdvance) (int, int, error) {
+	return c.RecurrenceRepository.ProcessDue(ctx, now, limit, func(claim domain.RecurrenceClaim) domain.RecurrenceAdvance {
+		c.mu.Lock()
+		c.claims[claim.Task.ID]++
+		c.mu.Unlock()
+		return fn(claim)
+	})
+}
+
+func TestTaskSchedulerPostgresConcurrency(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+
+	const templates, schedulers = 20, 8
+	start := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -5)
+	wantNext := start.AddDate(0, 0, 6) // kemunculan pertama setelah sekarang
+
+	var userID int64
+	err := db.QueryRow(ctx, `INSERT INTO users (name, email, password, timezone) VALUES ('Scheduler Test', $1, 'x', 'UTC') RETURNING id`,
+		fmt.Sprintf("scheduler-%d@example.test", time.Now().UnixNano())).Scan(&userID)
+	if err != nil {
+		t.Fatalf("seeding user: %v", err)
+	}
+	t.Cleanup(func() {
+		db.Exec(ctx, `DELETE FROM task_events WHERE task_id IN (SELECT id FROM tasks WHERE user_id = $1)`, userID)
+		db.Exec(ctx, `DELETE FROM users WHERE id = $1`, userID)
+	})
+
+	ids := make([]int64, templates)
+	for i := range ids {
+		err := db.QueryRow(ctx, `
+			INSERT INTO tasks (user_id, title, status, priority, recurrence_pattern, recurrence_start, next_run,
+				recurrence_mode, missed_run_policy)
+			VALUES ($1, $2, 'pending', 'medium', 'FREQ=DAILY', $3, $3, 'schedule', 'all')
+			RETURNING id
+		`, userID, fmt.Sprintf("Daily check %d", i), start).Scan(&ids[i])
+		if err != nil {
+			t.Fatalf("seeding template: %v", err)
+		}
+	}
+
+	repo := &claimCounter{RecurrenceRepository: repository.NewRecurrenceRepository(db), claims: map[int64]int{}}
+	ready := make(chan struct{})
+	var wg sync.WaitGroup
+	for range schedulers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-ready
+			_, err := scheduler.NewTaskScheduler(repo, scheduler.RecurrenceConfig{BatchSize: 5}).Process(ctx)
+			assert.NoError(t, err)
+		}()
+	}
+	close(ready)
+	wg.Wait()
+
+	for _, id := range ids {
+		assert.Equal(t, 1, repo.claims[id], "task %d must be claimed exactly once", id)
+
+		var nextRun time.Time
+		if err := db.QueryRow(ctx, `SELECT next_run FROM tasks WHERE id = $1`, id).Scan(&nextRun); assert.NoError(t, err) {
+			assert.True(t, wantNext.Equal(nextRun), "task %d next_run %v, want %v", id, nextRun, wantNext)
+		}
+	}
+
+	var rows, distinct int
+	err = db.QueryRow(ctx, `
+		SELECT count(*), count(DISTINCT (recurrence_parent_id, occurrence_at))
+		FROM tasks WHERE recurrence_parent_id = ANY($1)
+	`, ids).Scan(&rows, &distinct)
+	if assert.NoError(t, err) {
+		assert.Equal(t, templates*6, rows) // kebijakan all: start .. start+5 hari
+		assert.Equal(t, rows, distinct)
+	}
+}
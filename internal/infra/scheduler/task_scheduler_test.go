@@ -0,0 +1,238 @@
+package scheduler_test
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+	"simple-task-manager/internal/infra/scheduler"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// memRecurrenceStore adalah RecurrenceRepository di memori yang meniru semantik Postgres
+// yang dipakai repository: klaim baris dengan FOR UPDATE SKIP LOCKED, dan commit yang
+// menyimpan instance & next_run sekaligus. Sengaja tanpa constraint unik supaya instance
+// ganda terlihat oleh test, bukan diredam oleh database.
+type memRecurrenceStore struct {
+	mu        sync.Mutex
+	tasks     map[int64]*domain.Task
+	locked    map[int64]bool
+	instances []domain.Task
+	loc       *time.Location // zona waktu pemilik semua task; default UTC
+	initial   string         // status awal workflow pemilik; default pending
+}
+
+func newMemRecurrenceStore(tasks ...domain.Task) *memRecurrenceStore {
+	s := &memRecurrenceStore{tasks: map[int64]*domain.Task{}, locked: map[int64]bool{}, loc: time.UTC, initial: "pending"}
+	for i := range tasks {
+		s.tasks[tasks[i].ID] = &tasks[i]
+	}
+	return s
+}
+
+func (s *memRecurrenceStore) ProcessDue(ctx context.Context, now time.Time, limit int, fn func(domain.RecurrenceClaim) domain.RecurrenceAdvance) (int, int, error) {
+	processed, created := 0, 0
+	for processed < limit {
+		claim, ok := s.claim(now)
+		if !ok {
+			break
+		}
+		runtime.Gosched() // beri kesempatan scheduler lain berjalan selama klaim ditahan
+
+		advance := fn(domain.RecurrenceClaim{Task: claim, Location: s.loc, InitialStatus: s.initial})
+
+		s.mu.Lock()
+		for _, inst := range advance.Instances {
+			inst.Task.ID = int64(len(s.instances) + 1000)
+			s.instances = append(s.instances, inst.Task)
+		}
+		s.tasks[claim.ID].NextRun = advance.NextRun
+		delete(s.locked, claim.ID)
+		s.mu.Unlock()
+
+		processed++
+		created += len(advance.Instances)
+	}
+	return processed, created, nil
+}
+
+// claim meniru SELECT ... WHERE next_run <= now FOR UPDATE SKIP LOCKED LIMIT 1
+func (s *memRecurrenceStore) claim(now time.Time) (domain.Task, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, t := range s.tasks {
+		if s.locked[id] || t.NextRun == nil || t.NextRun.After(now) {
+			continue
+		}
+		s.locked[id] = true
+		return *t, true
+	}
+	return domain.Task{}, false
+}
+
+func dailyTemplate(id int64, start, nextRun time.Time, policy string) domain.Task {
+	return domain.Task{ID: id, UserID: 1, Title: fmt.Sprintf("Chore %d", id), Priority: "low",
+		RecurrencePattern: "FREQ=DAILY", RecurrenceStart: &start, NextRun: &nextRun, MissedRunPolicy: policy}
+}
+
+func TestTaskSchedulerConcurrentReplicas(t *testing.T) {
+	const tasks, replicas = 40, 8
+
+	// Setiap task terlewat 3 hari (+ kemunculan hari ini), kebijakan all: 4 instance per task
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	if time.Now().Sub(today) < time.Minute {
+		today = today.AddDate(0, 0, -1) // hindari batas tengah malam
+	}
+	var templates []domain.Task
+	for i := int64(1); i <= tasks; i++ {
+		start := today.AddDate(0, 0, -10)
+		templates = append(templates, dailyTemplate(i, start, today.AddDate(0, 0, -3), domain.MissedRunAll))
+	}
+	store := newMemRecurrenceStore(templates...)
+
+	var wg sync.WaitGroup
+	reports := make([]scheduler.RecurrenceReport, replicas)
+	for r := 0; r < replicas; r++ {
+		wg.Add(1)
+		go func(r int) {
+			defer wg.Done()
+			s := scheduler.NewTaskScheduler(store, scheduler.RecurrenceConfig{BatchSize: 7})
+			// Beberapa tick per replica, seperti cron yang berjalan di tiap pod
+			for tick := 0; tick < 10; tick++ {
+				report, err := s.Process(context.Background())
+				assert.NoError(t, err)
+				reports[r].Tasks += report.Tasks
+				reports[r].Created += report.Created
+			}
+		}(r)
+	}
+	wg.Wait()
+
+	seen := map[string]bool{}
+	for _, inst := range store.instances {
+		key := fmt.Sprintf("%d@%s", *inst.RecurrenceParentID, inst.OccurrenceAt.Format(time.RFC3339))
+		assert.False(t, seen[key], "duplicate instance %s", key)
+		seen[key] = true
+	}
+	assert.Len(t, store.instances, tasks*4)
+
+	total := scheduler.RecurrenceReport{}
+	for _, r := range reports {
+		total.Tasks += r.Tasks
+		total.Created += r.Created
+	}
+	assert.Equal(t, tasks, total.Tasks, "each task is claimed exactly once")
+	assert.Equal(t, tasks*4, total.Created)
+
+	for _, tpl := range store.tasks {
+		assert.Equal(t, today.AddDate(0, 0, 1), *tpl.NextRun)
+	}
+}
+
+func TestTaskSchedulerProcess(t *testing.T) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	if time.Now().Sub(today) < time.Minute {
+		today = today.AddDate(0, 0, -1)
+	}
+	start := today.AddDate(0, 0, -10)
+
+	t.Run("Latest Policy Creates One Instance And Reports Skipped", func(t *testing.T) {
+		store := newMemRecurrenceStore(dailyTemplate(1, start, today.AddDate(0, 0, -3), domain.MissedRunLatest))
+		s := scheduler.NewTaskScheduler(store, scheduler.RecurrenceConfig{})
+
+		report, err := s.Process(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, scheduler.RecurrenceReport{Tasks: 1, Created: 1, Skipped: 3}, report)
+		if assert.Len(t, store.instances, 1) {
+			inst := store.instances[0]
+			assert.Equal(t, today, *inst.OccurrenceAt)
+			assert.Equal(t, int64(1), *inst.RecurrenceParentID)
+			assert.Equal(t, "Chore 1", inst.Title)
+			assert.Equal(t, "pending", inst.Status)
+		}
+	})
+
+	t.Run("Instance Starts In Owner's Workflow", func(t *testing.T) {
+		store := newMemRecurrenceStore(dailyTemplate(6, start, today, domain.MissedRunLatest))
+		store.initial = "backlog" // workflow custom tanpa status pending
+		s := scheduler.NewTaskScheduler(store, scheduler.RecurrenceConfig{})
+
+		_, err := s.Process(context.Background())
+
+		assert.NoError(t, err)
+		if assert.Len(t, store.instances, 1) {
+			assert.Equal(t, "backlog", store.instances[0].Status)
+		}
+	})
+
+	t.Run("Invalid Rule Stops The Series", func(t *testing.T) {
+		broken := dailyTemplate(2, start, today, domain.MissedRunLatest)
+		broken.RecurrencePattern = "FREQ=FORTNIGHTLY"
+		store := newMemRecurrenceStore(broken)
+		s := scheduler.NewTaskScheduler(store, scheduler.RecurrenceConfig{})
+
+		report, err := s.Process(context.Background())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, report.Created)
+		assert.Empty(t, store.instances)
+		assert.Nil(t, store.tasks[2].NextRun)
+	})
+
+	t.Run("Shift Keeps Local Dates Across DST", func(t *testing.T) {
+		ny, err := time.LoadLocation("America/New_York")
+		assert.NoError(t, err)
+		// DST di New York mulai 8 Maret 2026; seri dimulai 1 Maret 09:00 EST
+		start := time.Date(2026, 3, 1, 9, 0, 0, 0, ny).UTC()
+		occurrence := time.Date(2026, 3, 10, 9, 0, 0, 0, ny).UTC()
+		allDay := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+		remind := time.Date(2026, 3, 1, 8, 0, 0, 0, ny).UTC()
+
+		tpl := dailyTemplate(4, start, occurrence, domain.MissedRunAll)
+		tpl.RecurrencePattern = "FREQ=DAILY;UNTIL=20260310T235959Z"
+		tpl.DueAt, tpl.DueAllDay, tpl.ReminderTime = &allDay, true, &remind
+		timed := dailyTemplate(5, start, occurrence, domain.MissedRunAll)
+		timed.RecurrencePattern = tpl.RecurrencePattern
+		timedDue := time.Date(2026, 3, 1, 17, 0, 0, 0, ny).UTC()
+		timed.DueAt = &timedDue
+
+		store := newMemRecurrenceStore(tpl, timed)
+		store.loc = ny
+		s := scheduler.NewTaskScheduler(store, scheduler.RecurrenceConfig{})
+
+		_, err = s.Process(context.Background())
+
+		assert.NoError(t, err)
+		if assert.Len(t, store.instances, 2) {
+			byParent := map[int64]domain.Task{}
+			for _, inst := range store.instances {
+				byParent[*inst.RecurrenceParentID] = inst
+			}
+			// all-day tetap tanggal 10, bukan 9 Maret 23:00Z
+			assert.Equal(t, time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC), *byParent[4].DueAt)
+			assert.Equal(t, time.Date(2026, 3, 10, 8, 0, 0, 0, ny).UTC(), *byParent[4].ReminderTime)
+			assert.Equal(t, time.Date(2026, 3, 10, 17, 0, 0, 0, ny).UTC(), *byParent[5].DueAt)
+		}
+	})
+
+	t.Run("Due Date Shifts With The Occurrence", func(t *testing.T) {
+		tpl := dailyTemplate(3, start, today, domain.MissedRunLatest)
+		due := start.Add(2 * time.Hour)
+		tpl.DueAt = &due
+		store := newMemRecurrenceStore(tpl)
+		s := scheduler.NewTaskScheduler(store, scheduler.RecurrenceConfig{})
+
+		_, err := s.Process(context.Background())
+
+		assert.NoError(t, err)
+		if assert.Len(t, store.instances, 1) {
+			assert.Equal(t, today.Add(2*time.Hour), *store.instances[0].DueAt)
+		}
+	})
+}
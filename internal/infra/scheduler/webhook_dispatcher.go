@@ -0,0 +1,140 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+)
+
+// WebhookConfig mengatur antrian pengiriman webhook
+type WebhookConfig struct {
+	BatchSize   int
+	MaxBatches  int
+	Lease       time.Duration // baris sending lebih lama dari ini dianggap terputus dan diantrikan ulang
+	SendTimeout time.Duration
+	MaxAttempts int
+	BaseBackoff time.Duration // jeda retry pertama; berlipat dua setiap percobaan
+	MaxBackoff  time.Duration
+}
+
+// WebhookDispatcher mengosongkan antrian webhook_deliveries. Pengiriman bersifat
+// at-least-once: beberapa replica aman berjalan bersamaan karena klaim memakai
+// SKIP LOCKED, dan baris yang terputus di tengah jalan dicoba lagi.
+type WebhookDispatcher struct {
+	repo   domain.WebhookRepository
+	sender domain.WebhookSender
+	cfg    WebhookConfig
+	now    func() time.Time
+}
+
+func NewWebhookDispatcher(repo domain.WebhookRepository, sender domain.WebhookSender, cfg WebhookConfig) *WebhookDispatcher {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 50
+	}
+	if cfg.MaxBatches <= 0 {
+		cfg.MaxBatches = 10
+	}
+	if cfg.SendTimeout <= 0 {
+		cfg.SendTimeout = 10 * time.Second
+	}
+	if cfg.Lease <= cfg.SendTimeout {
+		cfg.Lease = 5 * time.Minute
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 8
+	}
+	if cfg.BaseBackoff <= 0 {
+		cfg.BaseBackoff = 30 * time.Second
+	}
+	if cfg.MaxBackoff <= 0 {
+		cfg.MaxBackoff = 6 * time.Hour
+	}
+	return &WebhookDispatcher{repo: repo, sender: sender, cfg: cfg, now: time.Now}
+}
+
+// DispatchPending akan dipanggil setiap kali Cron berjalan
+func (d *WebhookDispatcher) DispatchPending() {
+	if err := d.Dispatch(context.Background()); err != nil {
+		log.Printf("[CRON ERROR] Dispatching webhooks: %v", err)
+	}
+}
+
+// Dispatch menjalankan satu putaran: antrikan ulang klaim basi, lalu kirim yang jatuh tempo
+func (d *WebhookDispatcher) Dispatch(ctx context.Context) error {
+	if n, err := d.repo.RequeueStale(ctx, d.now().Add(-d.cfg.Lease)); err != nil {
+		return err
+	} else if n > 0 {
+		log.Printf("[WEBHOOK] %d delivery(s) interrupted while sending, requeued", n)
+	}
+
+	hooks := map[int64]*domain.Webhook{}
+	for i := 0; i < d.cfg.MaxBatches; i++ {
+		batch, err := d.repo.ClaimDeliveries(ctx, d.now(), d.cfg.BatchSize)
+		if err != nil {
+			return err
+		}
+		for _, delivery := range batch {
+			hook, ok := hooks[delivery.WebhookID]
+			if !ok {
+				if hook, err = d.repo.GetByID(ctx, delivery.WebhookID); err != nil {
+					return err
+				}
+				hooks[delivery.WebhookID] = hook
+			}
+			d.deliver(ctx, hook, delivery)
+		}
+		if len(batch) < d.cfg.BatchSize {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (d *WebhookDispatcher) deliver(ctx context.Context, hook *domain.Webhook, delivery domain.WebhookDelivery) {
+	var status int
+	var err error
+	if hook == nil {
+		err = errWebhookGone
+	} else {
+		sendCtx, cancel := context.WithTimeout(ctx, d.cfg.SendTimeout)
+		status, err = d.sender.Send(sendCtx, *hook, delivery)
+		cancel()
+	}
+
+	now := d.now()
+	delivery.UpdatedAt = now
+	delivery.ResponseStatus = nil
+	if status != 0 {
+		delivery.ResponseStatus = &status
+	}
+
+	switch {
+	case err == nil:
+		delivery.Status, delivery.DeliveredAt, delivery.LastError = domain.WebhookDeliverySucceeded, &now, nil
+	case hook == nil || delivery.Attempts >= d.cfg.MaxAttempts:
+		msg := err.Error()
+		delivery.Status, delivery.LastError = domain.WebhookDeliveryFailed, &msg
+	default:
+		msg := err.Error()
+		delivery.Status, delivery.LastError = domain.WebhookDeliveryPending, &msg
+		delivery.NextAttemptAt = now.Add(d.backoff(delivery.Attempts))
+	}
+
+	if err := d.repo.CompleteDelivery(ctx, &delivery); err != nil {
+		log.Printf("[WEBHOOK ERROR] Saving result of delivery %d: %v", delivery.ID, err)
+	}
+}
+
+// backoff: BaseBackoff * 2^(attempts-1), dibatasi MaxBackoff
+func (d *WebhookDispatcher) backoff(attempts int) time.Duration {
+	wait := d.cfg.BaseBackoff
+	for i := 1; i < attempts && wait < d.cfg.MaxBackoff; i++ {
+		wait *= 2
+	}
+	return min(wait, d.cfg.MaxBackoff)
+}
+
+var errWebhookGone = errors.New("webhook no longer exists")
@@ -0,0 +1,86 @@
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+	"simple-task-manager/internal/core/usecase/mocks"
+	"simple-task-manager/internal/infra/scheduler"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDispatchWebhooks(t *testing.T) {
+	cfg := scheduler.WebhookConfig{BatchSize: 10, MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: 3 * time.Minute}
+	hook := &domain.Webhook{ID: 1, URL: "https://example.com/hook", Active: true}
+
+	expectTick := func(repo *mocks.WebhookRepository, batch []domain.WebhookDelivery) {
+		repo.On("RequeueStale", mock.Anything, mock.Anything).Return(int64(0), nil).Once()
+		repo.On("ClaimDeliveries", mock.Anything, mock.Anything, 10).Return(batch, nil).Once()
+		repo.On("GetByID", mock.Anything, int64(1)).Return(hook, nil).Once()
+	}
+
+	t.Run("Success - Marks Succeeded", func(t *testing.T) {
+		repo, sender := new(mocks.WebhookRepository), new(mocks.WebhookSender)
+		d := scheduler.NewWebhookDispatcher(repo, sender, cfg)
+
+		expectTick(repo, []domain.WebhookDelivery{{ID: 10, WebhookID: 1, Attempts: 1}})
+		sender.On("Send", mock.Anything, *hook, mock.Anything).Return(204, nil).Once()
+		repo.On("CompleteDelivery", mock.Anything, mock.MatchedBy(func(d *domain.WebhookDelivery) bool {
+			return d.ID == 10 && d.Status == domain.WebhookDeliverySucceeded && d.DeliveredAt != nil && *d.ResponseStatus == 204
+		})).Return(nil).Once()
+
+		assert.NoError(t, d.Dispatch(context.Background()))
+		repo.AssertExpectations(t)
+		sender.AssertExpectations(t)
+	})
+
+	t.Run("Failed - Requeued With Exponential Backoff", func(t *testing.T) {
+		repo, sender := new(mocks.WebhookRepository), new(mocks.WebhookSender)
+		d := scheduler.NewWebhookDispatcher(repo, sender, cfg)
+
+		// percobaan ke-2: 1m * 2 = 2m
+		expectTick(repo, []domain.WebhookDelivery{{ID: 11, WebhookID: 1, Attempts: 2}})
+		sender.On("Send", mock.Anything, *hook, mock.Anything).Return(503, errors.New("webhook responded with status 503")).Once()
+		repo.On("CompleteDelivery", mock.Anything, mock.MatchedBy(func(d *domain.WebhookDelivery) bool {
+			wait := time.Until(d.NextAttemptAt)
+			return d.Status == domain.WebhookDeliveryPending && wait > 110*time.Second && wait <= 2*time.Minute && *d.LastError != ""
+		})).Return(nil).Once()
+
+		assert.NoError(t, d.Dispatch(context.Background()))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Failed - Gives Up After Max Attempts", func(t *testing.T) {
+		repo, sender := new(mocks.WebhookRepository), new(mocks.WebhookSender)
+		d := scheduler.NewWebhookDispatcher(repo, sender, cfg)
+
+		expectTick(repo, []domain.WebhookDelivery{{ID: 12, WebhookID: 1, Attempts: 3}})
+		sender.On("Send", mock.Anything, *hook, mock.Anything).Return(0, errors.New("connection refused")).Once()
+		repo.On("CompleteDelivery", mock.Anything, mock.MatchedBy(func(d *domain.WebhookDelivery) bool {
+			return d.Status == domain.WebhookDeliveryFailed && d.ResponseStatus == nil
+		})).Return(nil).Once()
+
+		assert.NoError(t, d.Dispatch(context.Background()))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Deleted Webhook - Fails Without Sending", func(t *testing.T) {
+		repo, sender := new(mocks.WebhookRepository), new(mocks.WebhookSender)
+		d := scheduler.NewWebhookDispatcher(repo, sender, cfg)
+
+		repo.On("RequeueStale", mock.Anything, mock.Anything).Return(int64(0), nil).Once()
+		repo.On("ClaimDeliveries", mock.Anything, mock.Anything, 10).Return([]domain.WebhookDelivery{{ID: 13, WebhookID: 9, Attempts: 1}}, nil).Once()
+		repo.On("GetByID", mock.Anything, int64(9)).Return(nil, nil).Once()
+		repo.On("CompleteDelivery", mock.Anything, mock.MatchedBy(func(d *domain.WebhookDelivery) bool {
+			return d.Status == domain.WebhookDeliveryFailed
+		})).Return(nil).Once()
+
+		assert.NoError(t, d.Dispatch(context.Background()))
+		sender.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,183 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+)
+
+// subscriberBuffer adalah jumlah pesan yang boleh tertahan per koneksi sebelum
+// koneksi itu diputus; client lalu reconnect dan melanjutkan dengan Last-Event-ID
+const subscriberBuffer = 64
+
+// Message adalah satu event yang siap dikirim ke client. ID dipakai sebagai
+// Last-Event-ID dan berbentuk "<epoch>-<seq>" supaya ID dari proses sebelum
+// restart tidak tertukar dengan ID baru.
+type Message struct {
+	ID    string
+	Event domain.Event
+
+	seq      uint64
+	audience map[int64]struct{}
+}
+
+// Subscriber adalah satu koneksi stream milik user
+type Subscriber struct {
+	UserID int64
+	C      <-chan Message
+
+	ch     chan Message
+	closed bool
+}
+
+// Hub adalah pub/sub in-process untuk stream real-time. Event dari event bus
+// diteruskan ke koneksi milik user yang boleh melihatnya, dan sejumlah event
+// terakhir disimpan supaya client bisa melanjutkan setelah koneksi putus.
+// Hub tidak dibagi antar replica; setiap proses hanya melihat event miliknya.
+type Hub struct {
+	workspaceRepo domain.WorkspaceRepository
+	epoch         string
+	size          int
+
+	mu     sync.Mutex
+	seq    uint64
+	buffer []Message // urut naik berdasarkan seq, paling banyak size pesan
+	subs   map[int64]map[*Subscriber]struct{}
+}
+
+func NewHub(workspaceRepo domain.WorkspaceRepository, bufferSize int) *Hub {
+	if bufferSize <= 0 {
+		bufferSize = 1000
+	}
+	return &Hub{
+		workspaceRepo: workspaceRepo,
+		epoch:         strconv.FormatInt(time.Now().UnixNano(), 36),
+		size:          bufferSize,
+		subs:          make(map[int64]map[*Subscriber]struct{}),
+	}
+}
+
+// audience adalah penerima langsung event ditambah seluruh anggota workspace-nya
+func (h *Hub) audience(ctx context.Context, e domain.Event) (map[int64]struct{}, error) {
+	audience := make(map[int64]struct{}, len(e.Recipients))
+	for _, id := range e.Recipients {
+		audience[id] = struct{}{}
+	}
+	if e.WorkspaceID != nil {
+		members, err := h.workspaceRepo.ListMembers(ctx, *e.WorkspaceID)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range members {
+			audience[m.UserID] = struct{}{}
+		}
+	}
+	return audience, nil
+}
+
+// Handle adalah subscriber event bus
+func (h *Hub) Handle(ctx context.Context, e domain.Event) error {
+	audience, err := h.audience(ctx, e)
+	if err != nil {
+		return err
+	}
+	if len(audience) == 0 {
+		return nil
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.seq++
+	msg := Message{
+		ID:       fmt.Sprintf("%s-%d", h.epoch, h.seq),
+		Event:    e,
+		seq:      h.seq,
+		audience: audience,
+	}
+	h.buffer = append(h.buffer, msg)
+	if len(h.buffer) > h.size {
+		h.buffer = append(h.buffer[:0:0], h.buffer[len(h.buffer)-h.size:]...)
+	}
+
+	for userID := range audience {
+		for sub := range h.subs[userID] {
+			select {
+			case sub.ch <- msg:
+			default:
+				// koneksi terlalu lambat; putus dan biarkan client melanjutkan dari Last-Event-ID
+				h.remove(sub)
+			}
+		}
+	}
+	return nil
+}
+
+// Subscribe mendaftarkan koneksi baru. Jika lastEventID diisi, pesan setelahnya
+// dikembalikan sebagai backlog; reset bernilai true jika riwayatnya sudah tidak
+// tersedia (restart atau terlalu lama terputus) sehingga client harus refetch.
+func (h *Hub) Subscribe(userID int64, lastEventID string) (sub *Subscriber, backlog []Message, reset bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if lastEventID != "" {
+		last, ok := h.parseID(lastEventID)
+		switch {
+		case !ok:
+			reset = true
+		case last < h.seq && (len(h.buffer) == 0 || h.buffer[0].seq > last+1):
+			reset = true
+		default:
+			for _, msg := range h.buffer {
+				if _, ok := msg.audience[userID]; ok && msg.seq > last {
+					backlog = append(backlog, msg)
+				}
+			}
+		}
+	}
+
+	ch := make(chan Message, subscriberBuffer)
+	sub = &Subscriber{UserID: userID, C: ch, ch: ch}
+	if h.subs[userID] == nil {
+		h.subs[userID] = make(map[*Subscriber]struct{})
+	}
+	h.subs[userID][sub] = struct{}{}
+	return sub, backlog, reset
+}
+
+// Unsubscribe melepas koneksi; aman dipanggil setelah hub memutusnya
+func (h *Hub) Unsubscribe(sub *Subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.remove(sub)
+}
+
+func (h *Hub) remove(sub *Subscriber) {
+	if sub.closed {
+		return
+	}
+	sub.closed = true
+	close(sub.ch)
+	delete(h.subs[sub.UserID], sub)
+	if len(h.subs[sub.UserID]) == 0 {
+		delete(h.subs, sub.UserID)
+	}
+}
+
+// parseID mengembalikan seq dari ID milik proses ini
+func (h *Hub) parseID(id string) (uint64, bool) {
+	epoch, raw, ok := strings.Cut(id, "-")
+	if !ok || epoch != h.epoch {
+		return 0, false
+	}
+	seq, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil || seq > h.seq {
+		return 0, false
+	}
+	return seq, true
+}
@@ -0,0 +1,85 @@
+package stream_test
+
+import (
+	"context"
+	"testing"
+
+	"simple-task-manager/internal/core/domain"
+	"simple-task-manager/internal/core/usecase/mocks"
+	"simple-task-manager/internal/infra/stream"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func drain(sub *stream.Subscriber) []int64 {
+	ids := []int64{}
+	for {
+		select {
+		case msg := <-sub.C:
+			ids = append(ids, msg.Event.TaskID)
+		default:
+			return ids
+		}
+	}
+}
+
+func TestHubAudience(t *testing.T) {
+	wsID := int64(4)
+	mockWorkspaceRepo := new(mocks.WorkspaceRepository)
+	hub := stream.NewHub(mockWorkspaceRepo, 10)
+
+	owner, _, _ := hub.Subscribe(1, "")
+	member, _, _ := hub.Subscribe(2, "")
+	outsider, _, _ := hub.Subscribe(3, "")
+
+	mockWorkspaceRepo.On("ListMembers", mock.Anything, wsID).Return([]domain.WorkspaceMember{{UserID: 1}, {UserID: 2}}, nil).Once()
+
+	_ = hub.Handle(context.Background(), domain.Event{Type: domain.EventTaskCreated, TaskID: 10, Recipients: []int64{1}})
+	_ = hub.Handle(context.Background(), domain.Event{Type: domain.EventTaskUpdated, TaskID: 11, WorkspaceID: &wsID})
+
+	assert.Equal(t, []int64{10, 11}, drain(owner))
+	assert.Equal(t, []int64{11}, drain(member))
+	assert.Empty(t, drain(outsider))
+}
+
+func TestHubResume(t *testing.T) {
+	hub := stream.NewHub(new(mocks.WorkspaceRepository), 2)
+
+	first, _, _ := hub.Subscribe(1, "")
+	for _, id := range []int64{10, 11, 12, 13} {
+		_ = hub.Handle(context.Background(), domain.Event{Type: domain.EventTaskUpdated, TaskID: id, Recipients: []int64{1}})
+	}
+	var ids []string
+	for i := 0; i < 4; i++ {
+		ids = append(ids, (<-first.C).ID)
+	}
+	hub.Unsubscribe(first)
+
+	t.Run("Replays Events After Last-Event-ID", func(t *testing.T) {
+		_, backlog, reset := hub.Subscribe(1, ids[1])
+
+		assert.False(t, reset)
+		assert.Len(t, backlog, 2)
+		assert.Equal(t, int64(12), backlog[0].Event.TaskID)
+		assert.Equal(t, int64(13), backlog[1].Event.TaskID)
+	})
+
+	t.Run("Reset When History Was Dropped", func(t *testing.T) {
+		// buffer hanya menyimpan 2 event terakhir, event ke-2 sudah terbuang
+		_, backlog, reset := hub.Subscribe(1, ids[0])
+		assert.True(t, reset)
+		assert.Empty(t, backlog)
+
+		// ID dari proses sebelum restart
+		_, _, reset = hub.Subscribe(1, "previous-1")
+		assert.True(t, reset)
+	})
+
+	t.Run("Nothing To Replay At Latest ID", func(t *testing.T) {
+		_, backlog, reset := hub.Subscribe(1, ids[3])
+
+		assert.False(t, reset)
+		assert.Empty(t, backlog)
+	})
+}
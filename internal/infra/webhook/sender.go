@@ -0,0 +1,123 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"syscall"
+	"time"
+
+	"simple-task-manager/internal/core/domain"
+	"simple-task-manager/pkg/util"
+)
+
+// Header yang dikirim bersama setiap delivery
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery" // sama untuk setiap retry; pakai untuk membuang duplikat
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// Sign menghasilkan tanda tangan "sha256=<hex>" dari HMAC-SHA256 atas "<timestamp>.<body>".
+// Timestamp ikut ditandatangani supaya penerima bisa menolak replay lama.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// SenderConfig mengatur HTTPSender
+type SenderConfig struct {
+	Timeout time.Duration
+	// AllowPrivateNetworks mematikan pemblokiran alamat internal; hanya untuk test & pengembangan lokal
+	AllowPrivateNetworks bool
+}
+
+// errBlockedAddress: URL webhook milik user tidak boleh menjangkau jaringan internal server
+var errBlockedAddress = errors.New("destination address is not allowed")
+
+// HTTPSender mengirim delivery sebagai JSON POST yang ditandatangani. Alamat tujuan diperiksa
+// saat koneksi dibuka (setelah DNS di-resolve), jadi DNS rebinding tidak bisa mengarahkan
+// webhook ke loopback, jaringan privat atau metadata cloud; redirect tidak diikuti.
+type HTTPSender struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+func NewHTTPSender(cfg SenderConfig) *HTTPSender {
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	if !cfg.AllowPrivateNetworks {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			ap, err := netip.ParseAddrPort(address)
+			if err != nil || !util.IsPublicIP(ap.Addr()) {
+				return errBlockedAddress
+			}
+			return nil
+		}
+	}
+	client := &http.Client{
+		Timeout: cfg.Timeout,
+		Transport: &http.Transport{
+			Proxy:               nil, // proxy akan melewati pemeriksaan alamat di atas
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: 10 * time.Second,
+			MaxIdleConnsPerHost: 2,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse // 3xx dianggap gagal, bukan diikuti
+		},
+	}
+	return &HTTPSender{client: client, now: time.Now}
+}
+
+// sendError merangkum error transport tanpa detail jaringan; pesan ini disimpan di log
+// delivery dan ditampilkan ke pemilik webhook
+func sendError(err error) error {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, errBlockedAddress):
+		return errors.New("webhook URL resolves to a private or reserved address")
+	case errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()):
+		return errors.New("webhook request timed out")
+	default:
+		return errors.New("webhook request failed: could not connect or the connection was closed")
+	}
+}
+
+func (s *HTTPSender) Send(ctx context.Context, hook domain.Webhook, d domain.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, errors.New("webhook URL is invalid")
+	}
+
+	ts := s.now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "simple-task-manager-webhooks/1.0")
+	req.Header.Set(HeaderEvent, d.EventType)
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(d.ID, 10))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(ts, 10))
+	req.Header.Set(HeaderSignature, Sign(hook.Secret, ts, d.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, sendError(err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
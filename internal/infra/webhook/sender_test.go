@@ -0,0 +1,113 @@
+package webhook_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"simple-task-manager/internal/core/domain"
+	"simple-task-manager/internal/infra/webhook"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPSenderSignsPayload(t *testing.T) {
+	secret := "whsec_test"
+	payload := []byte(`{"type":"task.created","task_id":10}`)
+
+	var got http.Header
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	sender := webhook.NewHTTPSender(webhook.SenderConfig{AllowPrivateNetworks: true})
+	status, err := sender.Send(context.Background(),
+		domain.Webhook{URL: srv.URL, Secret: secret},
+		domain.WebhookDelivery{ID: 7, EventType: domain.EventTaskCreated, Payload: payload})
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, status)
+	assert.Equal(t, payload, body)
+	assert.Equal(t, "7", got.Get(webhook.HeaderDelivery))
+	assert.Equal(t, domain.EventTaskCreated, got.Get(webhook.HeaderEvent))
+
+	ts, err := strconv.ParseInt(got.Get(webhook.HeaderTimestamp), 10, 64)
+	assert.NoError(t, err)
+	assert.Equal(t, webhook.Sign(secret, ts, payload), got.Get(webhook.HeaderSignature))
+	assert.NotEqual(t, webhook.Sign("other", ts, payload), got.Get(webhook.HeaderSignature))
+}
+
+func TestHTTPSenderNon2xxIsError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	status, err := webhook.NewHTTPSender(webhook.SenderConfig{AllowPrivateNetworks: true}).Send(context.Background(),
+		domain.Webhook{URL: srv.URL, Secret: "s"},
+		domain.WebhookDelivery{ID: 1, EventType: domain.WebhookEventPing, Payload: []byte(`{}`)})
+
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusBadGateway, status)
+}
+
+func TestHTTPSenderBlocksInternalAddresses(t *testing.T) {
+	hit := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hit = true
+	}))
+	defer srv.Close()
+
+	// localhost di-resolve ke 127.0.0.1 dan diperiksa saat koneksi dibuka
+	url := strings.Replace(srv.URL, "127.0.0.1", "localhost", 1)
+	status, err := webhook.NewHTTPSender(webhook.SenderConfig{}).Send(context.Background(),
+		domain.Webhook{URL: url, Secret: "s"},
+		domain.WebhookDelivery{ID: 1, EventType: domain.WebhookEventPing, Payload: []byte(`{}`)})
+
+	assert.EqualError(t, err, "webhook URL resolves to a private or reserved address")
+	assert.Zero(t, status)
+	assert.False(t, hit)
+}
+
+func TestHTTPSenderDoesNotFollowRedirects(t *testing.T) {
+	followed := false
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		followed = true
+	}))
+	defer target.Close()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+	}))
+	defer srv.Close()
+
+	status, err := webhook.NewHTTPSender(webhook.SenderConfig{AllowPrivateNetworks: true}).Send(context.Background(),
+		domain.Webhook{URL: srv.URL, Secret: "s"},
+		domain.WebhookDelivery{ID: 1, EventType: domain.WebhookEventPing, Payload: []byte(`{}`)})
+
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusTemporaryRedirect, status)
+	assert.False(t, followed)
+}
+
+func TestHTTPSenderHidesTransportErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := srv.URL
+	srv.Close() // port tertutup: connection refused
+
+	_, err := webhook.NewHTTPSender(webhook.SenderConfig{AllowPrivateNetworks: true}).Send(context.Background(),
+		domain.Webhook{URL: url, Secret: "s"},
+		domain.WebhookDelivery{ID: 1, EventType: domain.WebhookEventPing, Payload: []byte(`{}`)})
+
+	if assert.Error(t, err) {
+		assert.NotContains(t, err.Error(), "127.0.0.1")
+		assert.NotContains(t, err.Error(), "refused")
+	}
+}
@@ -0,0 +1,34 @@
+package util
+
+import "net/netip"
+
+// Rentang khusus di luar yang sudah dikenali netip (loopback, private, link-local, multicast)
+var reservedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),     // "this network"
+	netip.MustParsePrefix("100.64.0.0/10"), // carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),  // IETF protocol assignments
+	netip.MustParsePrefix("192.0.2.0/24"),  // dokumentasi (TEST-NET-1)
+	netip.MustParsePrefix("198.18.0.0/15"), // benchmarking
+	netip.MustParsePrefix("198.51.100.0/24"),
+	netip.MustParsePrefix("203.0.113.0/24"),
+	netip.MustParsePrefix("240.0.0.0/4"),  // reserved & broadcast
+	netip.MustParsePrefix("64:ff9b::/96"), // NAT64: bisa menunjuk ke alamat IPv4 internal
+	netip.MustParsePrefix("2001:db8::/32"),
+	netip.MustParsePrefix("2002::/16"), // 6to4
+}
+
+// IsPublicIP bernilai false untuk alamat yang tidak boleh dituju request keluar atas nama
+// user (webhook): loopback, jaringan privat, link-local (termasuk metadata cloud
+// 169.254.169.254), multicast, dan rentang khusus lainnya
+func IsPublicIP(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+	for _, p := range reservedPrefixes {
+		if p.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
@@ -1,17 +1,40 @@
 package util
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"time"
+
 	"github.com/golang-jwt/jwt/v5"
 )
 
-// CreateAccessToken membuat JWT token baru
-func CreateAccessToken(userID int64, secretKey string, duration time.Duration) (string, error) {
+// CreateAccessToken membuat JWT token baru yang terikat ke sebuah session
+func CreateAccessToken(userID int64, sessionID int64, secretKey string, duration time.Duration) (string, error) {
 	claims := jwt.MapClaims{
 		"user_id": userID,
+		"sid":     sessionID,
 		"exp":     time.Now().Add(duration).Unix(),
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString([]byte(secretKey))
 }
+
+// GenerateOpaqueToken membuat token acak (untuk refresh token dsb.) beserta hash-nya.
+// Yang disimpan di database hanya hash; token mentah dikirim ke client.
+func GenerateOpaqueToken() (token string, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token = base64.RawURLEncoding.EncodeToString(b)
+	return token, HashToken(token), nil
+}
+
+// HashToken menghasilkan SHA-256 hex dari token mentah
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
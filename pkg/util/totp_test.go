@@ -0,0 +1,53 @@
+package util_test
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"simple-task-manager/pkg/util"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Vektor uji SHA1 dari RFC 6238 Appendix B (8 digit), dipotong ke 6 digit terakhir
+func TestTOTPCodeAt(t *testing.T) {
+	secret := strings.TrimRight(base32.StdEncoding.EncodeToString([]byte("12345678901234567890")), "=")
+
+	cases := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, tc := range cases {
+		code, err := util.TOTPCodeAt(secret, util.TOTPStep(time.Unix(tc.unix, 0)))
+		assert.NoError(t, err)
+		assert.Equal(t, tc.code, code, "unix time %d", tc.unix)
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	secret, err := util.GenerateTOTPSecret()
+	assert.NoError(t, err)
+
+	now := time.Now()
+	previous, _ := util.TOTPCodeAt(secret, util.TOTPStep(now)-1)
+
+	step, ok := util.ValidateTOTP(secret, previous, now, 1)
+	assert.True(t, ok)
+	assert.Equal(t, util.TOTPStep(now)-1, step)
+
+	old, _ := util.TOTPCodeAt(secret, util.TOTPStep(now)-3)
+	_, ok = util.ValidateTOTP(secret, old, now, 1)
+	assert.False(t, ok)
+
+	_, ok = util.ValidateTOTP(secret, "12345", now, 1)
+	assert.False(t, ok)
+}